@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/cmd/stand/utils"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"log"
+	"os"
+)
+
+func main() {
+	ctx := context.Background()
+	cfg := utils.LoadConfig()
+	logger := utils.GetLogger(os.Stdout, "cron")
+
+	sys, specifications, err := builder.BuildCore(ctx, logger, cfg.Core)
+	if err != nil {
+		log.Fatal("failed to build system: ", err)
+	}
+
+	deployment, err := builder.BuildCronDeployment(ctx, sys, specifications, logger, cfg.Services.Cron)
+	if err != nil {
+		log.Fatal("failed to build cron:", err)
+	}
+
+	deployer := service.NewDeployer()
+	deployer.Add(deployment.Service, deployment.Middlewares...)
+	if err := deployer.Deploy(ctx); err != nil {
+		log.Fatal("failed to deploy:", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/cmd/stand/utils"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"log"
+	"os"
+)
+
+func main() {
+	ctx := context.Background()
+	cfg := utils.LoadConfig()
+	logger := utils.GetLogger(os.Stdout, "event_spawner")
+
+	sys, specifications, err := builder.BuildCore(ctx, logger, cfg.Core)
+	if err != nil {
+		log.Fatal("failed to build system: ", err)
+	}
+
+	eventMappings, err := builder.BuildEventMappings(ctx, cfg.Core.EventMappings)
+	if err != nil {
+		log.Fatal("failed to build event mappings:", err)
+	}
+
+	deployment, err := builder.BuildEventSpawnerDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.EventSpawner)
+	if err != nil {
+		log.Fatal("failed to build event spawner:", err)
+	}
+
+	deployer := service.NewDeployer()
+	deployer.Add(deployment.Service, deployment.Middlewares...)
+	if err := deployer.Deploy(ctx); err != nil {
+		log.Fatal("failed to deploy:", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/cmd/stand/utils"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"log"
+	"os"
+)
+
+func main() {
+	ctx := context.Background()
+	cfg := utils.LoadConfig()
+	logger := utils.GetLogger(os.Stdout, "gc")
+
+	sys, _, err := builder.BuildCore(ctx, logger, cfg.Core)
+	if err != nil {
+		log.Fatal("failed to build system: ", err)
+	}
+
+	dependencies, taskToGroup, err := builder.BuildSchedulerStorages(ctx, logger, cfg.Services.Scheduler)
+	if err != nil {
+		log.Fatal("failed to build scheduler storages:", err)
+	}
+
+	resolvers, err := builder.BuildSchedulerResolvers(ctx, sys, logger, cfg.Services.Scheduler)
+	if err != nil {
+		log.Fatal("failed to build scheduler resolvers:", err)
+	}
+
+	deployment, err := builder.BuildGCDeployment(ctx, sys, dependencies, taskToGroup, resolvers, logger, cfg.Services.GC)
+	if err != nil {
+		log.Fatal("failed to build gc:", err)
+	}
+
+	deployer := service.NewDeployer()
+	deployer.Add(deployment.Service, deployment.Middlewares...)
+	if err := deployer.Deploy(ctx); err != nil {
+		log.Fatal("failed to deploy:", err)
+	}
+}
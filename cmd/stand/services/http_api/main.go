@@ -14,17 +14,17 @@ func main() {
 	cfg := utils.LoadConfig()
 	logger := utils.GetLogger(os.Stdout, "http_api")
 
-	sys, err := builder.BuildSystem(ctx, logger, cfg.Core.System)
+	sys, specifications, err := builder.BuildCore(ctx, logger, cfg.Core)
 	if err != nil {
 		log.Fatal("failed to build system: ", err)
 	}
 
-	specifications, err := builder.BuildSpecifications(ctx, cfg.Core.Specifications)
+	eventMappings, err := builder.BuildEventMappings(ctx, cfg.Core.EventMappings)
 	if err != nil {
-		log.Fatal("failed to build specifications:", err)
+		log.Fatal("failed to build event mappings:", err)
 	}
 
-	deployment, err := builder.BuildHttpApiDeployment(ctx, sys, specifications, logger, cfg.Services.HttpApi)
+	deployment, err := builder.BuildHttpApiDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.HttpApi)
 	if err != nil {
 		log.Fatal("failed to build http api:", err)
 	}
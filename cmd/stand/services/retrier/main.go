@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/cmd/stand/utils"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"log"
+	"os"
+)
+
+func main() {
+	ctx := context.Background()
+	cfg := utils.LoadConfig()
+	logger := utils.GetLogger(os.Stdout, "retrier")
+
+	sys, _, err := builder.BuildCore(ctx, logger, cfg.Core)
+	if err != nil {
+		log.Fatal("failed to build system: ", err)
+	}
+
+	deployment, err := builder.BuildRetrierDeployment(ctx, sys, logger, cfg.Services.Retrier)
+	if err != nil {
+		log.Fatal("failed to build retrier:", err)
+	}
+
+	deployer := service.NewDeployer()
+	deployer.Add(deployment.Service, deployment.Middlewares...)
+	if err := deployer.Deploy(ctx); err != nil {
+		log.Fatal("failed to deploy:", err)
+	}
+}
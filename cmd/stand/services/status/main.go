@@ -14,7 +14,7 @@ func main() {
 	cfg := utils.LoadConfig()
 	logger := utils.GetLogger(os.Stdout, "status")
 
-	sys, err := builder.BuildSystem(ctx, logger, cfg.Core.System)
+	sys, _, err := builder.BuildCore(ctx, logger, cfg.Core)
 	if err != nil {
 		log.Fatal("failed to build system: ", err)
 	}
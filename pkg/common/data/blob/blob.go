@@ -0,0 +1,38 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps binary payloads by keys
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrNotFound if there is no blob with the key
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete ignores missing blobs
+	Delete(ctx context.Context, keys ...string) error
+
+	// Open streams the blob, it returns ErrNotFound if there is no blob with the key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Create streams a blob, which is stored on Close
+	Create(ctx context.Context, key string) (io.WriteCloser, error)
+
+	// Keys lists keys of blobs that start with the prefix
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+var keyPattern = regexp.MustCompile(`^[a-zA-Z0-9_\-][a-zA-Z0-9_.\-]*$`)
+
+func validateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid blob key: '%s'", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newS3StandIn emulates the object API of an S3 compatible storage
+func newS3StandIn(t *testing.T, bucket, accessKey string) *httptest.Server {
+	var (
+		objects = map[string][]byte{}
+		mu      sync.Mutex
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+accessKey+"/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/"+bucket && r.URL.Query().Get("list-type") == "2" {
+			var keys []string
+			for key := range objects {
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			io.WriteString(w, "<ListBucketResult><IsTruncated>false</IsTruncated>")
+			for _, key := range keys {
+				io.WriteString(w, "<Contents><Key>"+key+"</Key></Contents>")
+			}
+			io.WriteString(w, "</ListBucketResult>")
+			return
+		}
+
+		key, ok := strings.CutPrefix(r.URL.Path, "/"+bucket+"/")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			hash := sha256.Sum256(data)
+			if r.Header.Get("x-amz-content-sha256") != hex.EncodeToString(hash[:]) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[key] = data
+		case http.MethodGet:
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create a file store: %s", err)
+	}
+
+	s3 := newS3StandIn(t, "resources", "access")
+
+	stores := map[string]Store{
+		"file": fileStore,
+		"s3": &S3Store{
+			Endpoint:  s3.URL,
+			Bucket:    "resources",
+			Region:    "us-east-1",
+			AccessKey: "access",
+			SecretKey: "secret",
+		},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if err := store.Put(ctx, "page-1", []byte("<html></html>")); err != nil {
+				t.Fatalf("failed to put: %s", err)
+			}
+
+			data, err := store.Get(ctx, "page-1")
+			if err != nil || string(data) != "<html></html>" {
+				t.Fatalf("unexpected blob: %q (%v)", data, err)
+			}
+
+			if err := store.Delete(ctx, "page-1", "missing"); err != nil {
+				t.Fatalf("failed to delete: %s", err)
+			}
+
+			if _, err := store.Get(ctx, "page-1"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got: %v", err)
+			}
+
+			writer, err := store.Create(ctx, "page-2")
+			if err != nil {
+				t.Fatalf("failed to create: %s", err)
+			}
+			for i := 0; i < 3; i++ {
+				io.WriteString(writer, "chunk;")
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("failed to close the writer: %s", err)
+			}
+
+			reader, err := store.Open(ctx, "page-2")
+			if err != nil {
+				t.Fatalf("failed to open: %s", err)
+			}
+			data, err = io.ReadAll(reader)
+			reader.Close()
+			if err != nil || string(data) != "chunk;chunk;chunk;" {
+				t.Fatalf("unexpected streamed blob: %q (%v)", data, err)
+			}
+
+			if err := store.Put(ctx, "page-3", []byte("<html></html>")); err != nil {
+				t.Fatalf("failed to put: %s", err)
+			}
+			keys, err := store.Keys(ctx, "page-")
+			if err != nil {
+				t.Fatalf("failed to list keys: %s", err)
+			}
+			sort.Strings(keys)
+			if strings.Join(keys, ",") != "page-2,page-3" {
+				t.Fatalf("unexpected keys: %v", keys)
+			}
+
+			if _, err := store.Open(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got: %v", err)
+			}
+
+			if err := store.Put(ctx, "../escape", nil); err == nil {
+				t.Fatal("an invalid key has been accepted")
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore keeps blobs as files in a directory
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (store *FileStore) Put(ctx context.Context, key string, data []byte) error {
+	writer, err := store.Create(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	writer.Write(data)
+
+	return writer.Close()
+}
+
+func (store *FileStore) Create(ctx context.Context, key string) (io.WriteCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	// the blob is written to a temporary file first, so readers never see partial data
+	file, err := os.CreateTemp(store.dir, "."+key+".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a file: %w", err)
+	}
+
+	return &fileWriter{file: file, path: store.path(key)}, nil
+}
+
+func (store *FileStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open the blob: %w", err)
+	}
+
+	return file, nil
+}
+
+func (store *FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(store.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read the blob: %w", err)
+	}
+
+	return data, nil
+}
+
+func (store *FileStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := validateKey(key); err != nil {
+			return err
+		}
+
+		if err := os.Remove(store.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete the blob '%s': %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (store *FileStore) Keys(ctx context.Context, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the directory: %w", err)
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		// temporary files of unfinished blobs start with a dot
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		keys = append(keys, entry.Name())
+	}
+
+	return keys, nil
+}
+
+func (store *FileStore) path(key string) string {
+	return filepath.Join(store.dir, key)
+}
+
+type fileWriter struct {
+	file *os.File
+	path string
+	// err is the first write error, the blob is not stored if it's set
+	err error
+}
+
+func (writer *fileWriter) Write(data []byte) (int, error) {
+	if writer.err != nil {
+		return 0, writer.err
+	}
+
+	n, err := writer.file.Write(data)
+	if err != nil {
+		writer.err = fmt.Errorf("failed to write the blob: %w", err)
+	}
+
+	return n, writer.err
+}
+
+func (writer *fileWriter) Close() error {
+	defer os.Remove(writer.file.Name())
+
+	if writer.err != nil {
+		writer.file.Close()
+		return writer.err
+	}
+
+	if err := writer.file.Close(); err != nil {
+		return fmt.Errorf("failed to close the file: %w", err)
+	}
+
+	if err := os.Rename(writer.file.Name(), writer.path); err != nil {
+		return fmt.Errorf("failed to rename the file: %w", err)
+	}
+
+	return nil
+}
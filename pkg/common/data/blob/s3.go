@@ -0,0 +1,297 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// S3Store keeps blobs in a bucket of an S3 compatible storage (AWS S3, MinIO, etc.).
+//
+// Objects are addressed in the path style ({endpoint}/{bucket}/{key}) and requests are signed with AWS Signature V4.
+type S3Store struct {
+	// Endpoint is the base URL of the storage (e.g. "http://localhost:9000")
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	// Client is http.DefaultClient if not set
+	Client *http.Client
+}
+
+func (store *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	return store.put(ctx, key, bytes.NewReader(data), int64(len(data)), sha256Hex(data))
+}
+
+func (store *S3Store) put(ctx context.Context, key string, body io.Reader, size int64, payloadHash string) error {
+	response, err := store.do(ctx, http.MethodPut, key, body, size, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put the blob: %s", readError(response))
+	}
+
+	return nil
+}
+
+func (store *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	reader, err := store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the blob: %w", err)
+	}
+
+	return data, nil
+}
+
+func (store *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	response, err := store.do(ctx, http.MethodGet, key, nil, 0, sha256Hex(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer response.Body.Close()
+		return nil, fmt.Errorf("failed to get the blob: %s", readError(response))
+	}
+}
+
+// Create buffers the blob in a temporary file, as uploads must be signed with the hash of the payload
+func (store *S3Store) Create(ctx context.Context, key string) (io.WriteCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "kantoku-blob-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+
+	return &s3Writer{
+		ctx:   ctx,
+		store: store,
+		key:   key,
+		file:  file,
+		hash:  sha256.New(),
+	}, nil
+}
+
+func (store *S3Store) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		response, err := store.do(ctx, http.MethodDelete, key, nil, 0, sha256Hex(nil))
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+
+		// deleting a missing object is not an error in S3
+		if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to delete the blob '%s': unexpected status %d", key, response.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// Keys lists objects with ListObjectsV2
+func (store *S3Store) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		endpoint := fmt.Sprintf("%s/%s?%s", strings.TrimRight(store.Endpoint, "/"), store.Bucket, query.Encode())
+		response, err := store.send(ctx, http.MethodGet, endpoint, nil, 0, sha256Hex(nil))
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != http.StatusOK {
+			err := fmt.Errorf("failed to list blobs: %s", readError(response))
+			response.Body.Close()
+			return nil, err
+		}
+
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(response.Body).Decode(&result)
+		response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the list of blobs: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (store *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	return store.send(ctx, method, fmt.Sprintf("%s/%s/%s", strings.TrimRight(store.Endpoint, "/"), store.Bucket, key), body, size, payloadHash)
+}
+
+func (store *S3Store) send(ctx context.Context, method, url string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a request: %w", err)
+	}
+	if body != nil {
+		request.ContentLength = size
+	}
+
+	store.sign(request, payloadHash, time.Now().UTC())
+
+	client := store.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make a request: %w", err)
+	}
+
+	return response, nil
+}
+
+// sign adds the AWS Signature V4 headers to the request
+func (store *S3Store) sign(request *http.Request, payloadHash string, now time.Time) {
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+	request.Header.Set("x-amz-date", amzDate)
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		"host:" + request.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, store.Region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+store.SecretKey), date)
+	key = hmacSHA256(key, store.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		store.AccessKey, scope, signedHeaders, signature))
+}
+
+type s3Writer struct {
+	ctx   context.Context
+	store *S3Store
+	key   string
+	file  *os.File
+	hash  hash.Hash
+	size  int64
+	err   error
+}
+
+func (writer *s3Writer) Write(data []byte) (int, error) {
+	if writer.err != nil {
+		return 0, writer.err
+	}
+
+	n, err := writer.file.Write(data)
+	writer.hash.Write(data[:n])
+	writer.size += int64(n)
+	if err != nil {
+		writer.err = fmt.Errorf("failed to buffer the blob: %w", err)
+	}
+
+	return n, writer.err
+}
+
+func (writer *s3Writer) Close() error {
+	defer os.Remove(writer.file.Name())
+	defer writer.file.Close()
+
+	if writer.err != nil {
+		return writer.err
+	}
+
+	if _, err := writer.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind the buffer: %w", err)
+	}
+
+	return writer.store.put(writer.ctx, writer.key, writer.file, writer.size, hex.EncodeToString(writer.hash.Sum(nil)))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func readError(response *http.Response) string {
+	data, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Sprintf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(data)))
+}
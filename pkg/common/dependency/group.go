@@ -1,6 +1,70 @@
 package dependency
 
+import "github.com/samber/lo"
+
+// Mode tells when a group is ready: as soon as the required amount of its dependencies is satisfied
+// or when it can't be satisfied anymore.
+//
+// A dependency is satisfied if it's resolved with OK or if it's optional and has failed.
+type Mode struct {
+	// Required amount of satisfied dependencies (all of them if not positive)
+	Required int
+	// Optional dependencies are satisfied even if they fail
+	Optional []string
+}
+
+func AllOf() Mode {
+	return Mode{}
+}
+
+func AnyOf() Mode {
+	return Mode{Required: 1}
+}
+
+func KOf(k int) Mode {
+	return Mode{Required: k}
+}
+
+// RequiredOf returns the amount of dependencies required in a group of n dependencies
+func (mode Mode) RequiredOf(n int) int {
+	if mode.Required <= 0 || mode.Required > n {
+		return n
+	}
+
+	return mode.Required
+}
+
 type Group struct {
 	ID           string
 	Dependencies []Dependency
+	Mode         Mode
+}
+
+// State reports whether the group is ready and, if so, whether it's satisfied
+func (group Group) State() (ready, satisfied bool) {
+	required := group.Mode.RequiredOf(len(group.Dependencies))
+
+	var ok, failed int
+	for _, dep := range group.Dependencies {
+		switch dep.Status {
+		case OK:
+			ok++
+		case Failed:
+			if lo.Contains(group.Mode.Optional, dep.ID) {
+				ok++
+			} else {
+				failed++
+			}
+		}
+	}
+
+	if ok >= required {
+		return true, true
+	}
+
+	if len(group.Dependencies)-failed < required {
+		return true, false
+	}
+
+	return false, false
 }
@@ -0,0 +1,39 @@
+package dependency
+
+import "testing"
+
+func TestGroupState(t *testing.T) {
+	deps := func(statuses ...Status) []Dependency {
+		result := make([]Dependency, 0, len(statuses))
+		for index, status := range statuses {
+			result = append(result, Dependency{ID: string(rune('a' + index)), Status: status})
+		}
+		return result
+	}
+
+	cases := []struct {
+		name      string
+		group     Group
+		ready     bool
+		satisfied bool
+	}{
+		{"all of: pending", Group{Dependencies: deps(OK, Pending)}, false, false},
+		{"all of: resolved", Group{Dependencies: deps(OK, OK)}, true, true},
+		{"all of: failed", Group{Dependencies: deps(Failed, Pending)}, true, false},
+		{"all of: optional failed", Group{Dependencies: deps(Failed, OK), Mode: Mode{Optional: []string{"a"}}}, true, true},
+		{"any of: first wins", Group{Dependencies: deps(Pending, OK, Pending), Mode: AnyOf()}, true, true},
+		{"any of: one failed", Group{Dependencies: deps(Failed, Pending), Mode: AnyOf()}, false, false},
+		{"any of: all failed", Group{Dependencies: deps(Failed, Failed), Mode: AnyOf()}, true, false},
+		{"k of n: waiting", Group{Dependencies: deps(OK, Failed, Pending), Mode: KOf(2)}, false, false},
+		{"k of n: unreachable", Group{Dependencies: deps(OK, Failed, Failed), Mode: KOf(2)}, true, false},
+		{"k of n: more than n", Group{Dependencies: deps(OK, OK), Mode: KOf(3)}, true, true},
+		{"empty", Group{}, true, true},
+	}
+
+	for _, c := range cases {
+		ready, satisfied := c.group.State()
+		if ready != c.ready || satisfied != c.satisfied {
+			t.Errorf("%s: expected (ready=%t, satisfied=%t), got (%t, %t)", c.name, c.ready, c.satisfied, ready, satisfied)
+		}
+	}
+}
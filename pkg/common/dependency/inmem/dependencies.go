@@ -10,14 +10,22 @@ import (
 	"time"
 )
 
+var (
+	_ dependency.Manager                   = (*Manager)(nil)
+	_ dependency.UninitializedGroupsFinder = (*Manager)(nil)
+)
+
 var validStatuses = []dependency.Status{
 	dependency.OK,
 	dependency.Failed,
 }
 
 type groupInfo struct {
-	deps []string
-	done bool
+	deps        []string
+	mode        dependency.Mode
+	initialized bool
+	done        bool
+	createdAt   time.Time
 }
 
 type Manager struct {
@@ -62,14 +70,7 @@ func (manager *Manager) LoadGroups(ctx context.Context, ids ...string) ([]depend
 			continue
 		}
 
-		group := dependency.Group{
-			ID:           id,
-			Dependencies: make([]dependency.Dependency, 0, len(info.deps)),
-		}
-		for _, depId := range info.deps {
-			group.Dependencies = append(group.Dependencies, manager.dependencies[depId])
-		}
-		result = append(result, group)
+		result = append(result, manager.group(id, info))
 	}
 
 	return result, nil
@@ -109,23 +110,89 @@ func (manager *Manager) NewDependencies(ctx context.Context, n int) ([]dependenc
 	return result, nil
 }
 
-func (manager *Manager) NewGroup(ctx context.Context, ids ...string) (groupId string, err error) {
+func (manager *Manager) DeleteDependencies(ctx context.Context, ids ...string) error {
 	manager.mu.Lock()
 	defer manager.mu.Unlock()
 
+	for _, id := range ids {
+		delete(manager.dependencies, id)
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewGroup(ctx context.Context) (groupId string, err error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	groupId = uuid.New().String()
+	manager.groups[groupId] = groupInfo{createdAt: time.Now()}
+
+	return groupId, nil
+}
+
+func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, mode dependency.Mode, ids ...string) error {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	info, ok := manager.groups[groupId]
+	if !ok || info.initialized {
+		return dependency.ErrGroupInitialized
+	}
+
 	for _, id := range ids {
 		if _, ok := manager.dependencies[id]; !ok {
-			return "", fmt.Errorf("dependency not found: '%s'", id)
+			return fmt.Errorf("dependency not found: '%s'", id)
 		}
 	}
 
-	groupId = uuid.New().String()
 	manager.groups[groupId] = groupInfo{
-		deps: ids,
-		done: false,
+		deps:        ids,
+		mode:        mode,
+		initialized: true,
+		createdAt:   info.createdAt,
 	}
 
-	return groupId, nil
+	return nil
+}
+
+func (manager *Manager) DeleteGroups(ctx context.Context, groupIds ...string) error {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	for _, groupId := range groupIds {
+		info, ok := manager.groups[groupId]
+		if !ok {
+			continue
+		}
+
+		for _, depId := range info.deps {
+			delete(manager.dependencies, depId)
+		}
+		delete(manager.groups, groupId)
+	}
+
+	return nil
+}
+
+func (manager *Manager) UninitializedGroups(ctx context.Context, age time.Duration, limit int) ([]string, error) {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+
+	createdBefore := time.Now().Add(-age)
+
+	var result []string
+	for id, info := range manager.groups {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if info.initialized || !info.createdAt.Before(createdBefore) {
+			continue
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
 }
 
 func (manager *Manager) ReadyGroups(ctx context.Context) (<-chan string, error) {
@@ -133,11 +200,6 @@ func (manager *Manager) ReadyGroups(ctx context.Context) (<-chan string, error)
 
 	go manager.pollReadyGroups(ctx, channel)
 
-	go func(ctx context.Context) {
-		<-ctx.Done()
-		close(channel)
-	}(ctx)
-
 	return channel, nil
 }
 
@@ -146,19 +208,11 @@ func (manager *Manager) syncGroups() (result []string) {
 	defer manager.mu.Unlock()
 
 	for id, info := range manager.groups {
-		if info.done {
+		if info.done || !info.initialized {
 			continue
 		}
 
-		resolved := true
-		for _, depId := range info.deps {
-			if manager.dependencies[depId].Status == dependency.Pending {
-				resolved = false
-				break
-			}
-		}
-
-		if !resolved {
+		if ready, _ := manager.group(id, info).State(); !ready {
 			continue
 		}
 
@@ -171,8 +225,30 @@ func (manager *Manager) syncGroups() (result []string) {
 	return
 }
 
+func (manager *Manager) group(id string, info groupInfo) dependency.Group {
+	group := dependency.Group{
+		ID:           id,
+		Dependencies: make([]dependency.Dependency, 0, len(info.deps)),
+		Mode:         info.mode,
+	}
+	for _, depId := range info.deps {
+		dep, ok := manager.dependencies[depId]
+		if !ok {
+			dep = dependency.Dependency{ID: depId, Status: dependency.DoesNotExist}
+		}
+		group.Dependencies = append(group.Dependencies, dep)
+	}
+
+	return group
+}
+
 func (manager *Manager) pollReadyGroups(ctx context.Context, channel chan<- string) {
+	// the channel is closed by the sender, so nothing is ever sent to a closed channel
+	defer close(channel)
+
 	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
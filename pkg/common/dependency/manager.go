@@ -2,19 +2,42 @@ package dependency
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrGroupInitialized is returned by InitializeGroup if the group has been initialized (or doesn't exist)
+var ErrGroupInitialized = errors.New("group is already initialized or doesn't exist")
+
 // TODO: Add options to NewDependency
 // TODO: Add methods "Fail" and "OK"
-// TODO: Make groups Fail-able
-
+// Manager tracks groups of dependencies.
+//
+// A group is ready as soon as its mode is satisfied or can't be satisfied anymore (see Group.State).
 type Manager interface {
 	LoadDependencies(ctx context.Context, ids ...string) ([]Dependency, error)
 	LoadGroups(ctx context.Context, ids ...string) ([]Group, error)
 	Resolve(ctx context.Context, values ...Dependency) error
 	NewDependencies(ctx context.Context, n int) ([]Dependency, error)
+	// DeleteDependencies deletes dependencies that are not used by any group
+	DeleteDependencies(ctx context.Context, ids ...string) error
 	// NewGroup generates id for a group, which then can be passed to SaveGroup
 	NewGroup(ctx context.Context) (groupId string, err error)
-	InitializeGroup(ctx context.Context, groupId string, dependencyIds ...string) error
+	InitializeGroup(ctx context.Context, groupId string, mode Mode, dependencyIds ...string) error
 	ReadyGroups(ctx context.Context) (<-chan string, error)
+	// DeleteGroups deletes the groups with their dependencies
+	DeleteGroups(ctx context.Context, groupIds ...string) error
+}
+
+// UninitializedGroupsFinder is implemented by managers that can find groups that have never been initialized
+// (e.g. the ones left by a registration interrupted between NewGroup and saving the group)
+type UninitializedGroupsFinder interface {
+	// UninitializedGroups returns up to limit groups created more than age ago that are still not initialized
+	UninitializedGroups(ctx context.Context, age time.Duration, limit int) ([]string, error)
+}
+
+// GroupAcknowledger is implemented by managers that deliver ready groups until they are acknowledged,
+// groups received from ReadyGroups must be passed to AckGroups once they are handled
+type GroupAcknowledger interface {
+	AckGroups(ctx context.Context, groupIds ...string) error
 }
@@ -13,7 +13,10 @@ import (
 	"time"
 )
 
-var _ dependency.Manager = (*Manager)(nil)
+var (
+	_ dependency.Manager                   = (*Manager)(nil)
+	_ dependency.UninitializedGroupsFinder = (*Manager)(nil)
+)
 
 type Manager struct {
 	Client *pgxpool.Pool
@@ -22,7 +25,7 @@ type Manager struct {
 }
 
 func (manager *Manager) LoadDependencies(ctx context.Context, ids ...string) ([]dependency.Dependency, error) {
-	sql := `select id, status from dependencies where id in ($1)`
+	sql := `select id, status from dependencies where id = any ($1)`
 
 	rows, err := manager.Client.Query(ctx, sql, ids)
 	if err != nil {
@@ -44,10 +47,11 @@ func (manager *Manager) LoadDependencies(ctx context.Context, ids ...string) ([]
 
 func (manager *Manager) LoadGroups(ctx context.Context, ids ...string) ([]dependency.Group, error) {
 	sql := `
-			select group_id, dependency_id, d.status
+			select gd.group_id, gd.dependency_id, d.status, gd.optional, g.required
 			from group_dependencies gd
 			join dependencies d on d.id = gd.dependency_id
-			where group_id in ($1)
+			join groups g on g.id = gd.group_id
+			where gd.group_id = any ($1)
 	`
 
 	rows, err := manager.Client.Query(ctx, sql, ids)
@@ -58,16 +62,27 @@ func (manager *Manager) LoadGroups(ctx context.Context, ids ...string) ([]depend
 	result := make(map[string]dependency.Group, len(ids))
 
 	for rows.Next() {
-		var groupId, dependencyId, status string
+		var (
+			groupId, dependencyId, status string
+			optional                      bool
+			required                      int
+		)
 
-		if err := rows.Scan(&groupId, &dependencyId, &status); err != nil {
+		if err := rows.Scan(&groupId, &dependencyId, &status, &optional, &required); err != nil {
 			return nil, fmt.Errorf("failed to scane: %w", err)
 		}
 		if _, ok := result[groupId]; !ok {
-			result[groupId] = dependency.Group{ID: groupId}
+			result[groupId] = dependency.Group{
+				ID:   groupId,
+				Mode: dependency.Mode{Required: required},
+			}
 		}
 		group := result[groupId]
 
+		if optional {
+			group.Mode.Optional = append(group.Mode.Optional, dependencyId)
+		}
+
 		group.Dependencies = append(group.Dependencies, dependency.Dependency{
 			ID:     dependencyId,
 			Status: dependency.Status(status),
@@ -98,19 +113,27 @@ func (manager *Manager) Resolve(ctx context.Context, values ...dependency.Depend
 		return dep.Status
 	})
 
+	// pending is the amount of satisfied dependencies a group still needs,
+	// tolerated is the amount of failures it can still take (see InitializeGroup)
 	sql := `
 		with cte as (
 			update dependencies
 				set status = $1
 				where id = any ($2) and status = $3
-				returning *)
+				returning *),
+		resolved as (
+			select gd.group_id,
+				   count(*) filter (where $4::boolean or gd.optional)     as satisfied,
+				   count(*) filter (where not ($4::boolean or gd.optional)) as failed
+			from cte
+					 join group_dependencies gd
+						  on gd.dependency_id = cte.id
+			group by gd.group_id)
 		update groups
-		set pending = pending - 1
-		where id in
-			  (select distinct gd.group_id
-			   from cte
-						join group_dependencies gd
-							 on gd.dependency_id = cte.id)`
+		set pending   = pending - resolved.satisfied,
+			tolerated = tolerated - resolved.failed
+		from resolved
+		where groups.id = resolved.group_id`
 
 	tx, err := manager.Client.Begin(ctx)
 	if err != nil {
@@ -122,7 +145,7 @@ func (manager *Manager) Resolve(ctx context.Context, values ...dependency.Depend
 		ids := lo.Map(_deps, func(dep dependency.Dependency, _ int) string {
 			return dep.ID
 		})
-		if _, err := tx.Exec(ctx, sql, status, ids, dependency.Pending); err != nil {
+		if _, err := tx.Exec(ctx, sql, status, ids, dependency.Pending, status == dependency.OK); err != nil {
 			return fmt.Errorf("failed to resolve dependencies (status=%s): %w", status, err)
 		}
 	}
@@ -161,6 +184,18 @@ func (manager *Manager) NewDependencies(ctx context.Context, n int) ([]dependenc
 	return newDependencies, nil
 }
 
+func (manager *Manager) DeleteDependencies(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := manager.Client.Exec(ctx, `DELETE FROM dependencies WHERE id = any ($1)`, ids); err != nil {
+		return fmt.Errorf("failed to delete dependencies: %w", err)
+	}
+
+	return nil
+}
+
 func (manager *Manager) NewGroup(ctx context.Context) (groupId string, err error) {
 	groupId = manager.generateNewID()
 
@@ -172,8 +207,8 @@ func (manager *Manager) NewGroup(ctx context.Context) (groupId string, err error
 
 	// Initializing the group
 	groupCreationQuery := `
-		INSERT INTO groups (id, pending, status) 
-		VALUES ($1, 0, $2)
+		INSERT INTO groups (id, pending, tolerated, required, status, updated_at) 
+		VALUES ($1, 0, 0, 0, $2, now())
 	`
 
 	_, err = tx.Exec(ctx, groupCreationQuery, groupId, GroupCreatedStatus)
@@ -188,7 +223,7 @@ func (manager *Manager) NewGroup(ctx context.Context) (groupId string, err error
 	return groupId, nil
 }
 
-func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, ids ...string) error {
+func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, mode dependency.Mode, ids ...string) error {
 	tx, err := manager.Client.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin a transaction: %w", err)
@@ -204,37 +239,46 @@ func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, ids
 		return fmt.Errorf("failed to update the group status: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("group is already initialized or doesn't exist")
+		return dependency.ErrGroupInitialized
 	}
 
 	// Initializing group dependencies
 	_, err = tx.CopyFrom(ctx,
 		pgx.Identifier{"group_dependencies"},
-		[]string{"dependency_id", "group_id"},
+		[]string{"dependency_id", "group_id", "optional"},
 		pgx.CopyFromRows(
 			lo.Map(ids, func(id string, _ int) []any {
-				return []any{id, groupId}
+				return []any{id, groupId, lo.Contains(mode.Optional, id)}
 			})),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert group's dependencies: %w", err)
 	}
 
-	// Updating the group status
+	// Updating the group status (dependencies might have been resolved before the initialization)
 	groupStatusUpdateQuery := `
 		UPDATE groups
-		SET pending = (
-		    SELECT COUNT(*) FROM group_dependencies gd
+		SET required  = $4,
+		    pending   = $4 - s.satisfied,
+		    tolerated = $5 - $4 - s.failed,
+		    status    = $1
+		FROM (
+		    SELECT COUNT(*) FILTER (WHERE d.status = $2 OR (d.status = $3 AND gd.optional)) AS satisfied,
+		           COUNT(*) FILTER (WHERE d.status = $3 AND NOT gd.optional) AS failed
+		    FROM group_dependencies gd
 				JOIN dependencies d ON d.id = gd.dependency_id
-				WHERE d.status = $2 and gd.group_id = $3
-		), status = $1
-		WHERE id = $3
+				WHERE gd.group_id = $6
+		) s
+		WHERE id = $6
 	`
 
 	_, err = tx.Exec(ctx,
 		groupStatusUpdateQuery,
 		GroupWaitingStatus,
-		dependency.Pending,
+		dependency.OK,
+		dependency.Failed,
+		mode.RequiredOf(len(ids)),
+		len(ids),
 		groupId,
 	)
 	if err != nil {
@@ -248,6 +292,71 @@ func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, ids
 	return nil
 }
 
+func (manager *Manager) DeleteGroups(ctx context.Context, groupIds ...string) error {
+	if len(groupIds) == 0 {
+		return nil
+	}
+
+	tx, err := manager.Client.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dependencies
+		WHERE id IN (SELECT dependency_id FROM group_dependencies WHERE group_id = any ($1))
+	`, groupIds)
+	if err != nil {
+		return fmt.Errorf("failed to delete dependencies: %w", err)
+	}
+
+	// group_dependencies are deleted by the cascade
+	if _, err := tx.Exec(ctx, `DELETE FROM groups WHERE id = any ($1)`, groupIds); err != nil {
+		return fmt.Errorf("failed to delete groups: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func (manager *Manager) UninitializedGroups(ctx context.Context, age time.Duration, limit int) ([]string, error) {
+	// groups created before updated_at had been set are old enough
+	sql := `
+		SELECT id FROM groups
+		WHERE status = $1 AND (updated_at IS NULL OR updated_at < now() - make_interval(secs => $2))
+		LIMIT $3
+	`
+
+	var limitArg any
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := manager.Client.Query(ctx, sql, GroupCreatedStatus, age.Seconds(), limitArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		result = append(result, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return result, nil
+}
+
 func (manager *Manager) ReadyGroups(ctx context.Context) (<-chan string, error) {
 	channel := make(chan string, 256)
 
@@ -294,7 +403,7 @@ func (manager *Manager) loadReadyGroups(ctx context.Context, limit int) ([]strin
 		update groups
 		set status = $1
 		where id in (select id from groups 
-		                       where status = $2 and pending = 0
+		                       where status = $2 and (pending <= 0 or tolerated < 0)
 		                       limit $3)
 		returning id
 	`
@@ -0,0 +1,17 @@
+-- upgrades tables created by init.sql before groups got modes (all-of, any-of, k-of-n and optional dependencies)
+
+ALTER TABLE groups
+    ADD COLUMN IF NOT EXISTS tolerated int,
+    ADD COLUMN IF NOT EXISTS required  int;
+
+ALTER TABLE group_dependencies
+    ADD COLUMN IF NOT EXISTS optional boolean NOT NULL DEFAULT false;
+
+-- existing groups require all of their dependencies and can't take any failures
+UPDATE groups
+SET required = (SELECT count(*) FROM group_dependencies gd WHERE gd.group_id = groups.id)
+WHERE required IS NULL;
+
+UPDATE groups
+SET tolerated = 0
+WHERE tolerated IS NULL;
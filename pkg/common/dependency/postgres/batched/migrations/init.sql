@@ -9,6 +9,8 @@ CREATE TABLE groups
 (
     id         varchar(255),
     pending    int,
+    tolerated  int,
+    required   int,
     status     varchar(16),
     updated_at timestamp,
     PRIMARY KEY (id)
@@ -18,6 +20,7 @@ CREATE TABLE group_dependencies
 (
     dependency_id varchar(255),
     group_id      varchar(255),
+    optional      boolean NOT NULL DEFAULT false,
     PRIMARY KEY (dependency_id, group_id),
     CONSTRAINT fk_group
         FOREIGN KEY (group_id)
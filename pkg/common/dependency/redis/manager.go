@@ -0,0 +1,505 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ dependency.Manager                   = (*Manager)(nil)
+	_ dependency.UninitializedGroupsFinder = (*Manager)(nil)
+	_ dependency.GroupAcknowledger         = (*Manager)(nil)
+)
+
+const (
+	GroupCreatedStatus = "created"
+	GroupWaitingStatus = "waiting"
+	GroupReadyStatus   = "ready"
+)
+
+// Manager keeps dependencies and groups in Redis.
+//
+// Dependencies are resolved and groups are updated atomically by Lua scripts,
+// ready groups are pushed to a list, so ReadyGroups doesn't poll.
+// Every consumer (a ReadyGroups call) moves popped groups to its own processing list and removes them from it
+// once they are acknowledged (see AckGroups). Consumers hold leases that they renew while they are running,
+// groups of a stopped consumer are requeued right away and the ones of a lost consumer are requeued
+// when its lease expires, so every group is delivered at least once (and usually exactly once,
+// even if there are several consumers).
+type Manager struct {
+	Client redis.UniversalClient
+	// Prefix of the keys ("dependencies" if empty)
+	Prefix string
+	// BlockTimeout limits waiting for ready groups (1s if not set)
+	BlockTimeout time.Duration
+	// Lease is the time after which groups of a consumer that has stopped renewing its lease are requeued (30s if not set)
+	Lease  time.Duration
+	Logger *slog.Logger
+
+	mu sync.Mutex
+	// delivered maps groups that have not been acknowledged yet to the processing lists they are kept in
+	delivered map[string]string
+}
+
+func (manager *Manager) LoadDependencies(ctx context.Context, ids ...string) ([]dependency.Dependency, error) {
+	ids = lo.Uniq(ids)
+	if len(ids) == 0 {
+		return []dependency.Dependency{}, nil
+	}
+
+	statuses, err := manager.Client.MGet(ctx, lo.Map(ids, func(id string, _ int) string {
+		return manager.dependencyKey(id)
+	})...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	result := make([]dependency.Dependency, 0, len(ids))
+	for i, status := range statuses {
+		status, ok := status.(string)
+		if !ok {
+			continue
+		}
+
+		result = append(result, dependency.Dependency{
+			ID:     ids[i],
+			Status: dependency.Status(status),
+		})
+	}
+
+	return result, nil
+}
+
+func (manager *Manager) LoadGroups(ctx context.Context, ids ...string) ([]dependency.Group, error) {
+	ids = lo.Uniq(ids)
+
+	pipe := manager.Client.Pipeline()
+	fields := make([]*redis.SliceCmd, len(ids))
+	dependencyIds := make([]*redis.StringSliceCmd, len(ids))
+	optional := make([]*redis.StringSliceCmd, len(ids))
+	for i, id := range ids {
+		key := manager.groupKey(id)
+		fields[i] = pipe.HMGet(ctx, key, "status", "required")
+		dependencyIds[i] = pipe.LRange(ctx, key+":dependencies", 0, -1)
+		optional[i] = pipe.SMembers(ctx, key+":optional")
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to load groups: %w", err)
+	}
+
+	result := make([]dependency.Group, 0, len(ids))
+	for i, id := range ids {
+		values := fields[i].Val()
+		if len(values) == 0 || values[0] == nil {
+			continue
+		}
+
+		group := dependency.Group{
+			ID:   id,
+			Mode: dependency.Mode{Optional: optional[i].Val()},
+		}
+		if raw, ok := values[1].(string); ok {
+			required, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the required amount of dependencies (group='%s'): %w", id, err)
+			}
+			group.Mode.Required = required
+		}
+
+		deps, err := manager.LoadDependencies(ctx, dependencyIds[i].Val()...)
+		if err != nil {
+			return nil, err
+		}
+		statuses := lo.SliceToMap(deps, func(dep dependency.Dependency) (string, dependency.Status) {
+			return dep.ID, dep.Status
+		})
+
+		for _, depId := range dependencyIds[i].Val() {
+			status, ok := statuses[depId]
+			if !ok {
+				status = dependency.DoesNotExist
+			}
+			group.Dependencies = append(group.Dependencies, dependency.Dependency{ID: depId, Status: status})
+		}
+
+		result = append(result, group)
+	}
+
+	return result, nil
+}
+
+func (manager *Manager) Resolve(ctx context.Context, values ...dependency.Dependency) error {
+	validStatuses := []dependency.Status{
+		dependency.OK,
+		dependency.Failed,
+	}
+
+	values = lo.UniqBy(
+		lo.Filter(values, func(item dependency.Dependency, _ int) bool {
+			return lo.Contains(validStatuses, item.Status)
+		}),
+		func(item dependency.Dependency) string {
+			return item.ID
+		},
+	)
+
+	status2deps := lo.GroupBy(values, func(dep dependency.Dependency) dependency.Status {
+		return dep.Status
+	})
+
+	for status, deps := range status2deps {
+		keys := append([]string{manager.readyKey()}, lo.Map(deps, func(dep dependency.Dependency, _ int) string {
+			return manager.dependencyKey(dep.ID)
+		})...)
+		args := append([]any{manager.prefix(), string(status)}, lo.Map(deps, func(dep dependency.Dependency, _ int) any {
+			return dep.ID
+		})...)
+
+		if err := resolveScript.Run(ctx, manager.Client, keys, args...).Err(); err != nil {
+			return fmt.Errorf("failed to resolve dependencies (status=%s): %w", status, err)
+		}
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewDependencies(ctx context.Context, n int) ([]dependency.Dependency, error) {
+	if n <= 0 {
+		return []dependency.Dependency{}, nil
+	}
+
+	newDependencies := lo.Times(n, func(_ int) dependency.Dependency {
+		return dependency.Dependency{
+			ID:     manager.generateNewID(),
+			Status: dependency.Pending,
+		}
+	})
+
+	pairs := make([]any, 0, 2*n)
+	for _, dep := range newDependencies {
+		pairs = append(pairs, manager.dependencyKey(dep.ID), string(dep.Status))
+	}
+
+	if err := manager.Client.MSet(ctx, pairs...).Err(); err != nil {
+		return nil, fmt.Errorf("failed to insert dependencies: %w", err)
+	}
+
+	return newDependencies, nil
+}
+
+func (manager *Manager) DeleteDependencies(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := lo.Map(ids, func(id string, _ int) string { return manager.dependencyKey(id) })
+	if err := manager.Client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete dependencies: %w", err)
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewGroup(ctx context.Context) (groupId string, err error) {
+	groupId = manager.generateNewID()
+
+	// created groups are indexed by the creation time, so the ones that are never initialized can be found
+	pipe := manager.Client.TxPipeline()
+	pipe.HSet(ctx, manager.groupKey(groupId), "status", GroupCreatedStatus)
+	pipe.ZAdd(ctx, manager.createdKey(), redis.Z{Score: float64(time.Now().UnixMilli()), Member: groupId})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to create a group: %w", err)
+	}
+
+	return groupId, nil
+}
+
+func (manager *Manager) InitializeGroup(ctx context.Context, groupId string, mode dependency.Mode, ids ...string) error {
+	optionalFlags := strings.Join(lo.Map(ids, func(id string, _ int) string {
+		if lo.Contains(mode.Optional, id) {
+			return "1"
+		}
+		return "0"
+	}), "")
+
+	keys := append(
+		[]string{manager.groupKey(groupId), manager.readyKey()},
+		lo.Map(ids, func(id string, _ int) string { return manager.dependencyKey(id) })...,
+	)
+	args := append(
+		[]any{manager.prefix(), groupId, mode.RequiredOf(len(ids)), optionalFlags},
+		lo.Map(ids, func(id string, _ int) any { return id })...,
+	)
+
+	result, err := initializeScript.Run(ctx, manager.Client, keys, args...).Int()
+	if err != nil {
+		return fmt.Errorf("failed to initialize the group: %w", err)
+	}
+	if result == 1 {
+		return dependency.ErrGroupInitialized
+	}
+
+	return nil
+}
+
+func (manager *Manager) DeleteGroups(ctx context.Context, groupIds ...string) error {
+	if len(groupIds) == 0 {
+		return nil
+	}
+
+	keys := lo.Map(groupIds, func(id string, _ int) string { return manager.groupKey(id) })
+
+	if err := deleteScript.Run(ctx, manager.Client, keys, manager.prefix()).Err(); err != nil {
+		return fmt.Errorf("failed to delete groups: %w", err)
+	}
+
+	return nil
+}
+
+func (manager *Manager) UninitializedGroups(ctx context.Context, age time.Duration, limit int) ([]string, error) {
+	candidates, err := manager.Client.ZRangeByScore(ctx, manager.createdKey(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().Add(-age).UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load created groups: %w", err)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	pipe := manager.Client.Pipeline()
+	statuses := lo.Map(candidates, func(id string, _ int) *redis.StringCmd {
+		return pipe.HGet(ctx, manager.groupKey(id), "status")
+	})
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to load statuses of groups: %w", err)
+	}
+
+	// the index is cleaned up lazily: initialized and deleted groups are removed from it here
+	var result, outdated []string
+	for i, id := range candidates {
+		if statuses[i].Val() == GroupCreatedStatus {
+			result = append(result, id)
+		} else {
+			outdated = append(outdated, id)
+		}
+	}
+
+	if len(outdated) > 0 {
+		if err := manager.Client.ZRem(ctx, manager.createdKey(), lo.ToAnySlice(outdated)...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to clean up the index of created groups: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (manager *Manager) ReadyGroups(ctx context.Context) (<-chan string, error) {
+	consumer := manager.generateNewID()
+
+	if err := manager.renewLease(ctx, consumer); err != nil {
+		return nil, err
+	}
+
+	// the handoff is unbuffered, so only the groups received by the caller are held by the consumer
+	channel := make(chan string)
+
+	go manager.keepLease(ctx, consumer)
+	go manager.popReadyGroups(ctx, consumer, channel)
+
+	return channel, nil
+}
+
+// AckGroups removes the delivered groups from the processing lists, so they are not delivered again
+func (manager *Manager) AckGroups(ctx context.Context, groupIds ...string) error {
+	manager.mu.Lock()
+	processing := make(map[string][]string)
+	for _, id := range groupIds {
+		if key, ok := manager.delivered[id]; ok {
+			processing[key] = append(processing[key], id)
+			delete(manager.delivered, id)
+		}
+	}
+	manager.mu.Unlock()
+
+	if len(processing) == 0 {
+		return nil
+	}
+
+	pipe := manager.Client.Pipeline()
+	for key, ids := range processing {
+		for _, id := range ids {
+			pipe.LRem(ctx, key, 1, id)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to acknowledge groups: %w", err)
+	}
+
+	return nil
+}
+
+func (manager *Manager) popReadyGroups(ctx context.Context, consumer string, channel chan<- string) {
+	// the channel is closed by the sender, so nothing is ever sent to a closed channel
+	defer close(channel)
+	defer manager.release(context.WithoutCancel(ctx), consumer)
+
+	timeout := manager.BlockTimeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+
+	processing := manager.processingKey(consumer)
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// the group stays in the processing list until it's acknowledged
+		groupId, err := manager.Client.BLMove(ctx, manager.readyKey(), processing, "LEFT", "RIGHT", timeout).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			manager.Logger.Error("failed to pop ready groups",
+				slog.String("error", err.Error()))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(timeout):
+			}
+			continue
+		}
+
+		manager.mu.Lock()
+		if manager.delivered == nil {
+			manager.delivered = make(map[string]string)
+		}
+		manager.delivered[groupId] = processing
+		manager.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case channel <- groupId:
+		}
+	}
+}
+
+// keepLease renews the lease of the consumer and requeues groups of consumers with expired leases
+func (manager *Manager) keepLease(ctx context.Context, consumer string) {
+	ticker := time.NewTicker(manager.lease() / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := manager.renewLease(ctx, consumer); err != nil && ctx.Err() == nil {
+				manager.Logger.Error("failed to renew the lease",
+					slog.String("consumer", consumer),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func (manager *Manager) renewLease(ctx context.Context, consumer string) error {
+	now := time.Now()
+
+	member := redis.Z{Score: float64(now.Add(manager.lease()).UnixMilli()), Member: consumer}
+	if err := manager.Client.ZAdd(ctx, manager.consumersKey(), member).Err(); err != nil {
+		return fmt.Errorf("failed to renew the lease: %w", err)
+	}
+
+	keys := []string{manager.consumersKey(), manager.readyKey()}
+	if err := requeueScript.Run(ctx, manager.Client, keys, manager.prefix(), now.UnixMilli()).Err(); err != nil {
+		return fmt.Errorf("failed to requeue groups of expired consumers: %w", err)
+	}
+
+	return nil
+}
+
+// release requeues the groups that the stopped consumer has not acknowledged
+func (manager *Manager) release(ctx context.Context, consumer string) {
+	processing := manager.processingKey(consumer)
+
+	manager.mu.Lock()
+	for id, key := range manager.delivered {
+		if key == processing {
+			delete(manager.delivered, id)
+		}
+	}
+	manager.mu.Unlock()
+
+	keys := []string{manager.consumersKey(), manager.readyKey(), processing}
+	if err := releaseScript.Run(ctx, manager.Client, keys, consumer).Err(); err != nil {
+		manager.Logger.Error("failed to requeue unacknowledged groups",
+			slog.String("consumer", consumer),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (manager *Manager) lease() time.Duration {
+	if manager.Lease <= 0 {
+		return 30 * time.Second
+	}
+	return manager.Lease
+}
+
+func (manager *Manager) prefix() string {
+	prefix := manager.Prefix
+	if prefix == "" {
+		prefix = "dependencies"
+	}
+
+	// the hash tag keeps every key in the same cluster slot, so scripts can access them
+	return "{" + prefix + "}"
+}
+
+func (manager *Manager) dependencyKey(id string) string {
+	return manager.prefix() + ":dependency:" + id
+}
+
+func (manager *Manager) groupKey(id string) string {
+	return manager.prefix() + ":group:" + id
+}
+
+func (manager *Manager) readyKey() string {
+	return manager.prefix() + ":ready"
+}
+
+func (manager *Manager) createdKey() string {
+	return manager.prefix() + ":created"
+}
+
+func (manager *Manager) processingKey(consumer string) string {
+	return manager.prefix() + ":processing:" + consumer
+}
+
+func (manager *Manager) consumersKey() string {
+	return manager.prefix() + ":consumers"
+}
+
+func (manager *Manager) generateNewID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
@@ -0,0 +1,314 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/redis/go-redis/v9"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	server := miniredis.RunT(t)
+
+	return &Manager{
+		Client:       redis.NewClient(&redis.Options{Addr: server.Addr()}),
+		BlockTimeout: 50 * time.Millisecond,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+// the cases mirror the ones of dependency.Group.State
+func TestGroupState(t *testing.T) {
+	cases := []struct {
+		name      string
+		statuses  []dependency.Status
+		mode      dependency.Mode
+		optional  []int
+		ready     bool
+		satisfied bool
+	}{
+		{"all of: pending", []dependency.Status{dependency.OK, dependency.Pending}, dependency.AllOf(), nil, false, false},
+		{"all of: resolved", []dependency.Status{dependency.OK, dependency.OK}, dependency.AllOf(), nil, true, true},
+		{"all of: failed", []dependency.Status{dependency.Failed, dependency.Pending}, dependency.AllOf(), nil, true, false},
+		{"all of: optional failed", []dependency.Status{dependency.Failed, dependency.OK}, dependency.AllOf(), []int{0}, true, true},
+		{"any of: first wins", []dependency.Status{dependency.Pending, dependency.OK, dependency.Pending}, dependency.AnyOf(), nil, true, true},
+		{"any of: one failed", []dependency.Status{dependency.Failed, dependency.Pending}, dependency.AnyOf(), nil, false, false},
+		{"any of: all failed", []dependency.Status{dependency.Failed, dependency.Failed}, dependency.AnyOf(), nil, true, false},
+		{"k of n: waiting", []dependency.Status{dependency.OK, dependency.Failed, dependency.Pending}, dependency.KOf(2), nil, false, false},
+		{"k of n: unreachable", []dependency.Status{dependency.OK, dependency.Failed, dependency.Failed}, dependency.KOf(2), nil, true, false},
+		{"k of n: more than n", []dependency.Status{dependency.OK, dependency.OK}, dependency.KOf(3), nil, true, true},
+		{"empty", nil, dependency.AllOf(), nil, true, true},
+	}
+
+	// dependencies are resolved either before or after the group is initialized
+	for _, resolveFirst := range []bool{true, false} {
+		for _, c := range cases {
+			ctx, cancel := context.WithCancel(context.Background())
+			manager := newTestManager(t)
+
+			deps, err := manager.NewDependencies(ctx, len(c.statuses))
+			if err != nil {
+				t.Fatalf("%s: failed to create dependencies: %s", c.name, err)
+			}
+
+			ids := make([]string, 0, len(deps))
+			var resolutions []dependency.Dependency
+			for index, dep := range deps {
+				ids = append(ids, dep.ID)
+				if c.statuses[index] != dependency.Pending {
+					resolutions = append(resolutions, dependency.Dependency{ID: dep.ID, Status: c.statuses[index]})
+				}
+			}
+
+			mode := dependency.Mode{Required: c.mode.Required}
+			for _, index := range c.optional {
+				mode.Optional = append(mode.Optional, ids[index])
+			}
+
+			groupId, err := manager.NewGroup(ctx)
+			if err != nil {
+				t.Fatalf("%s: failed to create a group: %s", c.name, err)
+			}
+
+			if resolveFirst {
+				if err := manager.Resolve(ctx, resolutions...); err != nil {
+					t.Fatalf("%s: failed to resolve dependencies: %s", c.name, err)
+				}
+			}
+
+			if err := manager.InitializeGroup(ctx, groupId, mode, ids...); err != nil {
+				t.Fatalf("%s: failed to initialize a group: %s", c.name, err)
+			}
+
+			if !resolveFirst {
+				for _, resolution := range resolutions {
+					if err := manager.Resolve(ctx, resolution); err != nil {
+						t.Fatalf("%s: failed to resolve dependencies: %s", c.name, err)
+					}
+				}
+			}
+
+			groups, err := manager.LoadGroups(ctx, groupId)
+			if err != nil || len(groups) != 1 {
+				t.Fatalf("%s: failed to load the group: %v (groups: %+v)", c.name, err, groups)
+			}
+
+			ready, satisfied := groups[0].State()
+			if ready != c.ready || satisfied != c.satisfied {
+				t.Errorf("%s (resolved first: %t): expected (ready=%t, satisfied=%t), got (%t, %t)",
+					c.name, resolveFirst, c.ready, c.satisfied, ready, satisfied)
+			}
+
+			readyGroups, err := manager.ReadyGroups(ctx)
+			if err != nil {
+				t.Fatalf("%s: failed to read ready groups: %s", c.name, err)
+			}
+
+			select {
+			case id := <-readyGroups:
+				if !c.ready || id != groupId {
+					t.Errorf("%s (resolved first: %t): unexpected ready group: %s", c.name, resolveFirst, id)
+				}
+			case <-time.After(200 * time.Millisecond):
+				if c.ready {
+					t.Errorf("%s (resolved first: %t): the group has not been delivered", c.name, resolveFirst)
+				}
+			}
+
+			cancel()
+		}
+	}
+}
+
+func TestGroupInitializedOnce(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestManager(t)
+
+	deps, err := manager.NewDependencies(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to create dependencies: %s", err)
+	}
+
+	groupId, err := manager.NewGroup(ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+
+	if err := manager.InitializeGroup(ctx, groupId, dependency.AllOf(), deps[0].ID); err != nil {
+		t.Fatalf("failed to initialize a group: %s", err)
+	}
+
+	err = manager.InitializeGroup(ctx, groupId, dependency.AllOf(), deps[0].ID)
+	if !errors.Is(err, dependency.ErrGroupInitialized) {
+		t.Fatalf("expected ErrGroupInitialized, got: %v", err)
+	}
+}
+
+func newReadyGroup(t *testing.T, manager *Manager) string {
+	ctx := context.Background()
+
+	groupId, err := manager.NewGroup(ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+	if err := manager.InitializeGroup(ctx, groupId, dependency.AllOf()); err != nil {
+		t.Fatalf("failed to initialize a group: %s", err)
+	}
+
+	return groupId
+}
+
+func receiveGroup(t *testing.T, groups <-chan string, timeout time.Duration) (string, bool) {
+	select {
+	case id := <-groups:
+		return id, true
+	case <-time.After(timeout):
+		return "", false
+	}
+}
+
+func TestGroupsOfExpiredConsumersAreRequeued(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestManager(t)
+	groupId := newReadyGroup(t, manager)
+
+	// a consumer has popped the group and has been lost before acknowledging it
+	lost := redis.Z{Score: float64(time.Now().Add(-time.Second).UnixMilli()), Member: "lost"}
+	if err := manager.Client.ZAdd(ctx, manager.consumersKey(), lost).Err(); err != nil {
+		t.Fatalf("failed to add a consumer: %s", err)
+	}
+	moved, err := manager.Client.LMove(ctx, manager.readyKey(), manager.processingKey("lost"), "LEFT", "RIGHT").Result()
+	if err != nil || moved != groupId {
+		t.Fatalf("failed to pop the group: %v (popped: '%s')", err, moved)
+	}
+
+	consumerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	readyGroups, err := manager.ReadyGroups(consumerCtx)
+	if err != nil {
+		t.Fatalf("failed to read ready groups: %s", err)
+	}
+
+	id, ok := receiveGroup(t, readyGroups, time.Second)
+	if !ok || id != groupId {
+		t.Fatalf("the group has not been requeued (received: '%s')", id)
+	}
+
+	if err := manager.AckGroups(ctx, id); err != nil {
+		t.Fatalf("failed to acknowledge the group: %s", err)
+	}
+
+	keys, err := manager.Client.Keys(ctx, manager.prefix()+":processing:*").Result()
+	if err != nil {
+		t.Fatalf("failed to list processing lists: %s", err)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("the acknowledged group is still processed: %v", keys)
+	}
+}
+
+func TestGroupsOfLiveConsumersAreNotRequeued(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestManager(t)
+	groupId := newReadyGroup(t, manager)
+
+	firstCtx, stopFirst := context.WithCancel(ctx)
+	defer stopFirst()
+
+	first, err := manager.ReadyGroups(firstCtx)
+	if err != nil {
+		t.Fatalf("failed to read ready groups: %s", err)
+	}
+	if id, ok := receiveGroup(t, first, time.Second); !ok || id != groupId {
+		t.Fatalf("the group has not been delivered (received: '%s')", id)
+	}
+
+	secondCtx, stopSecond := context.WithCancel(ctx)
+	defer stopSecond()
+
+	// the group is held by a live consumer, so it's not delivered to the second one
+	second, err := manager.ReadyGroups(secondCtx)
+	if err != nil {
+		t.Fatalf("failed to read ready groups: %s", err)
+	}
+	if id, ok := receiveGroup(t, second, 200*time.Millisecond); ok {
+		t.Fatalf("a group held by a live consumer has been delivered: %s", id)
+	}
+
+	// the first consumer stops without acknowledging the group
+	stopFirst()
+
+	if id, ok := receiveGroup(t, second, time.Second); !ok || id != groupId {
+		t.Fatalf("the unacknowledged group has not been requeued (received: '%s')", id)
+	}
+}
+
+func TestUninitializedGroups(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestManager(t)
+
+	deps, err := manager.NewDependencies(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to create dependencies: %s", err)
+	}
+
+	var groups []string
+	for i := 0; i < 3; i++ {
+		groupId, err := manager.NewGroup(ctx)
+		if err != nil {
+			t.Fatalf("failed to create a group: %s", err)
+		}
+		groups = append(groups, groupId)
+	}
+
+	if err := manager.InitializeGroup(ctx, groups[1], dependency.AllOf(), deps[0].ID); err != nil {
+		t.Fatalf("failed to initialize a group: %s", err)
+	}
+	if err := manager.DeleteGroups(ctx, groups[2]); err != nil {
+		t.Fatalf("failed to delete a group: %s", err)
+	}
+
+	fresh, err := manager.UninitializedGroups(ctx, time.Hour, 0)
+	if err != nil {
+		t.Fatalf("failed to find uninitialized groups: %s", err)
+	}
+	if len(fresh) != 0 {
+		t.Fatalf("fresh groups have been found: %v", fresh)
+	}
+
+	uninitialized, err := manager.UninitializedGroups(ctx, -time.Hour, 0)
+	if err != nil {
+		t.Fatalf("failed to find uninitialized groups: %s", err)
+	}
+	if len(uninitialized) != 1 || uninitialized[0] != groups[0] {
+		t.Fatalf("unexpected uninitialized groups: %v", uninitialized)
+	}
+}
+
+func TestDeleteDependencies(t *testing.T) {
+	ctx := context.Background()
+	manager := newTestManager(t)
+
+	deps, err := manager.NewDependencies(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to create dependencies: %s", err)
+	}
+
+	if err := manager.DeleteDependencies(ctx, deps[0].ID); err != nil {
+		t.Fatalf("failed to delete dependencies: %s", err)
+	}
+
+	loaded, err := manager.LoadDependencies(ctx, deps[0].ID, deps[1].ID)
+	if err != nil {
+		t.Fatalf("failed to load dependencies: %s", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != deps[1].ID {
+		t.Fatalf("unexpected dependencies: %v", loaded)
+	}
+}
@@ -0,0 +1,127 @@
+package redis
+
+import "github.com/redis/go-redis/v9"
+
+// Scripts get the keys they know in advance as KEYS and the key prefix as ARGV[1],
+// the rest of the keys (e.g. groups of a dependency) are derived from the prefix.
+// Keys share a hash tag, so they are in the same cluster slot.
+//
+// A group keeps "pending" (the amount of satisfied dependencies it still needs) and "tolerated"
+// (the amount of failures it can still take), it's pushed to the ready list as soon as
+// pending <= 0 or tolerated < 0.
+
+// resolveScript sets the status (ARGV[2]) of pending dependencies (ARGV[3:], their keys are KEYS[2:])
+// and updates their groups, KEYS[1] is the ready list
+var resolveScript = redis.NewScript(`
+local prefix, status = ARGV[1], ARGV[2]
+for i = 3, #ARGV do
+	local depKey = KEYS[i - 1]
+	if redis.call('GET', depKey) == 'pending' then
+		redis.call('SET', depKey, status)
+		for _, groupId in ipairs(redis.call('SMEMBERS', depKey .. ':groups')) do
+			local groupKey = prefix .. ':group:' .. groupId
+			if redis.call('HGET', groupKey, 'status') == 'waiting' then
+				local optional = redis.call('SISMEMBER', groupKey .. ':optional', ARGV[i]) == 1
+				local pending, tolerated
+				if status == 'ok' or optional then
+					pending = redis.call('HINCRBY', groupKey, 'pending', -1)
+					tolerated = tonumber(redis.call('HGET', groupKey, 'tolerated'))
+				else
+					pending = tonumber(redis.call('HGET', groupKey, 'pending'))
+					tolerated = redis.call('HINCRBY', groupKey, 'tolerated', -1)
+				end
+				if pending <= 0 or tolerated < 0 then
+					redis.call('HSET', groupKey, 'status', 'ready')
+					redis.call('RPUSH', KEYS[1], groupId)
+				end
+			end
+		end
+	end
+end
+return 0
+`)
+
+// initializeScript binds dependencies (ARGV[5:], their keys are KEYS[3:]) to a created group (ARGV[2], KEYS[1]),
+// 1 is returned if it's not created. KEYS[2] is the ready list.
+// ARGV[3] is the required amount of satisfied dependencies, ARGV[4] is a string of "0" and "1" flags
+// telling which dependencies are optional.
+var initializeScript = redis.NewScript(`
+local groupId, required, optionalFlags = ARGV[2], tonumber(ARGV[3]), ARGV[4]
+local groupKey = KEYS[1]
+if redis.call('HGET', groupKey, 'status') ~= 'created' then
+	return 1
+end
+
+local statuses = {}
+for i = 5, #ARGV do
+	local status = redis.call('GET', KEYS[i - 2])
+	if not status then
+		return redis.error_reply('dependency not found: ' .. ARGV[i])
+	end
+	statuses[i] = status
+end
+
+-- dependencies might have been resolved before the initialization
+local satisfied, failed = 0, 0
+for i = 5, #ARGV do
+	local optional = string.sub(optionalFlags, i - 4, i - 4) == '1'
+	redis.call('RPUSH', groupKey .. ':dependencies', ARGV[i])
+	redis.call('SADD', KEYS[i - 2] .. ':groups', groupId)
+	if optional then
+		redis.call('SADD', groupKey .. ':optional', ARGV[i])
+	end
+
+	if statuses[i] == 'ok' or (statuses[i] == 'failed' and optional) then
+		satisfied = satisfied + 1
+	elseif statuses[i] == 'failed' then
+		failed = failed + 1
+	end
+end
+
+local pending, tolerated = required - satisfied, (#ARGV - 4) - required - failed
+local status = 'waiting'
+if pending <= 0 or tolerated < 0 then
+	status = 'ready'
+	redis.call('RPUSH', KEYS[2], groupId)
+end
+redis.call('HSET', groupKey, 'status', status, 'required', required, 'pending', pending, 'tolerated', tolerated)
+return 0
+`)
+
+// deleteScript deletes groups (KEYS) with their dependencies
+var deleteScript = redis.NewScript(`
+local prefix = ARGV[1]
+for _, groupKey in ipairs(KEYS) do
+	for _, depId in ipairs(redis.call('LRANGE', groupKey .. ':dependencies', 0, -1)) do
+		local depKey = prefix .. ':dependency:' .. depId
+		redis.call('DEL', depKey, depKey .. ':groups')
+	end
+	redis.call('DEL', groupKey, groupKey .. ':dependencies', groupKey .. ':optional')
+end
+return 0
+`)
+
+// requeueScript moves groups popped by consumers with expired leases (KEYS[1], scored by the expiration time)
+// back to the ready list (KEYS[2]), ARGV[2] is the current time
+var requeueScript = redis.NewScript(`
+local moved = 0
+for _, consumer in ipairs(redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[2])) do
+	local processing = ARGV[1] .. ':processing:' .. consumer
+	while redis.call('RPOPLPUSH', processing, KEYS[2]) do
+		moved = moved + 1
+	end
+	redis.call('ZREM', KEYS[1], consumer)
+end
+return moved
+`)
+
+// releaseScript moves groups popped by a stopped consumer (ARGV[1], its processing list is KEYS[3])
+// back to the ready list (KEYS[2]) and removes its lease from KEYS[1]
+var releaseScript = redis.NewScript(`
+local moved = 0
+while redis.call('RPOPLPUSH', KEYS[3], KEYS[2]) do
+	moved = moved + 1
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+return moved
+`)
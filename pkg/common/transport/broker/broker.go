@@ -24,6 +24,11 @@ type Publisher[Item any] interface {
 	Publish(ctx context.Context, topic string, item Item) error
 }
 
+// BatchPublisher publishes several items to the topic with a single call
+type BatchPublisher[Item any] interface {
+	PublishBatch(ctx context.Context, topic string, items ...Item) error
+}
+
 type Broker[Item any] interface {
 	Consumer[Item]
 	Publisher[Item]
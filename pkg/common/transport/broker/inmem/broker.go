@@ -0,0 +1,240 @@
+package inmem
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"sync"
+)
+
+var _ broker.Broker[int] = (*Broker[int])(nil)
+
+// Broker is an in-process broker.Broker.
+//
+// Every topic keeps a log of published items, so new consumer groups can start from the oldest item.
+// Consumers with the same group share (compete for) the items, every group receives all items.
+// A consumer of several topics receives items in the order they were published.
+// Nacked items are redelivered to the group.
+//
+// The log is never truncated, so the broker is meant for tests and local development.
+type Broker[Item any] struct {
+	topics   map[string]*topic[Item]
+	sequence uint64
+	// signal is closed (and replaced) on every change to wake up waiting consumers
+	signal chan struct{}
+	mu     sync.Mutex
+}
+
+type topic[Item any] struct {
+	log    []entry[Item]
+	queues map[string]*queue[Item] // group -> queue
+}
+
+type queue[Item any] struct {
+	entries []entry[Item]
+}
+
+type entry[Item any] struct {
+	seq  uint64
+	item Item
+}
+
+func New[Item any]() *Broker[Item] {
+	return &Broker[Item]{
+		topics: make(map[string]*topic[Item]),
+		signal: make(chan struct{}),
+	}
+}
+
+func (b *Broker[Item]) Publish(ctx context.Context, topicName string, item Item) error {
+	return b.PublishBatch(ctx, topicName, item)
+}
+
+func (b *Broker[Item]) PublishBatch(_ context.Context, topicName string, items ...Item) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topicName)
+	for _, item := range items {
+		b.sequence++
+		e := entry[Item]{seq: b.sequence, item: item}
+
+		t.log = append(t.log, e)
+		for _, q := range t.queues {
+			q.entries = append(q.entries, e)
+		}
+	}
+
+	b.notify()
+
+	return nil
+}
+
+func (b *Broker[Item]) Consume(ctx context.Context, topics []string, settings broker.ConsumerSettings) (<-chan broker.Message[Item], error) {
+	group, ephemeral := settings.Group, settings.Group == ""
+	if ephemeral {
+		// every consumer without a group is a group by itself
+		group = uuid.New().String()
+	}
+
+	b.mu.Lock()
+	queues := make([]*queue[Item], 0, len(topics))
+	for _, topicName := range topics {
+		queues = append(queues, b.queue(topicName, group, settings.InitializationPolicy))
+	}
+	b.mu.Unlock()
+
+	channel := make(chan broker.Message[Item], 128)
+
+	go func() {
+		b.deliver(ctx, queues, channel)
+
+		// nobody else can consume from the queues of a consumer without a group
+		if ephemeral {
+			b.deleteQueues(topics, group)
+		}
+	}()
+
+	return channel, nil
+}
+
+func (b *Broker[Item]) deliver(ctx context.Context, queues []*queue[Item], channel chan<- broker.Message[Item]) {
+	for {
+		b.mu.Lock()
+		q, e, ok := b.pop(queues)
+		signal := b.signal
+		b.mu.Unlock()
+
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signal:
+				continue
+			}
+		}
+
+		mes := &message[Item]{
+			entry:  e,
+			queue:  q,
+			broker: b,
+		}
+
+		select {
+		case <-ctx.Done():
+			// the item has been taken from the queue, so it must be returned
+			mes.Nack()
+			return
+		case channel <- mes:
+		}
+	}
+}
+
+// pop takes the oldest entry from the queues
+func (b *Broker[Item]) pop(queues []*queue[Item]) (*queue[Item], entry[Item], bool) {
+	var oldest *queue[Item]
+	for _, q := range queues {
+		if len(q.entries) == 0 {
+			continue
+		}
+
+		if oldest == nil || q.entries[0].seq < oldest.entries[0].seq {
+			oldest = q
+		}
+	}
+
+	if oldest == nil {
+		return nil, entry[Item]{}, false
+	}
+
+	e := oldest.entries[0]
+	oldest.entries = oldest.entries[1:]
+
+	return oldest, e, true
+}
+
+func (b *Broker[Item]) requeue(q *queue[Item], e entry[Item]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the entry goes to the back of the queue, so it gets a new sequence number
+	b.sequence++
+	e.seq = b.sequence
+
+	q.entries = append(q.entries, e)
+	b.notify()
+}
+
+func (b *Broker[Item]) topic(name string) *topic[Item] {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic[Item]{queues: make(map[string]*queue[Item])}
+		b.topics[name] = t
+	}
+
+	return t
+}
+
+func (b *Broker[Item]) queue(topicName, group string, policy broker.ConsumerInitializationPolicy) *queue[Item] {
+	t := b.topic(topicName)
+
+	q, ok := t.queues[group]
+	if !ok {
+		q = &queue[Item]{}
+		// like in the watermill broker, only NewestOffset skips the published items
+		if policy != broker.NewestOffset {
+			q.entries = append(q.entries, t.log...)
+		}
+		t.queues[group] = q
+	}
+
+	return q
+}
+
+func (b *Broker[Item]) deleteQueues(topics []string, group string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topicName := range topics {
+		if t, ok := b.topics[topicName]; ok {
+			delete(t.queues, group)
+		}
+	}
+}
+
+func (b *Broker[Item]) notify() {
+	close(b.signal)
+	b.signal = make(chan struct{})
+}
+
+type message[Item any] struct {
+	entry  entry[Item]
+	queue  *queue[Item]
+	broker *Broker[Item]
+	done   bool
+	mu     sync.Mutex
+}
+
+func (mes *message[Item]) Item() Item {
+	return mes.entry.item
+}
+
+func (mes *message[Item]) Ack() {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+
+	mes.done = true
+}
+
+// Nack returns the item to the group's queue (it is a no-op after Ack)
+func (mes *message[Item]) Nack() {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+
+	if mes.done {
+		return
+	}
+	mes.done = true
+
+	mes.broker.requeue(mes.queue, mes.entry)
+}
@@ -0,0 +1,102 @@
+package inmem
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"testing"
+	"time"
+)
+
+func TestGroups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := New[string]()
+
+	if err := b.Publish(ctx, "a", "1"); err != nil {
+		t.Fatalf("failed to publish: %s", err)
+	}
+
+	oldest, _ := b.Consume(ctx, []string{"a", "b"}, broker.ConsumerSettings{Group: "oldest", InitializationPolicy: broker.OldestOffset})
+	newest, _ := b.Consume(ctx, []string{"a", "b"}, broker.ConsumerSettings{Group: "newest", InitializationPolicy: broker.NewestOffset})
+
+	_ = b.Publish(ctx, "b", "2")
+	_ = b.Publish(ctx, "a", "3")
+
+	expectItems(t, oldest, "1", "2", "3")
+	expectItems(t, newest, "2", "3")
+}
+
+func TestNack(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := New[string]()
+	channel, _ := b.Consume(ctx, []string{"a"}, broker.ConsumerSettings{Group: "group"})
+
+	_ = b.Publish(ctx, "a", "1")
+
+	mes := receive(t, channel)
+	mes.Nack()
+
+	// the nacked item is redelivered, acked items are not
+	mes = receive(t, channel)
+	if mes.Item() != "1" {
+		t.Fatalf("expected '1', got '%s'", mes.Item())
+	}
+	mes.Ack()
+	mes.Nack()
+
+	select {
+	case mes := <-channel:
+		t.Fatalf("unexpected item: '%s'", mes.Item())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestConsumerWithoutGroupDeletesQueues(t *testing.T) {
+	b := New[string]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, _ = b.Consume(ctx, []string{"a", "b"}, broker.ConsumerSettings{})
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		b.mu.Lock()
+		left := len(b.topics["a"].queues) + len(b.topics["b"].queues)
+		b.mu.Unlock()
+
+		if left == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queues of the consumer have not been deleted: %d", left)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func expectItems(t *testing.T, channel <-chan broker.Message[string], items ...string) {
+	t.Helper()
+
+	for _, item := range items {
+		mes := receive(t, channel)
+		if mes.Item() != item {
+			t.Fatalf("expected '%s', got '%s'", item, mes.Item())
+		}
+		mes.Ack()
+	}
+}
+
+func receive(t *testing.T, channel <-chan broker.Message[string]) broker.Message[string] {
+	t.Helper()
+
+	select {
+	case mes := <-channel:
+		return mes
+	case <-time.After(time.Second):
+		t.Fatalf("no message received")
+		return nil
+	}
+}
@@ -12,8 +12,10 @@ import (
 )
 
 type Broker[Item any] struct {
-	Agent                     Agent
-	ItemCodec                 codec.Codec[Item, []byte]
+	Agent     Agent
+	ItemCodec codec.Codec[Item, []byte]
+	// MessageDecoder decodes consumed messages instead of the ItemCodec (e.g. to consume raw payloads)
+	MessageDecoder            func(topic string, mes *message.Message) (Item, error)
 	Logger                    *slog.Logger
 	ConsumerChannelBufferSize int
 }
@@ -54,13 +56,6 @@ func (b Broker[Item]) Consume(ctx context.Context, topics []string, settings bro
 			case <-ctx.Done():
 				return
 			case mes := <-from:
-				item, err := b.ItemCodec.Decode(mes.Payload)
-				if err != nil {
-					b.Logger.Error("failed to decode item",
-						slog.String("error", err.Error()))
-					continue
-				}
-
 				rawTopic := mes.Context().Value("topic")
 				topic, ok := rawTopic.(string)
 				if !ok {
@@ -69,6 +64,13 @@ func (b Broker[Item]) Consume(ctx context.Context, topics []string, settings bro
 					continue
 				}
 
+				item, err := b.decode(topic, mes)
+				if err != nil {
+					b.Logger.Error("failed to decode item",
+						slog.String("error", err.Error()))
+					continue
+				}
+
 				brokerMessage := Message[Item]{
 					item:  item,
 					topic: topic,
@@ -87,6 +89,14 @@ func (b Broker[Item]) Consume(ctx context.Context, topics []string, settings bro
 	return resultChannel, nil
 }
 
+func (b Broker[Item]) decode(topic string, mes *message.Message) (Item, error) {
+	if b.MessageDecoder != nil {
+		return b.MessageDecoder(topic, mes)
+	}
+
+	return b.ItemCodec.Decode(mes.Payload)
+}
+
 func (b Broker[Item]) Publish(_ context.Context, topic string, item Item) error {
 	payload, err := b.ItemCodec.Encode(item)
 	if err != nil {
@@ -99,3 +109,21 @@ func (b Broker[Item]) Publish(_ context.Context, topic string, item Item) error
 
 	return nil
 }
+
+func (b Broker[Item]) PublishBatch(_ context.Context, topic string, items ...Item) error {
+	messages := make([]*message.Message, 0, len(items))
+	for _, item := range items {
+		payload, err := b.ItemCodec.Encode(item)
+		if err != nil {
+			return fmt.Errorf("failed to encode item: %w", err)
+		}
+
+		messages = append(messages, message.NewMessage(uuid.New().String(), payload))
+	}
+
+	if err := b.Agent.Publisher.Publish(topic, messages...); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -9,8 +9,10 @@ var TaskStatuses struct {
 }
 
 var TaskSubStatuses struct {
-	OK     string
-	Failed string
+	OK        string
+	Failed    string
+	Cancelled string
+	TimedOut  string
 }
 
 var OnTask struct {
@@ -25,6 +27,7 @@ var ResourceStatuses struct {
 	DoesNotExist string
 	Allocated    string
 	Ready        string
+	Expired      string
 }
 
 func init() {
@@ -36,6 +39,8 @@ func init() {
 
 	TaskSubStatuses.OK = "ok"
 	TaskSubStatuses.Failed = "failed"
+	TaskSubStatuses.Cancelled = "cancelled"
+	TaskSubStatuses.TimedOut = "timed_out"
 
 	OnTask.Created = "task.created"
 	OnTask.Ready = "task.ready"
@@ -46,4 +51,8 @@ func init() {
 	ResourceStatuses.DoesNotExist = "does_not_exist"
 	ResourceStatuses.Allocated = "allocated"
 	ResourceStatuses.Ready = "ready"
+	ResourceStatuses.Expired = "expired"
+
+	// built after the statuses are initialized
+	TaskStates = newTaskStateMachine()
 }
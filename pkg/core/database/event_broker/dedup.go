@@ -0,0 +1,123 @@
+package eventbroker
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"sync"
+)
+
+var (
+	_ core.Broker      = (*Deduplicator)(nil)
+	_ core.BatchBroker = (*Deduplicator)(nil)
+)
+
+// Deduplicator drops events that were already acknowledged by the same consumer.
+//
+// Events published through the outbox are delivered at-least-once,
+// so the same event (identified by core.Event.ID) might be received several times.
+// Only the last "window" ids are remembered (per consumer).
+type Deduplicator struct {
+	broker core.Broker
+	window int
+}
+
+func Deduplicate(b core.Broker, window int) *Deduplicator {
+	return &Deduplicator{broker: b, window: window}
+}
+
+func (d *Deduplicator) Send(ctx context.Context, event core.Event) error {
+	return d.broker.Send(ctx, event)
+}
+
+func (d *Deduplicator) SendBatch(ctx context.Context, events []core.Event) error {
+	return core.SendBatch(ctx, d.broker, events)
+}
+
+func (d *Deduplicator) Consume(ctx context.Context, events []string, settings broker.ConsumerSettings) (<-chan core.BrokerEvent, error) {
+	channel, err := d.broker.Consume(ctx, events, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := newIDWindow(d.window)
+	output := make(chan core.BrokerEvent, 1)
+
+	go func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-channel:
+				if !ok {
+					return
+				}
+
+				if seen.Contains(message.Item().ID) {
+					message.Ack()
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case output <- dedupMessage{BrokerEvent: message, seen: seen}:
+				}
+			}
+		}
+	}(ctx)
+
+	return output, nil
+}
+
+// dedupMessage remembers the event id only after a successful processing,
+// so nacked events can still be redelivered.
+type dedupMessage struct {
+	core.BrokerEvent
+	seen *idWindow
+}
+
+func (m dedupMessage) Ack() {
+	m.seen.Add(m.Item().ID)
+	m.BrokerEvent.Ack()
+}
+
+type idWindow struct {
+	ids   map[string]struct{}
+	queue []string
+	size  int
+	mu    sync.Mutex
+}
+
+func newIDWindow(size int) *idWindow {
+	return &idWindow{
+		ids:  make(map[string]struct{}, size),
+		size: size,
+	}
+}
+
+func (w *idWindow) Contains(id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.ids[id]
+
+	return ok
+}
+
+func (w *idWindow) Add(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.ids[id]; ok {
+		return
+	}
+
+	w.ids[id] = struct{}{}
+	w.queue = append(w.queue, id)
+
+	if len(w.queue) > w.size {
+		delete(w.ids, w.queue[0])
+		w.queue = w.queue[1:]
+	}
+}
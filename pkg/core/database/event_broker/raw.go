@@ -0,0 +1,38 @@
+package eventbroker
+
+import (
+	"fmt"
+	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ischenkx/kantoku/pkg/common/data/uid"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"time"
+)
+
+// DecodeRawEvent makes an event out of a message published by a third party, i.e. the payload is not a core.Event.
+//
+// The ID of the event is the UUID of the message, so redeliveries of the message have the same ID.
+// Kafka messages without a UUID are identified by their partition and offset.
+// Otherwise, the message can't be identified and a random ID is generated.
+func DecodeRawEvent(topic string, mes *message.Message) (core.Event, error) {
+	return core.Event{
+		ID:        rawEventID(topic, mes),
+		Data:      mes.Payload,
+		Topic:     topic,
+		Timestamp: time.Now().UnixNano(),
+	}, nil
+}
+
+func rawEventID(topic string, mes *message.Message) string {
+	if mes.UUID != "" {
+		return mes.UUID
+	}
+
+	partition, hasPartition := kafka.MessagePartitionFromCtx(mes.Context())
+	offset, hasOffset := kafka.MessagePartitionOffsetFromCtx(mes.Context())
+	if hasPartition && hasOffset {
+		return fmt.Sprintf("%s:%d:%d", topic, partition, offset)
+	}
+
+	return uid.Generate()
+}
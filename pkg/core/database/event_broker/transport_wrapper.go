@@ -6,7 +6,10 @@ import (
 	"github.com/ischenkx/kantoku/pkg/core"
 )
 
-var _ core.Broker = (*CommonBrokerWrapper)(nil)
+var (
+	_ core.Broker      = (*CommonBrokerWrapper)(nil)
+	_ core.BatchBroker = (*CommonBrokerWrapper)(nil)
+)
 
 type CommonBrokerWrapper struct {
 	broker broker.Broker[core.Event]
@@ -20,6 +23,36 @@ func (b *CommonBrokerWrapper) Send(ctx context.Context, event core.Event) error
 	return b.broker.Publish(ctx, event.Topic, event)
 }
 
+// SendBatch publishes the events of each topic with a single call if the underlying broker supports it
+func (b *CommonBrokerWrapper) SendBatch(ctx context.Context, events []core.Event) error {
+	publisher, ok := b.broker.(broker.BatchPublisher[core.Event])
+	if !ok {
+		for _, event := range events {
+			if err := b.Send(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var topics []string
+	byTopic := map[string][]core.Event{}
+	for _, event := range events {
+		if _, ok := byTopic[event.Topic]; !ok {
+			topics = append(topics, event.Topic)
+		}
+		byTopic[event.Topic] = append(byTopic[event.Topic], event)
+	}
+
+	for _, topic := range topics {
+		if err := publisher.PublishBatch(ctx, topic, byTopic[topic]...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (b *CommonBrokerWrapper) Consume(ctx context.Context, events []string, settings broker.ConsumerSettings) (<-chan core.BrokerEvent, error) {
 	return b.broker.Consume(ctx, events, settings)
 }
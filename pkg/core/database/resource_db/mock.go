@@ -5,22 +5,39 @@ import (
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/samber/lo"
+	"sync"
+	"time"
 )
 
 type MockDB struct {
 	idCounter int
 	data      map[string]core.Resource
+	expiresAt map[string]time.Time
+	mu        sync.RWMutex
 }
 
 func NewMockDB() *MockDB {
 	return &MockDB{
 		idCounter: 0,
 		data:      map[string]core.Resource{},
+		expiresAt: map[string]time.Time{},
 	}
 }
 
 func (s *MockDB) Load(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+
 	return lo.Map(ids, func(id string, _ int) core.Resource {
+		if expiresAt, ok := s.expiresAt[id]; ok && !now.Before(expiresAt) {
+			return core.Resource{
+				ID:     id,
+				Status: core.ResourceStatuses.Expired,
+			}
+		}
+
 		res, has := s.data[id]
 		if has {
 			return res
@@ -35,16 +52,42 @@ func (s *MockDB) Load(ctx context.Context, ids ...string) ([]core.Resource, erro
 }
 
 func (s *MockDB) Alloc(ctx context.Context, amount int) ([]string, error) {
+	return s.AllocWithTTL(ctx, amount, 0)
+}
+
+func (s *MockDB) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	ids := make([]string, amount)
 	for i := 0; i < amount; i++ {
 		s.idCounter++
 		ids[i] = fmt.Sprint("resource_db-", s.idCounter)
+		s.data[ids[i]] = core.Resource{
+			ID:     ids[i],
+			Status: core.ResourceStatuses.Allocated,
+		}
+
+		if ttl > 0 {
+			s.expiresAt[ids[i]] = time.Now().Add(ttl)
+		}
 	}
 	return ids, nil
 }
 
 func (s *MockDB) Init(ctx context.Context, resources []core.Resource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for _, res := range resources {
+		if expiresAt, ok := s.expiresAt[res.ID]; ok && !time.Now().Before(expiresAt) {
+			return fmt.Errorf("can't initialize an expired resource (id='%s')", res.ID)
+		}
+
+		if res.TTL > 0 {
+			s.expiresAt[res.ID] = time.Now().Add(res.TTL)
+		}
+
 		res.Status = core.ResourceStatuses.Ready
 		s.data[res.ID] = res
 	}
@@ -52,8 +95,12 @@ func (s *MockDB) Init(ctx context.Context, resources []core.Resource) error {
 }
 
 func (s *MockDB) Dealloc(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for _, id := range ids {
 		delete(s.data, id)
+		delete(s.expiresAt, id)
 	}
 	return nil
 }
@@ -0,0 +1,52 @@
+package resourcedb
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"testing"
+	"time"
+)
+
+func TestMockDBExpiry(t *testing.T) {
+	ctx := context.Background()
+	db := NewMockDB()
+
+	ids, err := db.Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	err = db.Init(ctx, []core.Resource{
+		{ID: ids[0], Data: []byte("short"), TTL: 10 * time.Millisecond},
+		{ID: ids[1], Data: []byte("long")},
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	resources, err := db.Load(ctx, ids...)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	if resources[0].Status != core.ResourceStatuses.Expired || resources[0].Data != nil {
+		t.Fatalf("expected the resource to expire, got: %+v", resources[0])
+	}
+	if resources[1].Status != core.ResourceStatuses.Ready {
+		t.Fatalf("expected the resource to be ready, got: %+v", resources[1])
+	}
+
+	// expired allocations can't be initialized
+	expiring, err := db.AllocWithTTL(ctx, 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := db.Init(ctx, []core.Resource{{ID: expiring[0], Data: []byte("late")}}); err == nil {
+		t.Fatal("an expired resource has been initialized")
+	}
+}
@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var _ core.ResourceDB = (*DB)(nil)
+var _ core.ExpiringResourceDB = (*DB)(nil)
+
+// DB stores resources in a postgres table (see migrations/init.sql).
+//
+// If Channel is set, ids of initialized resources are sent to it with NOTIFY
+// when the initializing transaction commits (see Listen).
+// Expired resources are kept (without being readable) until they are deallocated.
+type DB struct {
+	Client  *pgxpool.Pool
+	Table   string
+	Channel string
+	// Logger reports lost LISTEN connections (slog.Default() is used if it's not set)
+	Logger *slog.Logger
+}
+
+const (
+	minListenBackoff = 100 * time.Millisecond
+	maxListenBackoff = 10 * time.Second
+)
+
+func (db *DB) Load(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	if len(ids) == 0 {
+		return []core.Resource{}, nil
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT id, status, data, coalesce(expires_at <= now(), false)
+		FROM %s
+		WHERE id = any ($1)
+	`, db.table())
+
+	rows, err := db.Client.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	loaded := make(map[string]core.Resource, len(ids))
+	for rows.Next() {
+		var (
+			res     core.Resource
+			expired bool
+		)
+		if err := rows.Scan(&res.ID, &res.Status, &res.Data, &expired); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		if expired {
+			res.Data = nil
+			res.Status = core.ResourceStatuses.Expired
+		}
+
+		loaded[res.ID] = res
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return lo.Map(ids, func(id string, _ int) core.Resource {
+		res, ok := loaded[id]
+		if !ok {
+			return core.Resource{
+				ID:     id,
+				Status: core.ResourceStatuses.DoesNotExist,
+			}
+		}
+		return res
+	}), nil
+}
+
+func (db *DB) Alloc(ctx context.Context, amount int) ([]string, error) {
+	return db.AllocWithTTL(ctx, amount, 0)
+}
+
+func (db *DB) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
+	if amount <= 0 {
+		return []string{}, nil
+	}
+
+	ids := lo.Times(amount, func(_ int) string {
+		return strings.ReplaceAll(uuid.New().String(), "-", "")
+	})
+
+	sql := fmt.Sprintf(`
+		INSERT INTO %s (id, status, expires_at)
+		SELECT id, $2, CASE WHEN $3::bigint > 0 THEN now() + $3::bigint * interval '1 microsecond' END
+		FROM unnest($1::text[]) AS id
+	`, db.table())
+
+	if _, err := db.Client.Exec(ctx, sql, ids, core.ResourceStatuses.Allocated, ttl.Microseconds()); err != nil {
+		return nil, fmt.Errorf("failed to insert: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Init initializes resources in a single transaction, so either all of them become ready or none
+func (db *DB) Init(ctx context.Context, resources []core.Resource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	ids := lo.Map(resources, func(res core.Resource, _ int) string { return res.ID })
+
+	tx, err := db.Client.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// the rows are locked until the transaction ends, so concurrent initializations are serialized
+	current, err := db.lock(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		status, ok := current[id]
+		if !ok {
+			status = core.ResourceStatuses.DoesNotExist
+		}
+
+		if status != core.ResourceStatuses.Allocated {
+			return fmt.Errorf("can't initialize a resource_db with status '%s' (id='%s')", status, id)
+		}
+	}
+
+	sql := fmt.Sprintf(`
+		UPDATE %s AS r
+		SET status     = $4,
+		    data       = u.data,
+		    expires_at = CASE WHEN u.ttl > 0 THEN now() + u.ttl * interval '1 microsecond' ELSE r.expires_at END,
+		    updated_at = now()
+		FROM unnest($1::text[], $2::bytea[], $3::bigint[]) AS u(id, data, ttl)
+		WHERE r.id = u.id
+	`, db.table())
+
+	_, err = tx.Exec(ctx, sql,
+		ids,
+		lo.Map(resources, func(res core.Resource, _ int) []byte { return res.Data }),
+		lo.Map(resources, func(res core.Resource, _ int) int64 { return res.TTL.Microseconds() }),
+		core.ResourceStatuses.Ready,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+
+	if db.Channel != "" {
+		// notifications are delivered on commit
+		_, err = tx.Exec(ctx, `SELECT pg_notify($1, id) FROM unnest($2::text[]) AS id`, db.Channel, ids)
+		if err != nil {
+			return fmt.Errorf("failed to notify: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) Dealloc(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE id = any ($1)`, db.table())
+
+	if _, err := db.Client.Exec(ctx, sql, ids); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	return nil
+}
+
+// Listen returns ids of initialized resources sent to the channel.
+// If the connection fails, it is reestablished with a backoff (notifications sent meanwhile are lost).
+// The returned channel is closed when the context is done.
+func (db *DB) Listen(ctx context.Context) (<-chan string, error) {
+	if db.Channel == "" {
+		return nil, fmt.Errorf("no channel set")
+	}
+
+	conn, err := db.listen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(chan string, 1024)
+	go func() {
+		defer close(ids)
+
+		for {
+			err := db.forward(ctx, conn, ids)
+			// the connection is not reused as it is still subscribed to the channel
+			conn.Close(context.Background())
+			if ctx.Err() != nil {
+				return
+			}
+
+			db.logger().Warn("lost the connection listening to resource notifications, reconnecting",
+				slog.String("channel", db.Channel),
+				slog.String("error", err.Error()))
+
+			conn, err = db.relisten(ctx)
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return ids, nil
+}
+
+// listen acquires a dedicated connection subscribed to the channel
+func (db *DB) listen(ctx context.Context) (*pgx.Conn, error) {
+	pooled, err := db.Client.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+
+	if _, err := pooled.Exec(ctx, "LISTEN "+pgx.Identifier{db.Channel}.Sanitize()); err != nil {
+		pooled.Release()
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	return pooled.Hijack(), nil
+}
+
+// relisten retries listen with an exponential backoff, it fails only if the context is done
+func (db *DB) relisten(ctx context.Context) (*pgx.Conn, error) {
+	backoff := minListenBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		conn, err := db.listen(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		db.logger().Error("failed to reconnect to resource notifications",
+			slog.String("channel", db.Channel),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()))
+
+		backoff = min(2*backoff, maxListenBackoff)
+	}
+}
+
+// forward sends notifications to the channel until the connection fails or the context is done
+func (db *DB) forward(ctx context.Context, conn *pgx.Conn, ids chan<- string) error {
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case ids <- notification.Payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (db *DB) logger() *slog.Logger {
+	if db.Logger == nil {
+		return slog.Default()
+	}
+
+	return db.Logger
+}
+
+// lock returns statuses of existing resources (expired ones are reported as such)
+func (db *DB) lock(ctx context.Context, tx pgx.Tx, ids []string) (map[string]string, error) {
+	sql := fmt.Sprintf(`
+		SELECT id, CASE WHEN coalesce(expires_at <= now(), false) THEN $2 ELSE status END
+		FROM %s
+		WHERE id = any ($1)
+		FOR UPDATE
+	`, db.table())
+
+	rows, err := tx.Query(ctx, sql, ids, core.ResourceStatuses.Expired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock resources: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]string, len(ids))
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		statuses[id] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func (db *DB) table() string {
+	return pgx.Identifier{db.Table}.Sanitize()
+}
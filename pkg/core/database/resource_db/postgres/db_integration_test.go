@@ -0,0 +1,252 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/uid"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDB runs the migrations in a fresh schema of the database at KANTOKU_TEST_POSTGRES_URI
+func newTestDB(t *testing.T) *DB {
+	ctx := context.Background()
+
+	uri := os.Getenv("KANTOKU_TEST_POSTGRES_URI")
+	if uri == "" {
+		t.Skip("KANTOKU_TEST_POSTGRES_URI is not set")
+	}
+
+	migration, err := os.ReadFile("migrations/init.sql")
+	if err != nil {
+		t.Fatalf("failed to read the migration: %s", err)
+	}
+
+	schema := "kantoku_test_" + strings.ReplaceAll(uid.Generate(), "-", "")
+
+	config, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		t.Fatalf("failed to parse the uri: %s", err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	client, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("failed to connect: %s", err)
+	}
+
+	if _, err := client.Exec(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+		t.Fatalf("failed to create a schema: %s", err)
+	}
+	if _, err := client.Exec(ctx, string(migration)); err != nil {
+		t.Fatalf("failed to migrate: %s", err)
+	}
+
+	t.Cleanup(func() {
+		client.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+		client.Close()
+	})
+
+	// notifications are not scoped by schemas
+	return &DB{Client: client, Table: "resources", Channel: schema}
+}
+
+func (db *DB) mustAlloc(t *testing.T, ttl time.Duration) string {
+	ids, err := db.AllocWithTTL(context.Background(), 1, ttl)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	return ids[0]
+}
+
+func TestConcurrentInit(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	id := db.mustAlloc(t, 0)
+
+	const attempts = 8
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded []string
+	)
+	for i := 0; i < attempts; i++ {
+		data := fmt.Sprint(i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := db.Init(ctx, []core.Resource{{ID: id, Data: []byte(data)}}); err == nil {
+				mu.Lock()
+				succeeded = append(succeeded, data)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(succeeded) != 1 {
+		t.Fatalf("expected a single successful initialization, got %d", len(succeeded))
+	}
+
+	loaded, err := db.Load(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	if loaded[0].Status != core.ResourceStatuses.Ready || string(loaded[0].Data) != succeeded[0] {
+		t.Fatalf("unexpected resource: %s '%s' (initialized with '%s')", loaded[0].Status, loaded[0].Data, succeeded[0])
+	}
+}
+
+func TestInitTransitions(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	allocated := db.mustAlloc(t, 0)
+	initialized := db.mustAlloc(t, 0)
+	if err := db.Init(ctx, []core.Resource{{ID: initialized, Data: []byte("1")}}); err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	cases := []struct {
+		name string
+		id   string
+	}{
+		{"initialized", initialized},
+		{"missing", "missing"},
+	}
+
+	for _, c := range cases {
+		// the allocated resource must not be initialized along with the illegal one
+		err := db.Init(ctx, []core.Resource{{ID: allocated, Data: []byte("2")}, {ID: c.id, Data: []byte("2")}})
+		if err == nil {
+			t.Fatalf("%s: expected the initialization to fail", c.name)
+		}
+
+		loaded, err := db.Load(ctx, allocated)
+		if err != nil {
+			t.Fatalf("%s: failed to load: %s", c.name, err)
+		}
+		if loaded[0].Status != core.ResourceStatuses.Allocated {
+			t.Fatalf("%s: the resource has been partially initialized", c.name)
+		}
+	}
+
+	loaded, err := db.Load(ctx, initialized)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+	if string(loaded[0].Data) != "1" {
+		t.Fatalf("an initialized resource has been overwritten: '%s'", loaded[0].Data)
+	}
+}
+
+func TestExpiredResources(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	allocated := db.mustAlloc(t, 50*time.Millisecond)
+	initialized := db.mustAlloc(t, 0)
+	if err := db.Init(ctx, []core.Resource{{ID: initialized, Data: []byte("1"), TTL: 50 * time.Millisecond}}); err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	loaded, err := db.Load(ctx, allocated, initialized)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	for _, res := range loaded {
+		if res.Status != core.ResourceStatuses.Expired || res.Data != nil {
+			t.Fatalf("expected '%s' to be expired, got %s '%s'", res.ID, res.Status, res.Data)
+		}
+	}
+
+	if err := db.Init(ctx, []core.Resource{{ID: allocated, Data: []byte("2")}}); err == nil {
+		t.Fatal("an expired resource has been initialized")
+	}
+}
+
+func TestListen(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := newTestDB(t)
+
+	ids, err := db.Listen(ctx)
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+
+	id := db.mustAlloc(t, 0)
+	if err := db.Init(ctx, []core.Resource{{ID: id, Data: []byte("1")}}); err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	select {
+	case received := <-ids:
+		if received != id {
+			t.Fatalf("unexpected notification: %s", received)
+		}
+	case <-ctx.Done():
+		t.Fatal("the initialization has not been notified")
+	}
+}
+
+func TestListenReconnects(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := newTestDB(t)
+
+	ids, err := db.Listen(ctx)
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+
+	_, err = db.Client.Exec(ctx,
+		`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query = $1 AND pid <> pg_backend_pid()`,
+		"LISTEN "+pgx.Identifier{db.Channel}.Sanitize())
+	if err != nil {
+		t.Fatalf("failed to terminate the listening connection: %s", err)
+	}
+
+	// notifications sent before the connection is reestablished are lost, so resources are initialized until one is received
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	initialized := map[string]bool{}
+	for {
+		select {
+		case received, ok := <-ids:
+			if !ok {
+				t.Fatal("the channel has been closed")
+			}
+			if !initialized[received] {
+				t.Fatalf("unexpected notification: %s", received)
+			}
+			return
+		case <-ticker.C:
+			id := db.mustAlloc(t, 0)
+			if err := db.Init(ctx, []core.Resource{{ID: id, Data: []byte("1")}}); err != nil {
+				t.Fatalf("failed to initialize: %s", err)
+			}
+			initialized[id] = true
+		case <-ctx.Done():
+			t.Fatal("notifications have not been resumed")
+		}
+	}
+}
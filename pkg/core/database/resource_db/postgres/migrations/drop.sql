@@ -0,0 +1 @@
+DROP TABLE resources;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE resources
+(
+    id         varchar(255),
+    status     varchar(32) NOT NULL,
+    data       bytea,
+    expires_at timestamptz,
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (id)
+);
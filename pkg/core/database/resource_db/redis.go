@@ -10,8 +10,12 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
 	"strings"
+	"time"
 )
 
+// expiredRetention is how long expired resources are reported as expired (instead of not existing)
+const expiredRetention = 24 * time.Hour
+
 type RedisDB struct {
 	client    redis.UniversalClient
 	codec     codec.Codec[core.Resource, []byte]
@@ -27,34 +31,14 @@ func NewRedisDB(client redis.UniversalClient, codec codec.Codec[core.Resource, [
 }
 
 func (storage *RedisDB) Load(ctx context.Context, ids ...string) ([]core.Resource, error) {
-	if len(ids) == 0 {
-		return []core.Resource{}, nil
-	}
-
-	cmd := storage.client.MGet(ctx, lo.Map(ids, func(id string, _ int) string {
-		return storage.globalResourceID(id)
-	})...)
-	if cmd.Err() != nil {
-		return nil, cmd.Err()
-	}
-
-	resources := make([]core.Resource, 0, len(ids))
-
-	for index, value := range cmd.Val() {
-		id := ids[index]
-
-		res, err := storage.parseResourceFromRedisValue(id, value)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse the resource_db (id='%s'): %w", id, err)
-		}
-
-		resources = append(resources, res)
-	}
-
-	return resources, nil
+	return storage.load(ctx, storage.client, ids)
 }
 
 func (storage *RedisDB) Alloc(ctx context.Context, amount int) ([]string, error) {
+	return storage.AllocWithTTL(ctx, amount, 0)
+}
+
+func (storage *RedisDB) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
 	if amount == 0 {
 		return []string{}, nil
 	}
@@ -63,23 +47,23 @@ func (storage *RedisDB) Alloc(ctx context.Context, amount int) ([]string, error)
 		return storage.generateKey()
 	})
 
-	var arguments []any
+	_, err := storage.client.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
+		for _, id := range ids {
+			encodedResource, err := storage.codec.Encode(core.Resource{
+				ID:     id,
+				Status: core.ResourceStatuses.Allocated,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode the resource_db: %w", err)
+			}
 
-	for _, id := range ids {
-		encodedResource, err := storage.codec.Encode(core.Resource{
-			ID:     id,
-			Status: core.ResourceStatuses.Allocated,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode the resource_db: %w", err)
+			storage.set(ctx, pipeliner, id, encodedResource, ttl)
 		}
 
-		arguments = append(arguments, storage.globalResourceID(id), encodedResource)
-	}
-
-	cmd := storage.client.MSet(ctx, arguments)
-	if cmd.Err() != nil {
-		return nil, cmd.Err()
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return ids, nil
@@ -92,24 +76,20 @@ func (storage *RedisDB) Init(ctx context.Context, resources []core.Resource) err
 	resourceIDs := lo.Map(resources, func(res core.Resource, _ int) string {
 		return res.ID
 	})
-	globalResourceIDs := lo.Map(resourceIDs, func(localID string, _ int) string {
-		return storage.globalResourceID(localID)
+	watchedKeys := lo.FlatMap(resourceIDs, func(localID string, _ int) []string {
+		return []string{storage.globalResourceID(localID), storage.expiryMarkerID(localID)}
 	})
 
 	tx := func(tx *redis.Tx) error {
-		loadedResources, err := tx.MGet(ctx, globalResourceIDs...).Result()
+		loadedResources, err := storage.load(ctx, tx, resourceIDs)
 		if err != nil {
 			return fmt.Errorf("failed to load resources: %w", err)
 		}
 
-		newValues := make([][]byte, 0, len(globalResourceIDs))
+		newValues := make([][]byte, 0, len(resources))
 
 		for _, pair := range lo.Zip2(resources, loadedResources) {
-			providedResource, rawLoadedResource := pair.A, pair.B
-			loadedResource, err := storage.parseResourceFromRedisValue(providedResource.ID, rawLoadedResource)
-			if err != nil {
-				return fmt.Errorf("failed to parse the redis value (id='%s'): %w", providedResource.ID, err)
-			}
+			providedResource, loadedResource := pair.A, pair.B
 
 			if loadedResource.Status != core.ResourceStatuses.Allocated {
 				return fmt.Errorf("can't initialize a resource_db with status '%s' (id='%s')",
@@ -129,17 +109,21 @@ func (storage *RedisDB) Init(ctx context.Context, resources []core.Resource) err
 		}
 
 		_, err = tx.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
-			arguments := lo.Interleave[any](
-				lo.ToAnySlice(globalResourceIDs),
-				lo.ToAnySlice(newValues),
-			)
-			return pipeliner.MSet(ctx, arguments).Err()
+			for index, res := range resources {
+				if res.TTL > 0 {
+					storage.set(ctx, pipeliner, res.ID, newValues[index], res.TTL)
+				} else {
+					// the ttl set at allocation is kept
+					pipeliner.Set(ctx, storage.globalResourceID(res.ID), newValues[index], redis.KeepTTL)
+				}
+			}
+			return nil
 		})
 
 		return err
 	}
 
-	err := storage.client.Watch(ctx, tx, globalResourceIDs...)
+	err := storage.client.Watch(ctx, tx, watchedKeys...)
 
 	if err != nil {
 		return fmt.Errorf("redis transaction failed: %w", err)
@@ -153,12 +137,63 @@ func (storage *RedisDB) Dealloc(ctx context.Context, ids []string) error {
 		return nil
 	}
 	return storage.client.
-		Del(ctx, lo.Map(ids, func(id string, _ int) string {
-			return storage.globalResourceID(id)
+		Del(ctx, lo.FlatMap(ids, func(id string, _ int) []string {
+			return []string{storage.globalResourceID(id), storage.expiryMarkerID(id)}
 		})...).
 		Err()
 }
 
+func (storage *RedisDB) load(ctx context.Context, client redis.Cmdable, ids []string) ([]core.Resource, error) {
+	if len(ids) == 0 {
+		return []core.Resource{}, nil
+	}
+
+	keys := make([]string, 0, 2*len(ids))
+	for _, id := range ids {
+		keys = append(keys, storage.globalResourceID(id))
+	}
+	for _, id := range ids {
+		keys = append(keys, storage.expiryMarkerID(id))
+	}
+
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.Resource, 0, len(ids))
+
+	for index, id := range ids {
+		value, marker := values[index], values[len(ids)+index]
+
+		// the resource has been evicted by redis, but the marker is still alive
+		if value == nil && marker != nil {
+			resources = append(resources, core.Resource{
+				ID:     id,
+				Status: core.ResourceStatuses.Expired,
+			})
+			continue
+		}
+
+		res, err := storage.parseResourceFromRedisValue(id, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the resource_db (id='%s'): %w", id, err)
+		}
+
+		resources = append(resources, res)
+	}
+
+	return resources, nil
+}
+
+// set stores the resource with the ttl (if it's positive) and marks it as expiring
+func (storage *RedisDB) set(ctx context.Context, pipeliner redis.Pipeliner, id string, value []byte, ttl time.Duration) {
+	pipeliner.Set(ctx, storage.globalResourceID(id), value, ttl)
+	if ttl > 0 {
+		pipeliner.Set(ctx, storage.expiryMarkerID(id), "", ttl+expiredRetention)
+	}
+}
+
 func (storage *RedisDB) parseResourceFromRedisValue(id string, value any) (res core.Resource, err error) {
 	if value == nil {
 		res = core.Resource{
@@ -187,3 +222,7 @@ func (storage *RedisDB) generateKey() string {
 func (storage *RedisDB) globalResourceID(key string) string {
 	return fmt.Sprintf("%s:%s", storage.keyPrefix, key)
 }
+
+func (storage *RedisDB) expiryMarkerID(key string) string {
+	return fmt.Sprintf("%s:%s:expiry", storage.keyPrefix, key)
+}
@@ -0,0 +1,338 @@
+package resourcedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/blob"
+	"github.com/ischenkx/kantoku/pkg/common/data/uid"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/samber/lo"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// Data in the primary database starts with a marker telling whether it's the payload itself
+// or a key of the blob with the payload
+const (
+	inlineMarker byte = iota
+	blobMarker
+)
+
+// TieredDB keeps resources in the primary database and spills payloads larger than the threshold
+// to the blob store.
+//
+// Every initialization writes its own blob ({resource id}.{unique suffix}), the key is stored in the primary database,
+// so a failed (e.g. concurrent) initialization deletes only the blob it has written.
+// Blobs of expired resources are deleted when they are loaded or deallocated.
+type TieredDB struct {
+	primary   core.ResourceDB
+	blobs     blob.Store
+	threshold int
+	logger    *slog.Logger
+}
+
+func NewTieredDB(primary core.ResourceDB, blobs blob.Store, threshold int, logger *slog.Logger) *TieredDB {
+	return &TieredDB{
+		primary:   primary,
+		blobs:     blobs,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+func (storage *TieredDB) Load(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	resources, err := storage.primary.Load(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []string
+	for index, res := range resources {
+		switch res.Status {
+		case core.ResourceStatuses.Expired:
+			expired = append(expired, res.ID)
+		case core.ResourceStatuses.Ready:
+			data, key, err := decodeStored(res.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the resource (id='%s'): %w", res.ID, err)
+			}
+
+			if key != "" {
+				data, err = storage.blobs.Get(ctx, key)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load the blob (id='%s'): %w", res.ID, err)
+				}
+			}
+			resources[index].Data = data
+		}
+	}
+
+	storage.cleanupExpired(ctx, expired)
+
+	return resources, nil
+}
+
+// LoadStatuses loads resources from the primary database without downloading their blobs
+func (storage *TieredDB) LoadStatuses(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	resources, err := storage.primary.Load(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []string
+	for index, res := range resources {
+		if res.Status == core.ResourceStatuses.Expired {
+			expired = append(expired, res.ID)
+		}
+		resources[index].Data = nil
+	}
+
+	storage.cleanupExpired(ctx, expired)
+
+	return resources, nil
+}
+
+func (storage *TieredDB) Alloc(ctx context.Context, amount int) ([]string, error) {
+	return storage.primary.Alloc(ctx, amount)
+}
+
+func (storage *TieredDB) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
+	return core.AllocWithTTL(ctx, storage.primary, amount, ttl)
+}
+
+func (storage *TieredDB) Init(ctx context.Context, resources []core.Resource) error {
+	large := lo.Filter(resources, func(res core.Resource, _ int) bool {
+		return len(res.Data) > storage.threshold
+	})
+
+	if len(large) > 0 {
+		// failing fast to avoid uploading blobs of initialized resources
+		loaded, err := storage.primary.Load(ctx, lo.Map(large, func(res core.Resource, _ int) string { return res.ID })...)
+		if err != nil {
+			return fmt.Errorf("failed to load resources: %w", err)
+		}
+
+		for _, res := range loaded {
+			if res.Status != core.ResourceStatuses.Allocated {
+				return fmt.Errorf("can't initialize a resource_db with status '%s' (id='%s')", res.Status, res.ID)
+			}
+		}
+	}
+
+	stored := make([]core.Resource, 0, len(resources))
+	var written []string
+	for _, res := range resources {
+		if len(res.Data) > storage.threshold {
+			key := blobKey(res.ID)
+			if err := storage.blobs.Put(ctx, key, res.Data); err != nil {
+				storage.cleanup(ctx, written)
+				return fmt.Errorf("failed to store the blob (id='%s'): %w", res.ID, err)
+			}
+			written = append(written, key)
+
+			res.Data = encodeBlobKey(key)
+		} else {
+			res.Data = encodeInline(res.Data)
+		}
+
+		stored = append(stored, res)
+	}
+
+	if err := storage.primary.Init(ctx, stored); err != nil {
+		storage.cleanup(ctx, written)
+		return err
+	}
+
+	return nil
+}
+
+func (storage *TieredDB) Dealloc(ctx context.Context, ids []string) error {
+	if err := storage.primary.Dealloc(ctx, ids); err != nil {
+		return err
+	}
+
+	if err := storage.deleteBlobs(ctx, ids); err != nil {
+		return fmt.Errorf("failed to delete blobs: %w", err)
+	}
+
+	return nil
+}
+
+// OpenReader streams spilled payloads directly from the blob store
+func (storage *TieredDB) OpenReader(ctx context.Context, id string) (io.ReadCloser, error) {
+	resources, err := storage.primary.Load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the resource: %w", err)
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("unexpected amount of loaded resources: %d", len(resources))
+	}
+
+	res := resources[0]
+	switch res.Status {
+	case core.ResourceStatuses.Ready:
+	case core.ResourceStatuses.Expired:
+		// the error is ignored as the blobs are deleted on deallocation anyway
+		_ = storage.deleteBlobs(ctx, []string{id})
+		return nil, fmt.Errorf("failed to read '%s': %w", id, core.ErrResourceExpired)
+	default:
+		return nil, fmt.Errorf("resource '%s' is not ready (status='%s')", id, res.Status)
+	}
+
+	data, key, err := decodeStored(res.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the resource (id='%s'): %w", id, err)
+	}
+
+	if key == "" {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	reader, err := storage.blobs.Open(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the blob (id='%s'): %w", id, err)
+	}
+
+	return reader, nil
+}
+
+// OpenWriter buffers the payload in memory until it exceeds the threshold, then it's streamed to the blob store
+func (storage *TieredDB) OpenWriter(ctx context.Context, id string) (io.WriteCloser, error) {
+	resources, err := storage.primary.Load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the resource: %w", err)
+	}
+	if len(resources) != 1 || resources[0].Status != core.ResourceStatuses.Allocated {
+		return nil, fmt.Errorf("can't initialize a resource_db that is not allocated (id='%s')", id)
+	}
+
+	return &tieredWriter{ctx: ctx, storage: storage, id: id}, nil
+}
+
+// cleanup deletes blobs written by a failed initialization
+func (storage *TieredDB) cleanup(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	// the error is ignored as the blobs are deleted on deallocation anyway
+	_ = storage.blobs.Delete(ctx, keys...)
+}
+
+// cleanupExpired deletes blobs of expired resources, failures are only logged as the blobs
+// are deleted on deallocation anyway
+func (storage *TieredDB) cleanupExpired(ctx context.Context, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := storage.deleteBlobs(ctx, ids); err != nil {
+		storage.logger.Warn("failed to delete blobs of expired resources",
+			slog.Any("ids", ids),
+			slog.String("error", err.Error()))
+	}
+}
+
+// deleteBlobs deletes all blobs of the resources
+func (storage *TieredDB) deleteBlobs(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		keys, err := storage.blobs.Keys(ctx, id+".")
+		if err != nil {
+			return fmt.Errorf("failed to list blobs (id='%s'): %w", id, err)
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		if err := storage.blobs.Delete(ctx, keys...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func blobKey(id string) string {
+	return id + "." + uid.Generate()
+}
+
+func encodeInline(data []byte) []byte {
+	return append([]byte{inlineMarker}, data...)
+}
+
+func encodeBlobKey(key string) []byte {
+	return append([]byte{blobMarker}, key...)
+}
+
+// decodeStored returns either the payload or a key of the blob with the payload
+func decodeStored(stored []byte) (data []byte, key string, err error) {
+	if len(stored) == 0 {
+		return nil, "", errors.New("no marker")
+	}
+
+	switch stored[0] {
+	case inlineMarker:
+		return stored[1:], "", nil
+	case blobMarker:
+		return nil, string(stored[1:]), nil
+	default:
+		return nil, "", fmt.Errorf("unknown marker: %d", stored[0])
+	}
+}
+
+type tieredWriter struct {
+	ctx     context.Context
+	storage *TieredDB
+	id      string
+	buffer  bytes.Buffer
+	// blob and key are set when the payload exceeds the threshold
+	blob io.WriteCloser
+	key  string
+}
+
+func (writer *tieredWriter) Write(data []byte) (int, error) {
+	if writer.blob != nil {
+		return writer.blob.Write(data)
+	}
+
+	writer.buffer.Write(data)
+	if writer.buffer.Len() <= writer.storage.threshold {
+		return len(data), nil
+	}
+
+	key := blobKey(writer.id)
+	blob, err := writer.storage.blobs.Create(writer.ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the blob: %w", err)
+	}
+	writer.blob = blob
+	writer.key = key
+
+	if _, err := blob.Write(writer.buffer.Bytes()); err != nil {
+		return 0, err
+	}
+	writer.buffer = bytes.Buffer{}
+
+	return len(data), nil
+}
+
+func (writer *tieredWriter) Close() error {
+	if writer.blob == nil {
+		return writer.storage.primary.Init(writer.ctx, []core.Resource{{ID: writer.id, Data: encodeInline(writer.buffer.Bytes())}})
+	}
+
+	if err := writer.blob.Close(); err != nil {
+		return fmt.Errorf("failed to store the blob (id='%s'): %w", writer.id, err)
+	}
+
+	if err := writer.storage.primary.Init(writer.ctx, []core.Resource{{ID: writer.id, Data: encodeBlobKey(writer.key)}}); err != nil {
+		writer.storage.cleanup(writer.ctx, []string{writer.key})
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package resourcedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/common/data/blob"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/samber/lo"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestTieredDB(t *testing.T) {
+	ctx := context.Background()
+
+	blobs, err := blob.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create a blob store: %s", err)
+	}
+
+	primary := NewMockDB()
+	db := NewTieredDB(primary, blobs, 8, slog.Default())
+
+	ids, err := db.Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	small, large := []byte("small"), bytes.Repeat([]byte("x"), 64)
+	err = db.Init(ctx, []core.Resource{
+		{ID: ids[0], Data: small},
+		{ID: ids[1], Data: large},
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	stored, err := primary.Load(ctx, ids...)
+	if err != nil {
+		t.Fatalf("failed to load from the primary database: %s", err)
+	}
+	if !bytes.HasSuffix(stored[0].Data, small) || bytes.HasSuffix(stored[1].Data, large) {
+		t.Fatal("only the large payload is expected to be spilled")
+	}
+
+	// the blob is keyed by the resource id and a unique suffix
+	keys, err := blobs.Keys(ctx, ids[1]+".")
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("expected a single blob, got: %v (%v)", keys, err)
+	}
+
+	resources, err := db.Load(ctx, ids...)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+	if !bytes.Equal(resources[0].Data, small) || !bytes.Equal(resources[1].Data, large) {
+		t.Fatalf("unexpected data: %q, %q", resources[0].Data, resources[1].Data)
+	}
+
+	// initialized resources can't be overwritten
+	if err := db.Init(ctx, []core.Resource{{ID: ids[1], Data: bytes.Repeat([]byte("y"), 64)}}); err == nil {
+		t.Fatal("an initialized resource has been overwritten")
+	}
+
+	// a losing initialization (the primary database rejects it) deletes only its own blob
+	losing := NewTieredDB(rejectingDB{primary}, blobs, 8, slog.Default())
+	if err := losing.Init(ctx, []core.Resource{{ID: ids[1], Data: bytes.Repeat([]byte("y"), 64)}}); err == nil {
+		t.Fatal("expected the initialization to fail")
+	}
+	resources, err = db.Load(ctx, ids[1])
+	if err != nil || !bytes.Equal(resources[0].Data, large) {
+		t.Fatalf("the blob of the initialized resource has been affected: %q (%v)", resources[0].Data, err)
+	}
+	if keys, _ := blobs.Keys(ctx, ids[1]+"."); len(keys) != 1 {
+		t.Fatalf("expected a single blob, got: %v", keys)
+	}
+
+	if err := db.Dealloc(ctx, ids); err != nil {
+		t.Fatalf("failed to deallocate: %s", err)
+	}
+	if _, err := blobs.Get(ctx, keys[0]); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("the blob has not been deleted: %v", err)
+	}
+}
+
+// rejectingDB emulates a concurrent initialization that has been won by someone else
+type rejectingDB struct {
+	core.ResourceDB
+}
+
+func (db rejectingDB) Load(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	return lo.Map(ids, func(id string, _ int) core.Resource {
+		return core.Resource{ID: id, Status: core.ResourceStatuses.Allocated}
+	}), nil
+}
+
+func (db rejectingDB) Init(ctx context.Context, resources []core.Resource) error {
+	return errors.New("already initialized")
+}
+
+func TestTieredDBStreaming(t *testing.T) {
+	ctx := context.Background()
+
+	blobs, err := blob.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create a blob store: %s", err)
+	}
+
+	primary := NewMockDB()
+	db := NewTieredDB(primary, blobs, 8, slog.Default())
+
+	ids, err := db.Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	payloads := map[string][]byte{
+		ids[0]: []byte("small"),
+		ids[1]: bytes.Repeat([]byte("chunk;"), 16),
+	}
+
+	for id, payload := range payloads {
+		writer, err := db.OpenWriter(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to open a writer: %s", err)
+		}
+		for _, chunk := range bytes.SplitAfter(payload, []byte(";")) {
+			if _, err := writer.Write(chunk); err != nil {
+				t.Fatalf("failed to write: %s", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("failed to close the writer: %s", err)
+		}
+	}
+
+	if keys, err := blobs.Keys(ctx, ids[1]+"."); err != nil || len(keys) != 1 {
+		t.Fatalf("the large payload has not been spilled: %v (%v)", keys, err)
+	}
+
+	for id, payload := range payloads {
+		reader, err := db.OpenReader(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to open a reader: %s", err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil || !bytes.Equal(data, payload) {
+			t.Fatalf("unexpected data: %q (%v)", data, err)
+		}
+	}
+
+	if _, err := db.OpenWriter(ctx, ids[0]); err == nil {
+		t.Fatal("an initialized resource has been opened for writing")
+	}
+}
+
+func TestTieredDBLoadStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	blobs, err := blob.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create a blob store: %s", err)
+	}
+
+	db := NewTieredDB(NewMockDB(), blobs, 8, slog.Default())
+
+	ids, err := db.Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+	if err := db.Init(ctx, []core.Resource{{ID: ids[0], Data: bytes.Repeat([]byte("x"), 64)}}); err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	// statuses are loaded without the blobs, so their absence goes unnoticed
+	keys, err := blobs.Keys(ctx, ids[0]+".")
+	if err != nil {
+		t.Fatalf("failed to list blobs: %s", err)
+	}
+	if err := blobs.Delete(ctx, keys...); err != nil {
+		t.Fatalf("failed to delete blobs: %s", err)
+	}
+
+	resources, err := core.LoadStatuses(ctx, db, ids...)
+	if err != nil {
+		t.Fatalf("failed to load statuses: %s", err)
+	}
+	if len(resources) != 1 || resources[0].Status != core.ResourceStatuses.Ready || resources[0].Data != nil {
+		t.Fatalf("unexpected resources: %+v", resources)
+	}
+}
@@ -0,0 +1,328 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/data/storage"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	_ core.TaskDB      = (*DB)(nil)
+	_ core.PagedTaskDB = (*DB)(nil)
+)
+
+// DB is an in-memory core.TaskDB.
+//
+// Tasks are stored as documents (the same way they are stored in mongo),
+// so property queries use dotted paths (e.g. "info.status") and nil stands for a missing value.
+// All operations are atomic.
+type DB struct {
+	codec codec.Codec[core.Task, map[string]any]
+	docs  map[string]storage.Document
+	order []string
+	mu    sync.RWMutex
+}
+
+func New() *DB {
+	return &DB{
+		codec: core.TaskCodec{},
+		docs:  make(map[string]storage.Document),
+	}
+}
+
+func (db *DB) Settings(ctx context.Context) (storage.Settings, error) {
+	return storage.Settings{
+		Type: "memory",
+		Meta: make(map[string]any),
+	}, nil
+}
+
+func (db *DB) Insert(ctx context.Context, tasks []core.Task) error {
+	docs := make([]storage.Document, 0, len(tasks))
+	for _, task := range tasks {
+		doc, err := db.codec.Encode(task)
+		if err != nil {
+			return fmt.Errorf("failed to encode task: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.insert(docs)
+}
+
+func (db *DB) Delete(ctx context.Context, ids []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.delete(lo.Uniq(ids))
+
+	return nil
+}
+
+func (db *DB) ByIDs(ctx context.Context, ids []string) ([]core.Task, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var result []core.Task
+	for _, id := range lo.Uniq(ids) {
+		doc, ok := db.docs[id]
+		if !ok {
+			continue
+		}
+
+		task, err := db.decode(doc)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, task)
+	}
+
+	return result, nil
+}
+
+func (db *DB) UpdateByIDs(ctx context.Context, ids []string, properties map[string]any) error {
+	filter := propertiesToFilter(map[string][]any{"id": lo.ToAnySlice(ids)})
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	_, err := db.update(filter, map[string]any{"$set": properties}, true)
+
+	return err
+}
+
+func (db *DB) GetWithProperties(ctx context.Context, propertiesToValues map[string][]any) ([]core.Task, error) {
+	filter := propertiesToFilter(propertiesToValues)
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	docs, err := db.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]core.Task, 0, len(docs))
+	for _, doc := range docs {
+		task, err := db.decode(doc)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, task)
+	}
+
+	return result, nil
+}
+
+func (db *DB) UpdateWithProperties(ctx context.Context, propertiesToValues map[string][]any, newProperties map[string]any) (int, error) {
+	filter := propertiesToFilter(propertiesToValues)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.update(filter, map[string]any{"$set": newProperties}, true)
+}
+
+func (db *DB) GetPage(ctx context.Context, propertiesToValues map[string][]any, orderBy string, after core.PageCursor, limit int) ([]core.Task, error) {
+	filter := propertiesToFilter(propertiesToValues)
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	docs, err := db.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		doc   storage.Document
+		value float64
+		id    string
+	}
+
+	var entries []entry
+	for _, doc := range docs {
+		values, _ := lookup(doc, strings.Split(orderBy, "."))
+		if len(values) == 0 {
+			continue
+		}
+
+		value, ok := toFloat(values[0])
+		if !ok {
+			continue
+		}
+
+		id, _ := doc["id"].(string)
+		if value < float64(after.Value) || (value == float64(after.Value) && id <= after.ID) {
+			continue
+		}
+
+		entries = append(entries, entry{doc: doc, value: value, id: id})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].value != entries[j].value {
+			return entries[i].value < entries[j].value
+		}
+		return entries[i].id < entries[j].id
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	result := make([]core.Task, 0, len(entries))
+	for _, entry := range entries {
+		task, err := db.decode(entry.doc)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, task)
+	}
+
+	return result, nil
+}
+
+func (db *DB) decode(doc storage.Document) (core.Task, error) {
+	task, err := db.codec.Decode(normalize(doc).(map[string]any))
+	if err != nil {
+		return core.Task{}, fmt.Errorf("failed to decode task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (db *DB) insert(docs []storage.Document) error {
+	normalized := make([]storage.Document, 0, len(docs))
+	for _, doc := range docs {
+		id, ok := doc["id"].(string)
+		if !ok {
+			return fmt.Errorf("document has no string id")
+		}
+
+		if _, exists := db.docs[id]; exists {
+			return fmt.Errorf("duplicate id: %s", id)
+		}
+
+		normalized = append(normalized, normalize(doc).(map[string]any))
+	}
+
+	// all documents are checked before the insertion, so it is all-or-nothing
+	for _, doc := range normalized {
+		id := doc["id"].(string)
+		db.docs[id] = doc
+		db.order = append(db.order, id)
+	}
+
+	return nil
+}
+
+func (db *DB) delete(ids []string) int {
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := db.docs[id]; ok {
+			delete(db.docs, id)
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		db.order = lo.Filter(db.order, func(id string, _ int) bool {
+			_, ok := db.docs[id]
+			return ok
+		})
+	}
+
+	return deleted
+}
+
+// find returns matching documents in the insertion order
+func (db *DB) find(filter map[string]any) ([]storage.Document, error) {
+	var result []storage.Document
+	for _, id := range db.order {
+		doc := db.docs[id]
+
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			result = append(result, doc)
+		}
+	}
+
+	return result, nil
+}
+
+func (db *DB) update(filter map[string]any, update map[string]any, multi bool) (int, error) {
+	properties, err := setOperand(update)
+	if err != nil {
+		return 0, err
+	}
+
+	docs, err := db.find(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	if !multi && len(docs) > 1 {
+		docs = docs[:1]
+	}
+
+	// changes are applied to copies, so nothing is updated if any of the documents fails
+	updated := make([]storage.Document, 0, len(docs))
+	for _, doc := range docs {
+		updatedDoc := normalize(doc).(map[string]any)
+		if err := setProperties(updatedDoc, properties); err != nil {
+			return 0, err
+		}
+		updated = append(updated, updatedDoc)
+	}
+
+	for index, doc := range docs {
+		db.docs[doc["id"].(string)] = updated[index]
+	}
+
+	return len(updated), nil
+}
+
+func setOperand(update map[string]any) (map[string]any, error) {
+	for key := range update {
+		if key != "$set" {
+			return nil, fmt.Errorf("unsupported update operator: %s", key)
+		}
+	}
+
+	rawSet, ok := update["$set"]
+	if !ok {
+		return nil, fmt.Errorf("no $set in the update")
+	}
+
+	set, ok := normalize(rawSet).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("$set must be an object")
+	}
+
+	return set, nil
+}
+
+func propertiesToFilter(propertiesToValues map[string][]any) map[string]any {
+	filter := make(map[string]any, len(propertiesToValues))
+	for key, values := range propertiesToValues {
+		filter[key] = map[string]any{
+			"$in": lo.ToAnySlice(values),
+		}
+	}
+
+	return filter
+}
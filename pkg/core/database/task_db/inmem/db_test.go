@@ -0,0 +1,218 @@
+package inmem
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/data/storage"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"slices"
+	"testing"
+)
+
+func TestProperties(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "1", Inputs: []string{"a"}, Info: map[string]any{"status": "ready", "meta": map[string]any{"attempt": 1}}},
+		{ID: "2", Inputs: []string{"b"}, Info: map[string]any{"status": "finished"}},
+		{ID: "3", Info: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	cases := []struct {
+		name     string
+		query    map[string][]any
+		expected []string
+	}{
+		{"equality", map[string][]any{"info.status": {"ready"}}, []string{"1"}},
+		{"several values", map[string][]any{"info.status": {"ready", "finished"}}, []string{"1", "2"}},
+		{"missing", map[string][]any{"info.status": {nil}}, []string{"3"}},
+		{"nested path", map[string][]any{"info.meta.attempt": {int64(1)}}, []string{"1"}},
+		{"array element", map[string][]any{"inputs": {"b"}}, []string{"2"}},
+		{"no values", map[string][]any{"info.status": {}}, nil},
+	}
+
+	for _, c := range cases {
+		tasks, err := db.GetWithProperties(ctx, c.query)
+		if err != nil {
+			t.Fatalf("%s: failed to query: %s", c.name, err)
+		}
+
+		if len(tasks) != len(c.expected) {
+			t.Fatalf("%s: expected %d tasks, got %d", c.name, len(c.expected), len(tasks))
+		}
+
+		for index, task := range tasks {
+			if task.ID != c.expected[index] {
+				t.Fatalf("%s: expected task '%s', got '%s'", c.name, c.expected[index], task.ID)
+			}
+		}
+	}
+}
+
+func TestConditionalUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	if err := db.Insert(ctx, []core.Task{{ID: "1", Info: map[string]any{}}}); err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	// only the first update must succeed
+	for attempt, expected := range []int{1, 0} {
+		updated, err := db.UpdateWithProperties(ctx,
+			map[string][]any{"id": {"1"}, "info.status": {nil}},
+			map[string]any{"info.status": "initialized", "info.dependencies.group_id": "g"},
+		)
+		if err != nil {
+			t.Fatalf("failed to update: %s", err)
+		}
+
+		if updated != expected {
+			t.Fatalf("attempt %d: expected %d updated tasks, got %d", attempt, expected, updated)
+		}
+	}
+
+	tasks, err := db.ByIDs(ctx, []string{"1"})
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	dependencies, _ := tasks[0].Info["dependencies"].(map[string]any)
+	if tasks[0].Info["status"] != "initialized" || dependencies["group_id"] != "g" {
+		t.Fatalf("unexpected info: %v", tasks[0].Info)
+	}
+
+	// returned tasks must not share memory with the stored ones
+	tasks[0].Info["status"] = "modified"
+	tasks, _ = db.ByIDs(ctx, []string{"1"})
+	if tasks[0].Info["status"] != "initialized" {
+		t.Fatalf("stored task was modified through the returned one")
+	}
+}
+
+func TestExec(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "1", Info: map[string]any{"updated_at": 3}},
+		{ID: "2", Info: map[string]any{"updated_at": 1}},
+		{ID: "3", Info: map[string]any{"updated_at": 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	docs, err := db.Exec(ctx, storage.Command{
+		Operation: "find",
+		Params: []storage.Param{
+			{Name: "filter", Value: map[string]any{"info.updated_at": map[string]any{"$gte": 2}}},
+			{Name: "sort", Value: map[string]any{"info.updated_at": -1}},
+			{Name: "limit", Value: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to exec: %s", err)
+	}
+
+	batch := docs[0]["cursor"].(map[string]any)["firstBatch"].([]any)
+	if len(batch) != 1 || batch[0].(map[string]any)["id"] != "1" {
+		t.Fatalf("unexpected batch: %v", batch)
+	}
+
+	docs, err = db.Exec(ctx, storage.Command{
+		Operation: "count",
+		Params:    []storage.Param{{Name: "query", Value: []any{}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to exec: %s", err)
+	}
+
+	if docs[0]["n"] != 3 {
+		t.Fatalf("expected 3 documents, got %v", docs[0]["n"])
+	}
+
+	_, err = db.Exec(ctx, storage.Command{
+		Operation: "delete",
+		Params:    []storage.Param{{Name: "deletes", Value: []any{}}},
+		Meta:      map[string]any{"readonly": true},
+	})
+	if err == nil {
+		t.Fatalf("expected the read-only constraint to be violated")
+	}
+}
+
+func TestFailedUpdateChangesNothing(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "1", Info: map[string]any{"meta": map[string]any{}}},
+		{ID: "2", Info: map[string]any{"meta": "not an object"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	err = db.UpdateByIDs(ctx, []string{"1", "2"}, map[string]any{"info.status": "updated", "info.meta.attempt": 1})
+	if err == nil {
+		t.Fatalf("expected the update to fail")
+	}
+
+	tasks, err := db.ByIDs(ctx, []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	for _, task := range tasks {
+		if _, ok := task.Info["status"]; ok {
+			t.Fatalf("task '%s' was partially updated: %v", task.ID, task.Info)
+		}
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "b", Info: map[string]any{"status": "finished", "finished_at": 1}},
+		{ID: "a", Info: map[string]any{"status": "finished", "finished_at": 1}},
+		{ID: "c", Info: map[string]any{"status": "finished", "finished_at": 0.5}},
+		{ID: "d", Info: map[string]any{"status": "finished"}},
+		{ID: "e", Info: map[string]any{"status": "ready", "finished_at": 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	var (
+		cursor core.PageCursor
+		ids    []string
+	)
+	for {
+		page, err := db.GetPage(ctx, map[string][]any{"info.status": {"finished"}}, "info.finished_at", cursor, 2)
+		if err != nil {
+			t.Fatalf("failed to get a page: %s", err)
+		}
+
+		for _, task := range page {
+			ids = append(ids, task.ID)
+		}
+
+		if len(page) < 2 {
+			break
+		}
+
+		last := page[len(page)-1]
+		finishedAt, _ := last.FinishedAt()
+		cursor = core.PageCursor{Value: finishedAt.Unix(), ID: last.ID}
+	}
+
+	if !slices.Equal(ids, []string{"c", "a", "b"}) {
+		t.Fatalf("unexpected order of tasks: %v", ids)
+	}
+}
@@ -0,0 +1,315 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/storage"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+var readCommands = []string{"find", "count"}
+
+// Exec supports a subset of mongo commands (https://www.mongodb.com/docs/manual/reference/command/):
+//   - find (filter, sort, skip, limit)
+//   - count (query)
+//   - insert (documents)
+//   - delete (deletes: [{q, limit}])
+//   - update (updates: [{q, u: {$set}, multi}])
+//
+// Responses have the same shape as mongo responses.
+func (db *DB) Exec(ctx context.Context, command storage.Command) ([]storage.Document, error) {
+	if rawIsReadOnly, ok := command.Meta["readonly"]; ok {
+		isReadOnly, ok := rawIsReadOnly.(bool)
+		if !ok {
+			return nil, fmt.Errorf("readonly mode must be set to 'true' or 'false'")
+		}
+
+		if isReadOnly && !lo.Contains(readCommands, command.Operation) {
+			return nil, fmt.Errorf("operation '%s' is not allowed (it violates the read-only constraint)", command.Operation)
+		}
+	}
+
+	params := make(map[string]any, len(command.Params))
+	for _, param := range command.Params {
+		params[param.Name] = normalize(param.Value)
+	}
+
+	switch command.Operation {
+	case "find":
+		return db.execFind(params)
+	case "count":
+		return db.execCount(params)
+	case "insert":
+		return db.execInsert(params)
+	case "delete":
+		return db.execDelete(params)
+	case "update":
+		return db.execUpdate(params)
+	default:
+		return nil, fmt.Errorf("unknown operation '%s'", command.Operation)
+	}
+}
+
+func (db *DB) execFind(params map[string]any) ([]storage.Document, error) {
+	filter, err := objectParam(params, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	docs, err := db.find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawSort, ok := params["sort"]; ok && rawSort != nil {
+		sortSpec, ok := rawSort.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("sort must be an object")
+		}
+		sortDocs(docs, sortSpec)
+	}
+
+	skip, err := intParam(params, "skip")
+	if err != nil {
+		return nil, err
+	}
+	if skip > len(docs) {
+		skip = len(docs)
+	}
+	docs = docs[skip:]
+
+	limit, err := intParam(params, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && limit < len(docs) {
+		docs = docs[:limit]
+	}
+
+	batch := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		batch = append(batch, normalize(doc))
+	}
+
+	return []storage.Document{
+		{
+			"cursor": map[string]any{
+				"firstBatch": batch,
+				"id":         int64(0),
+				"ns":         "memory.tasks",
+			},
+			"ok": 1.0,
+		},
+	}, nil
+}
+
+func (db *DB) execCount(params map[string]any) ([]storage.Document, error) {
+	query, err := objectParam(params, "query")
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	docs, err := db.find(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return []storage.Document{{"n": len(docs), "ok": 1.0}}, nil
+}
+
+func (db *DB) execInsert(params map[string]any) ([]storage.Document, error) {
+	rawDocs, ok := params["documents"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("documents must be an array")
+	}
+
+	docs := make([]storage.Document, 0, len(rawDocs))
+	for _, rawDoc := range rawDocs {
+		doc, ok := rawDoc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("documents must be objects")
+		}
+		docs = append(docs, doc)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.insert(docs); err != nil {
+		return nil, err
+	}
+
+	return []storage.Document{{"n": len(docs), "ok": 1.0}}, nil
+}
+
+func (db *DB) execDelete(params map[string]any) ([]storage.Document, error) {
+	statements, err := statementsParam(params, "deletes")
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	deleted := 0
+	for _, statement := range statements {
+		query, err := objectParam(statement, "q")
+		if err != nil {
+			return nil, err
+		}
+
+		limit, err := intParam(statement, "limit")
+		if err != nil {
+			return nil, err
+		}
+
+		docs, err := db.find(query)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit > 0 && limit < len(docs) {
+			docs = docs[:limit]
+		}
+
+		deleted += db.delete(lo.Map(docs, func(doc storage.Document, _ int) string {
+			return doc["id"].(string)
+		}))
+	}
+
+	return []storage.Document{{"n": deleted, "ok": 1.0}}, nil
+}
+
+func (db *DB) execUpdate(params map[string]any) ([]storage.Document, error) {
+	statements, err := statementsParam(params, "updates")
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	updated := 0
+	for _, statement := range statements {
+		query, err := objectParam(statement, "q")
+		if err != nil {
+			return nil, err
+		}
+
+		update, err := objectParam(statement, "u")
+		if err != nil {
+			return nil, err
+		}
+
+		multi, _ := statement["multi"].(bool)
+
+		n, err := db.update(query, update, multi)
+		if err != nil {
+			return nil, err
+		}
+		updated += n
+	}
+
+	return []storage.Document{{"n": updated, "nModified": updated, "ok": 1.0}}, nil
+}
+
+func sortDocs(docs []storage.Document, spec map[string]any) {
+	// the order of keys in a decoded object is lost, so keys are applied alphabetically
+	keys := lo.Keys(spec)
+	sort.Strings(keys)
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range keys {
+			direction, _ := toFloat(spec[key])
+
+			left, _ := lookup(docs[i], strings.Split(key, "."))
+			right, _ := lookup(docs[j], strings.Split(key, "."))
+
+			cmp := compareForSort(left, right)
+			if cmp == 0 {
+				continue
+			}
+
+			if direction < 0 {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// compareForSort puts missing values first
+func compareForSort(left, right []any) int {
+	switch {
+	case len(left) == 0 && len(right) == 0:
+		return 0
+	case len(left) == 0:
+		return -1
+	case len(right) == 0:
+		return 1
+	}
+
+	cmp, _ := compare(left[0], right[0])
+
+	return cmp
+}
+
+func objectParam(params map[string]any, name string) (map[string]any, error) {
+	raw, ok := params[name]
+	if !ok || raw == nil {
+		return map[string]any{}, nil
+	}
+
+	switch typed := raw.(type) {
+	case map[string]any:
+		return typed, nil
+	case []any:
+		// an empty filter might be passed as an empty array
+		if len(typed) == 0 {
+			return map[string]any{}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s must be an object", name)
+}
+
+func intParam(params map[string]any, name string) (int, error) {
+	raw, ok := params[name]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+
+	value, ok := toFloat(raw)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number", name)
+	}
+
+	return int(value), nil
+}
+
+func statementsParam(params map[string]any, name string) ([]map[string]any, error) {
+	raw, ok := params[name].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array", name)
+	}
+
+	statements := make([]map[string]any, 0, len(raw))
+	for _, rawStatement := range raw {
+		statement, ok := rawStatement.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an array of objects", name)
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
@@ -0,0 +1,342 @@
+package inmem
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// normalize deep copies a value converting all maps to map[string]any and all slices to []any
+func normalize(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typed))
+		for key, val := range typed {
+			result[key] = normalize(val)
+		}
+		return result
+	case []any:
+		result := make([]any, len(typed))
+		for index, val := range typed {
+			result[index] = normalize(val)
+		}
+		return result
+	case []byte:
+		return append([]byte(nil), typed...)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		if rv.IsNil() {
+			return nil
+		}
+		result := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[iter.Key().String()] = normalize(iter.Value().Interface())
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		result := make([]any, rv.Len())
+		for index := 0; index < rv.Len(); index++ {
+			result[index] = normalize(rv.Index(index).Interface())
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// lookup returns all values located at the dotted path.
+// Arrays on the way are traversed element by element (like in mongo).
+func lookup(value any, path []string) (values []any, exists bool) {
+	if len(path) == 0 {
+		return []any{value}, true
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		next, ok := typed[path[0]]
+		if !ok {
+			return nil, false
+		}
+		return lookup(next, path[1:])
+	case []any:
+		for _, item := range typed {
+			if _, isObject := item.(map[string]any); !isObject {
+				continue
+			}
+			itemValues, itemExists := lookup(item, path)
+			if itemExists {
+				exists = true
+				values = append(values, itemValues...)
+			}
+		}
+		return values, exists
+	default:
+		return nil, false
+	}
+}
+
+func matches(doc map[string]any, filter map[string]any) (bool, error) {
+	for key, condition := range filter {
+		var (
+			ok  bool
+			err error
+		)
+
+		switch key {
+		case "$and", "$or", "$nor":
+			ok, err = matchLogical(doc, key, condition)
+		default:
+			ok, err = matchField(doc, strings.Split(key, "."), condition)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func matchLogical(doc map[string]any, operator string, condition any) (bool, error) {
+	rawFilters, ok := normalize(condition).([]any)
+	if !ok {
+		return false, fmt.Errorf("%s expects an array", operator)
+	}
+
+	for _, rawFilter := range rawFilters {
+		filter, ok := rawFilter.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("%s expects an array of objects", operator)
+		}
+
+		matched, err := matches(doc, filter)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case operator == "$and" && !matched:
+			return false, nil
+		case operator == "$or" && matched:
+			return true, nil
+		case operator == "$nor" && matched:
+			return false, nil
+		}
+	}
+
+	return operator != "$or", nil
+}
+
+func matchField(doc map[string]any, path []string, condition any) (bool, error) {
+	values, exists := lookup(doc, path)
+
+	operators, isOperatorObject := normalize(condition).(map[string]any)
+	if isOperatorObject && len(operators) > 0 && strings.HasPrefix(firstKey(operators), "$") {
+		for operator, operand := range operators {
+			ok, err := matchOperator(values, exists, operator, operand)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	return matchOperator(values, exists, "$eq", condition)
+}
+
+func matchOperator(values []any, exists bool, operator string, operand any) (bool, error) {
+	switch operator {
+	case "$eq":
+		return anyEquals(values, exists, operand), nil
+	case "$ne":
+		return !anyEquals(values, exists, operand), nil
+	case "$in", "$nin":
+		operands, ok := normalize(operand).([]any)
+		if !ok {
+			return false, fmt.Errorf("%s expects an array", operator)
+		}
+
+		found := false
+		for _, candidate := range operands {
+			if anyEquals(values, exists, candidate) {
+				found = true
+				break
+			}
+		}
+
+		if operator == "$in" {
+			return found, nil
+		}
+		return !found, nil
+	case "$exists":
+		shouldExist, ok := operand.(bool)
+		if !ok {
+			return false, fmt.Errorf("$exists expects a boolean")
+		}
+		return exists == shouldExist, nil
+	case "$gt", "$gte", "$lt", "$lte":
+		for _, value := range flatten(values) {
+			cmp, comparable := compare(value, operand)
+			if !comparable {
+				continue
+			}
+
+			if (operator == "$gt" && cmp > 0) ||
+				(operator == "$gte" && cmp >= 0) ||
+				(operator == "$lt" && cmp < 0) ||
+				(operator == "$lte" && cmp <= 0) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported operator: %s", operator)
+	}
+}
+
+// anyEquals checks if any of the values (or elements of array values) equals to the operand.
+// nil operand matches missing values.
+func anyEquals(values []any, exists bool, operand any) bool {
+	operand = normalize(operand)
+
+	if operand == nil && !exists {
+		return true
+	}
+
+	for _, value := range values {
+		if equals(value, operand) {
+			return true
+		}
+
+		if array, ok := value.([]any); ok {
+			for _, item := range array {
+				if equals(item, operand) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func flatten(values []any) []any {
+	var result []any
+	for _, value := range values {
+		if array, ok := value.([]any); ok {
+			result = append(result, array...)
+			continue
+		}
+		result = append(result, value)
+	}
+
+	return result
+}
+
+func equals(a, b any) bool {
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// compare compares numbers (of any type) and strings
+func compare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(sa, sb), true
+	}
+
+	return 0, false
+}
+
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// setProperties sets values by dotted paths creating missing objects on the way
+func setProperties(doc map[string]any, properties map[string]any) error {
+	for key, value := range properties {
+		path := strings.Split(key, ".")
+
+		obj := doc
+		for _, part := range path[:len(path)-1] {
+			next, ok := obj[part]
+			if !ok || next == nil {
+				created := make(map[string]any)
+				obj[part] = created
+				obj = created
+				continue
+			}
+
+			nextObject, ok := next.(map[string]any)
+			if !ok {
+				return fmt.Errorf("failed to set '%s': '%s' is not an object", key, part)
+			}
+			obj = nextObject
+		}
+
+		obj[path[len(path)-1]] = normalize(value)
+	}
+
+	return nil
+}
+
+func firstKey(m map[string]any) string {
+	for key := range m {
+		return key
+	}
+
+	return ""
+}
@@ -11,7 +11,10 @@ import (
 	"reflect"
 )
 
-var _ core.TaskDB = (*MongoDB)(nil)
+var (
+	_ core.TaskDB      = (*MongoDB)(nil)
+	_ core.PagedTaskDB = (*MongoDB)(nil)
+)
 
 type MongoDB struct {
 	BaseStorage *storage.MongoStorage
@@ -158,6 +161,7 @@ func (ms *MongoDB) UpdateByIDs(ctx context.Context, ids []string, properties map
 						"u": map[string]any{
 							"$set": properties,
 						},
+						"multi": true,
 					},
 				},
 			},
@@ -178,13 +182,39 @@ func (ms *MongoDB) GetWithProperties(ctx context.Context, propertiesToValues map
 		}
 	}
 
+	return ms.find(ctx,
+		storage.Param{Name: "filter", Value: filter},
+		storage.Param{Name: "batchSize", Value: 200000000},
+		storage.Param{Name: "singleBatch", Value: true},
+	)
+}
+
+func (ms *MongoDB) GetPage(ctx context.Context, propertiesToValues map[string][]any, orderBy string, after core.PageCursor, limit int) ([]core.Task, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{orderBy: bson.M{"$gt": after.Value}},
+			bson.M{orderBy: after.Value, "id": bson.M{"$gt": after.ID}},
+		},
+	}
+	for key, value := range propertiesToValues {
+		filter[key] = bson.M{
+			"$in": value,
+		}
+	}
+
+	return ms.find(ctx,
+		storage.Param{Name: "filter", Value: filter},
+		storage.Param{Name: "sort", Value: bson.D{{Key: orderBy, Value: 1}, {Key: "id", Value: 1}}},
+		storage.Param{Name: "limit", Value: limit},
+		storage.Param{Name: "batchSize", Value: limit},
+		storage.Param{Name: "singleBatch", Value: true},
+	)
+}
+
+func (ms *MongoDB) find(ctx context.Context, params ...storage.Param) ([]core.Task, error) {
 	docs, err := ms.Exec(ctx, storage.Command{
 		Operation: "find",
-		Params: []storage.Param{
-			{"filter", filter},
-			{"batchSize", 200000000},
-			{"singleBatch", true},
-		},
+		Params:    params,
 	})
 	if err != nil {
 		return nil, err
@@ -252,6 +282,7 @@ func (ms *MongoDB) UpdateWithProperties(ctx context.Context, propertiesToValues
 						"u": map[string]any{
 							"$set": newProperties,
 						},
+						"multi": true,
 					},
 				},
 			},
@@ -0,0 +1,444 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/storage"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/lo"
+	"sort"
+	"strings"
+)
+
+var (
+	_ core.TaskDB      = (*DB)(nil)
+	_ core.PagedTaskDB = (*DB)(nil)
+)
+
+// DB stores tasks in a postgres table (see migrations/init.sql).
+//
+// Info is stored as jsonb, dotted property paths are mapped to jsonb paths:
+// "info.dependencies.group_id" becomes info #> '{dependencies,group_id}'.
+// nil in property queries matches both missing and null values.
+type DB struct {
+	Client *pgxpool.Pool
+	Table  string
+}
+
+func (db *DB) Settings(ctx context.Context) (storage.Settings, error) {
+	return storage.Settings{
+		Type: "postgres",
+		Meta: map[string]any{
+			"table": db.Table,
+		},
+	}, nil
+}
+
+// Exec runs raw sql:
+//
+//	Operation: "query"
+//	Params: [{"sql", "select ..."}, {"args", [...]}]
+//
+// Every returned row is converted to a document.
+// Setting Meta["readonly"] to true runs the query in a read-only transaction.
+func (db *DB) Exec(ctx context.Context, command storage.Command) ([]storage.Document, error) {
+	if command.Operation != "query" {
+		return nil, fmt.Errorf("unknown operation '%s'", command.Operation)
+	}
+
+	var (
+		sql  string
+		args []any
+	)
+	for _, param := range command.Params {
+		switch param.Name {
+		case "sql":
+			value, ok := param.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("sql must be a string")
+			}
+			sql = value
+		case "args":
+			value, ok := param.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("args must be an array")
+			}
+			args = value
+		default:
+			return nil, fmt.Errorf("unknown param '%s'", param.Name)
+		}
+	}
+
+	accessMode := pgx.ReadWrite
+	if rawIsReadOnly, ok := command.Meta["readonly"]; ok {
+		isReadOnly, ok := rawIsReadOnly.(bool)
+		if !ok {
+			return nil, fmt.Errorf("readonly mode must be set to 'true' or 'false'")
+		}
+
+		if isReadOnly {
+			accessMode = pgx.ReadOnly
+		}
+	}
+
+	tx, err := db.Client.BeginTx(ctx, pgx.TxOptions{AccessMode: accessMode})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+
+	docs, err := pgx.CollectRows(rows, pgx.RowToMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return docs, nil
+}
+
+func (db *DB) Insert(ctx context.Context, tasks []core.Task) error {
+	rows := make([][]any, 0, len(tasks))
+	for _, task := range tasks {
+		inputs, err := json.Marshal(task.Inputs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal inputs: %w", err)
+		}
+
+		outputs, err := json.Marshal(task.Outputs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outputs: %w", err)
+		}
+
+		info := task.Info
+		if info == nil {
+			info = map[string]any{}
+		}
+
+		encodedInfo, err := json.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("failed to marshal info: %w", err)
+		}
+
+		rows = append(rows, []any{task.ID, string(inputs), string(outputs), string(encodedInfo)})
+	}
+
+	// a single statement, so either all tasks are inserted or none
+	_, err := db.Client.CopyFrom(ctx,
+		pgx.Identifier{db.Table},
+		[]string{"id", "inputs", "outputs", "info"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert tasks: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) Delete(ctx context.Context, ids []string) error {
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE id = any ($1)`, db.table())
+
+	if _, err := db.Client.Exec(ctx, sql, ids); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) ByIDs(ctx context.Context, ids []string) ([]core.Task, error) {
+	sql := fmt.Sprintf(`SELECT id, inputs, outputs, info FROM %s WHERE id = any ($1)`, db.table())
+
+	return db.query(ctx, sql, ids)
+}
+
+func (db *DB) UpdateByIDs(ctx context.Context, ids []string, properties map[string]any) error {
+	_, err := db.UpdateWithProperties(ctx,
+		map[string][]any{
+			"id": lo.ToAnySlice(ids),
+		},
+		properties,
+	)
+
+	return err
+}
+
+func (db *DB) GetWithProperties(ctx context.Context, propertiesToValues map[string][]any) ([]core.Task, error) {
+	var q query
+
+	condition, err := q.condition(propertiesToValues)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT id, inputs, outputs, info FROM %s WHERE %s`, db.table(), condition)
+
+	return db.query(ctx, sql, q.args...)
+}
+
+func (db *DB) GetPage(ctx context.Context, propertiesToValues map[string][]any, orderBy string, after core.PageCursor, limit int) ([]core.Task, error) {
+	var q query
+
+	condition, err := q.condition(propertiesToValues)
+	if err != nil {
+		return nil, err
+	}
+
+	expression, err := q.path(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// LIMIT NULL is the same as no limit
+	var limitArg any = limit
+	if limit <= 0 {
+		limitArg = nil
+	}
+
+	sql := fmt.Sprintf(`SELECT id, inputs, outputs, info FROM %s
+		WHERE %s AND jsonb_typeof(%s) = 'number' AND ((%s)::numeric, id) > (%s, %s)
+		ORDER BY (%s)::numeric, id
+		LIMIT %s`,
+		db.table(),
+		condition,
+		expression,
+		expression, q.arg(after.Value), q.arg(after.ID),
+		expression,
+		q.arg(limitArg),
+	)
+
+	return db.query(ctx, sql, q.args...)
+}
+
+func (db *DB) UpdateWithProperties(ctx context.Context, propertiesToValues map[string][]any, newProperties map[string]any) (int, error) {
+	if len(newProperties) == 0 {
+		return 0, nil
+	}
+
+	var q query
+
+	assignments, err := q.assignments(newProperties)
+	if err != nil {
+		return 0, err
+	}
+
+	condition, err := q.condition(propertiesToValues)
+	if err != nil {
+		return 0, err
+	}
+
+	// a single statement: the condition is checked and the row is updated atomically
+	sql := fmt.Sprintf(`UPDATE %s SET %s WHERE %s`, db.table(), assignments, condition)
+
+	result, err := db.Client.Exec(ctx, sql, q.args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (db *DB) query(ctx context.Context, sql string, args ...any) ([]core.Task, error) {
+	rows, err := db.Client.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var result []core.Task
+	for rows.Next() {
+		var (
+			task                  core.Task
+			inputs, outputs, info []byte
+		)
+
+		if err := rows.Scan(&task.ID, &inputs, &outputs, &info); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+
+		if err := unmarshalNullable(inputs, &task.Inputs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inputs: %w", err)
+		}
+
+		if err := unmarshalNullable(outputs, &task.Outputs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outputs: %w", err)
+		}
+
+		if err := unmarshalNullable(info, &task.Info); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal info: %w", err)
+		}
+
+		result = append(result, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (db *DB) table() string {
+	return pgx.Identifier{db.Table}.Sanitize()
+}
+
+// query accumulates positional arguments while building sql
+type query struct {
+	args []any
+}
+
+func (q *query) arg(value any) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *query) jsonArg(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return q.arg(string(data)) + "::jsonb", nil
+}
+
+func (q *query) condition(propertiesToValues map[string][]any) (string, error) {
+	if len(propertiesToValues) == 0 {
+		return "true", nil
+	}
+
+	var conditions []string
+	for _, property := range sortedKeys(propertiesToValues) {
+		values := propertiesToValues[property]
+
+		if property == "id" {
+			conditions = append(conditions, fmt.Sprintf("id = any (%s)", q.arg(lo.Map(values, func(value any, _ int) string {
+				return fmt.Sprint(value)
+			}))))
+			continue
+		}
+
+		expression, err := q.path(property)
+		if err != nil {
+			return "", err
+		}
+
+		alternatives := []string{"false"}
+		for _, value := range values {
+			if value == nil {
+				alternatives = append(alternatives,
+					fmt.Sprintf("%s IS NULL", expression),
+					fmt.Sprintf("%s = 'null'::jsonb", expression),
+				)
+				continue
+			}
+
+			encoded, err := q.jsonArg(value)
+			if err != nil {
+				return "", err
+			}
+
+			// array values match if any of their elements matches (like in mongo)
+			alternatives = append(alternatives,
+				fmt.Sprintf("%s = %s", expression, encoded),
+				fmt.Sprintf("(jsonb_typeof(%s) = 'array' AND %s @> jsonb_build_array(%s))", expression, expression, encoded),
+			)
+		}
+
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	return strings.Join(conditions, " AND "), nil
+}
+
+func (q *query) assignments(properties map[string]any) (string, error) {
+	columns := map[string]string{}
+	for _, property := range sortedKeys(properties) {
+		column, path, err := splitProperty(property)
+		if err != nil {
+			return "", err
+		}
+
+		if column == "id" {
+			return "", errors.New("id can't be updated")
+		}
+
+		encoded, err := q.jsonArg(properties[property])
+		if err != nil {
+			return "", err
+		}
+
+		if len(path) == 0 {
+			columns[column] = encoded
+			continue
+		}
+
+		current, ok := columns[column]
+		if !ok {
+			current = column
+		}
+		columns[column] = fmt.Sprintf("kantoku_jsonb_set(%s, %s::text[], %s)", current, q.arg(path), encoded)
+	}
+
+	var assignments []string
+	for _, column := range sortedKeys(columns) {
+		assignments = append(assignments, fmt.Sprintf("%s = %s", column, columns[column]))
+	}
+
+	return strings.Join(assignments, ", "), nil
+}
+
+func (q *query) path(property string) (string, error) {
+	column, path, err := splitProperty(property)
+	if err != nil {
+		return "", err
+	}
+
+	if column == "id" {
+		return "to_jsonb(id)", nil
+	}
+
+	if len(path) == 0 {
+		return column, nil
+	}
+
+	return fmt.Sprintf("(%s #> %s::text[])", column, q.arg(path)), nil
+}
+
+func splitProperty(property string) (column string, path []string, err error) {
+	parts := strings.Split(property, ".")
+
+	switch parts[0] {
+	case "id", "inputs", "outputs", "info":
+		if parts[0] != "info" && len(parts) > 1 {
+			return "", nil, fmt.Errorf("unsupported property: %s", property)
+		}
+		return parts[0], parts[1:], nil
+	default:
+		return "", nil, fmt.Errorf("unsupported property: %s", property)
+	}
+}
+
+func unmarshalNullable(data []byte, to any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, to)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
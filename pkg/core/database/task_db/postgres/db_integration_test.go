@@ -0,0 +1,202 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/storage"
+	"github.com/ischenkx/kantoku/pkg/common/data/uid"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newTestDB runs the migrations in a fresh schema of the database at KANTOKU_TEST_POSTGRES_URI
+func newTestDB(t *testing.T) *DB {
+	ctx := context.Background()
+
+	uri := os.Getenv("KANTOKU_TEST_POSTGRES_URI")
+	if uri == "" {
+		t.Skip("KANTOKU_TEST_POSTGRES_URI is not set")
+	}
+
+	migration, err := os.ReadFile("migrations/init.sql")
+	if err != nil {
+		t.Fatalf("failed to read the migration: %s", err)
+	}
+
+	schema := "kantoku_test_" + strings.ReplaceAll(uid.Generate(), "-", "")
+
+	config, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		t.Fatalf("failed to parse the uri: %s", err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	client, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("failed to connect: %s", err)
+	}
+
+	if _, err := client.Exec(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+		t.Fatalf("failed to create a schema: %s", err)
+	}
+	if _, err := client.Exec(ctx, string(migration)); err != nil {
+		t.Fatalf("failed to migrate: %s", err)
+	}
+
+	t.Cleanup(func() {
+		client.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+		client.Close()
+	})
+
+	return &DB{Client: client, Table: "tasks"}
+}
+
+func TestProperties(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "1", Inputs: []string{"a"}, Info: map[string]any{"status": "ready", "meta": map[string]any{"attempt": 1}}},
+		{ID: "2", Inputs: []string{"b"}, Info: map[string]any{"status": "finished"}},
+		{ID: "3", Info: map[string]any{}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	cases := []struct {
+		name     string
+		query    map[string][]any
+		expected []string
+	}{
+		{"equality", map[string][]any{"info.status": {"ready"}}, []string{"1"}},
+		{"several values", map[string][]any{"info.status": {"ready", "finished"}}, []string{"1", "2"}},
+		{"missing", map[string][]any{"info.status": {nil}}, []string{"3"}},
+		{"nested path", map[string][]any{"info.meta.attempt": {int64(1)}}, []string{"1"}},
+		{"array element", map[string][]any{"inputs": {"b"}}, []string{"2"}},
+		{"no values", map[string][]any{"info.status": {}}, nil},
+	}
+
+	for _, c := range cases {
+		tasks, err := db.GetWithProperties(ctx, c.query)
+		if err != nil {
+			t.Fatalf("%s: failed to query: %s", c.name, err)
+		}
+
+		if len(tasks) != len(c.expected) {
+			t.Fatalf("%s: expected %d tasks, got %d", c.name, len(c.expected), len(tasks))
+		}
+
+		// rows are not ordered
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+		for index, task := range tasks {
+			if task.ID != c.expected[index] {
+				t.Fatalf("%s: expected task '%s', got '%s'", c.name, c.expected[index], task.ID)
+			}
+		}
+	}
+}
+
+func TestConditionalUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	if err := db.Insert(ctx, []core.Task{{ID: "1", Info: map[string]any{}}}); err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	// only the first update must succeed
+	for attempt, expected := range []int{1, 0} {
+		updated, err := db.UpdateWithProperties(ctx,
+			map[string][]any{"id": {"1"}, "info.status": {nil}},
+			map[string]any{"info.status": "initialized", "info.dependencies.group_id": "g"},
+		)
+		if err != nil {
+			t.Fatalf("failed to update: %s", err)
+		}
+
+		if updated != expected {
+			t.Fatalf("attempt %d: expected %d updated tasks, got %d", attempt, expected, updated)
+		}
+	}
+
+	tasks, err := db.ByIDs(ctx, []string{"1"})
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	dependencies, _ := tasks[0].Info["dependencies"].(map[string]any)
+	if tasks[0].Info["status"] != "initialized" || dependencies["group_id"] != "g" {
+		t.Fatalf("unexpected info: %v", tasks[0].Info)
+	}
+}
+
+func TestExec(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "1", Info: map[string]any{"updated_at": 3}},
+		{ID: "2", Info: map[string]any{"updated_at": 1}},
+		{ID: "3", Info: map[string]any{"updated_at": 2}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	docs, err := db.Exec(ctx, storage.Command{
+		Operation: "query",
+		Params: []storage.Param{
+			{Name: "sql", Value: "SELECT id FROM tasks WHERE (info->>'updated_at')::int >= $1 ORDER BY info->'updated_at' DESC LIMIT 1"},
+			{Name: "args", Value: []any{2}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to exec: %s", err)
+	}
+
+	if len(docs) != 1 || docs[0]["id"] != "1" {
+		t.Fatalf("unexpected documents: %v", docs)
+	}
+
+	_, err = db.Exec(ctx, storage.Command{
+		Operation: "query",
+		Params:    []storage.Param{{Name: "sql", Value: "DELETE FROM tasks"}},
+		Meta:      map[string]any{"readonly": true},
+	})
+	if err == nil {
+		t.Fatalf("expected the read-only constraint to be violated")
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	err := db.Insert(ctx, []core.Task{
+		{ID: "b", Info: map[string]any{"status": "finished", "finished_at": 2}},
+		{ID: "a", Info: map[string]any{"status": "finished", "finished_at": 2}},
+		{ID: "c", Info: map[string]any{"status": "finished", "finished_at": 1}},
+		{ID: "d", Info: map[string]any{"status": "finished"}},
+		{ID: "e", Info: map[string]any{"status": "ready", "finished_at": 3}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	first, err := db.GetPage(ctx, map[string][]any{"info.status": {"finished"}}, "info.finished_at", core.PageCursor{}, 2)
+	if err != nil || len(first) != 2 || first[0].ID != "c" || first[1].ID != "a" {
+		t.Fatalf("unexpected first page: %v (error: %v)", first, err)
+	}
+
+	second, err := db.GetPage(ctx, map[string][]any{"info.status": {"finished"}}, "info.finished_at", core.PageCursor{Value: 2, ID: "a"}, 2)
+	if err != nil || len(second) != 1 || second[0].ID != "b" {
+		t.Fatalf("unexpected second page: %v (error: %v)", second, err)
+	}
+}
@@ -0,0 +1,2 @@
+DROP FUNCTION kantoku_jsonb_set;
+DROP TABLE tasks;
\ No newline at end of file
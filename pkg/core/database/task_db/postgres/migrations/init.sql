@@ -0,0 +1,31 @@
+CREATE TABLE tasks
+(
+    id         varchar(255),
+    inputs     jsonb,
+    outputs    jsonb,
+    info       jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp     DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+-- kantoku_jsonb_set works like jsonb_set but also creates missing intermediate objects
+-- (e.g. setting info.dependencies.group_id when there is no info.dependencies yet)
+CREATE OR REPLACE FUNCTION kantoku_jsonb_set(target jsonb, path text[], val jsonb) RETURNS jsonb AS
+$$
+DECLARE
+    i int;
+BEGIN
+    IF target IS NULL OR jsonb_typeof(target) <> 'object' THEN
+        target := '{}'::jsonb;
+    END IF;
+
+    FOR i IN 1..coalesce(array_length(path, 1), 0) - 1
+        LOOP
+            IF target #> path[1:i] IS NULL OR jsonb_typeof(target #> path[1:i]) <> 'object' THEN
+                target := jsonb_set(target, path[1:i], '{}'::jsonb, true);
+            END IF;
+        END LOOP;
+
+    RETURN jsonb_set(target, path, val, true);
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
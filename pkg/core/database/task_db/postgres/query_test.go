@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition(t *testing.T) {
+	cases := []struct {
+		name       string
+		properties map[string][]any
+		sql        string
+		args       []any
+	}{
+		{
+			name:       "dotted path",
+			properties: map[string][]any{"info.dependencies.group_id": {"g"}},
+			sql:        "(false OR (info #> $1::text[]) = $2::jsonb OR (jsonb_typeof((info #> $1::text[])) = 'array' AND (info #> $1::text[]) @> jsonb_build_array($2::jsonb)))",
+			args:       []any{[]string{"dependencies", "group_id"}, `"g"`},
+		},
+		{
+			name:       "nil as missing",
+			properties: map[string][]any{"info.status": {nil}},
+			sql:        "(false OR (info #> $1::text[]) IS NULL OR (info #> $1::text[]) = 'null'::jsonb)",
+			args:       []any{[]string{"status"}},
+		},
+		{
+			name:       "several values",
+			properties: map[string][]any{"id": {"1", "2"}, "info.status": {"ready", "finished"}},
+			sql: "id = any ($1) AND (false OR (info #> $2::text[]) = $3::jsonb OR (jsonb_typeof((info #> $2::text[])) = 'array' AND (info #> $2::text[]) @> jsonb_build_array($3::jsonb)) " +
+				"OR (info #> $2::text[]) = $4::jsonb OR (jsonb_typeof((info #> $2::text[])) = 'array' AND (info #> $2::text[]) @> jsonb_build_array($4::jsonb)))",
+			args: []any{[]string{"1", "2"}, []string{"status"}, `"ready"`, `"finished"`},
+		},
+		{
+			name:       "no values",
+			properties: map[string][]any{"info.status": {}},
+			sql:        "(false)",
+			args:       []any{[]string{"status"}},
+		},
+		{
+			name:       "no properties",
+			properties: map[string][]any{},
+			sql:        "true",
+		},
+	}
+
+	for _, c := range cases {
+		var q query
+
+		sql, err := q.condition(c.properties)
+		if err != nil {
+			t.Fatalf("%s: failed to build the condition: %s", c.name, err)
+		}
+
+		if sql != c.sql {
+			t.Errorf("%s: unexpected sql:\n%s", c.name, sql)
+		}
+
+		if !reflect.DeepEqual(q.args, c.args) {
+			t.Errorf("%s: unexpected args: %#v", c.name, q.args)
+		}
+	}
+}
+
+func TestAssignments(t *testing.T) {
+	cases := []struct {
+		name       string
+		properties map[string]any
+		sql        string
+		args       []any
+	}{
+		{
+			name:       "dotted paths",
+			properties: map[string]any{"info.status": "ok", "info.dependencies.group_id": "g"},
+			sql:        "info = kantoku_jsonb_set(kantoku_jsonb_set(info, $2::text[], $1::jsonb), $4::text[], $3::jsonb)",
+			args:       []any{`"g"`, []string{"dependencies", "group_id"}, `"ok"`, []string{"status"}},
+		},
+		{
+			name:       "whole column",
+			properties: map[string]any{"outputs": []string{"a"}},
+			sql:        "outputs = $1::jsonb",
+			args:       []any{`["a"]`},
+		},
+	}
+
+	for _, c := range cases {
+		var q query
+
+		sql, err := q.assignments(c.properties)
+		if err != nil {
+			t.Fatalf("%s: failed to build assignments: %s", c.name, err)
+		}
+
+		if sql != c.sql {
+			t.Errorf("%s: unexpected sql:\n%s", c.name, sql)
+		}
+
+		if !reflect.DeepEqual(q.args, c.args) {
+			t.Errorf("%s: unexpected args: %#v", c.name, q.args)
+		}
+	}
+
+	for _, property := range []string{"id", "inputs.x", "status"} {
+		var q query
+		if _, err := q.assignments(map[string]any{property: 1}); err == nil {
+			t.Errorf("expected '%s' to be rejected", property)
+		}
+	}
+}
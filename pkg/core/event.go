@@ -29,3 +29,23 @@ type Broker interface {
 	Send(ctx context.Context, event Event) error
 	Consume(ctx context.Context, events []string, consumerSettings broker.ConsumerSettings) (<-chan BrokerEvent, error)
 }
+
+// BatchBroker sends several events at once (e.g. the events of tasks spawned with SpawnBatch)
+type BatchBroker interface {
+	SendBatch(ctx context.Context, events []Event) error
+}
+
+// SendBatch sends the events with a single call if the broker is a BatchBroker, otherwise they are sent one by one
+func SendBatch(ctx context.Context, broker Broker, events []Event) error {
+	if batchBroker, ok := broker.(BatchBroker); ok {
+		return batchBroker.SendBatch(ctx, events)
+	}
+
+	for _, event := range events {
+		if err := broker.Send(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
+	"sort"
+	"time"
+)
+
+// The outbox is stored inside the task record, so a task and its pending events
+// are written with a single storage operation.
+//
+// Layout (info.outbox):
+//
+//	status: pending | sent
+//	last_event_id: id of the newest event
+//	updated_at: timestamp of the newest event (unix milliseconds)
+//	events: {<event id>: {topic, data, timestamp}}
+//
+// Events are keyed by their ids, so adding an event (see OutboxProperties) never overwrites the ones that
+// may still be pending. An outbox is marked as sent only by the sender of its newest event (see MarkOutboxSent).
+const OutboxInfoKey = "outbox"
+
+var OutboxStatuses struct {
+	Pending string
+	Sent    string
+}
+
+func init() {
+	OutboxStatuses.Pending = "pending"
+	OutboxStatuses.Sent = "sent"
+}
+
+type outboxRecord struct {
+	Topic     string `mapstructure:"topic"`
+	Data      string `mapstructure:"data"`
+	Timestamp int64  `mapstructure:"timestamp"`
+}
+
+// NewOutbox makes a pending outbox of the events (stored as info.outbox of a new task)
+func NewOutbox(events ...Event) map[string]any {
+	records := make(map[string]any, len(events))
+	for _, event := range events {
+		records[event.ID] = newOutboxRecord(event)
+	}
+
+	outbox := map[string]any{
+		"status": OutboxStatuses.Pending,
+		"events": records,
+	}
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		outbox["last_event_id"] = last.ID
+		outbox["updated_at"] = time.Unix(0, last.Timestamp).UnixMilli()
+	}
+
+	return outbox
+}
+
+// OutboxProperties returns properties adding the events to the outbox of an existing task
+// (they are meant to be stored in the same update that produced the events)
+func OutboxProperties(events ...Event) map[string]any {
+	prefix := "info." + OutboxInfoKey + "."
+
+	properties := map[string]any{}
+	for _, event := range events {
+		properties[prefix+"events."+event.ID] = newOutboxRecord(event)
+	}
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		properties[prefix+"status"] = OutboxStatuses.Pending
+		properties[prefix+"last_event_id"] = last.ID
+		properties[prefix+"updated_at"] = time.Unix(0, last.Timestamp).UnixMilli()
+	}
+
+	return properties
+}
+
+func newOutboxRecord(event Event) map[string]any {
+	return map[string]any{
+		"topic":     event.Topic,
+		"data":      string(event.Data),
+		"timestamp": event.Timestamp,
+	}
+}
+
+func (task Task) OutboxStatus() string {
+	outbox, ok := task.Info[OutboxInfoKey].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	status, _ := outbox["status"].(string)
+
+	return status
+}
+
+// OutboxLastEventID returns the id of the newest event of the outbox
+func (task Task) OutboxLastEventID() string {
+	outbox, ok := task.Info[OutboxInfoKey].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	id, _ := outbox["last_event_id"].(string)
+
+	return id
+}
+
+// OutboxEvents returns events of the outbox ordered by their timestamps
+func (task Task) OutboxEvents() ([]Event, error) {
+	rawOutbox, ok := task.Info[OutboxInfoKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var outbox struct {
+		Events map[string]outboxRecord `mapstructure:"events"`
+	}
+	if err := mapstructure.Decode(rawOutbox, &outbox); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox: %w", err)
+	}
+
+	events := make([]Event, 0, len(outbox.Events))
+	for id, record := range outbox.Events {
+		events = append(events, Event{
+			ID:        id,
+			Data:      []byte(record.Data),
+			Topic:     record.Topic,
+			Timestamp: record.Timestamp,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Timestamp != events[j].Timestamp {
+			return events[i].Timestamp < events[j].Timestamp
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	return events, nil
+}
+
+// MarkOutboxSent marks outboxes whose newest events are among the events as sent.
+// An outbox that has got a newer event in the meantime stays pending, so the new event is not lost
+// (the events that have already been published are published again by the relay and deduplicated by consumers).
+func MarkOutboxSent(ctx context.Context, tasks TaskDB, eventIDs ...string) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	_, err := tasks.UpdateWithProperties(
+		ctx,
+		map[string][]any{
+			"info." + OutboxInfoKey + ".last_event_id": lo.ToAnySlice(eventIDs),
+			"info." + OutboxInfoKey + ".status":        {OutboxStatuses.Pending},
+		},
+		map[string]any{
+			"info." + OutboxInfoKey + ".status":  OutboxStatuses.Sent,
+			"info." + OutboxInfoKey + ".sent_at": time.Now().Unix(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update the outbox: %w", err)
+	}
+
+	return nil
+}
@@ -1,11 +1,23 @@
 package core
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrResourceExpired = errors.New("resource has expired")
+
+var ErrTTLNotSupported = errors.New("allocation ttls are not supported")
 
 type Resource struct {
 	Data   []byte
 	ID     string
 	Status string
+	// TTL is the lifetime of the resource after initialization.
+	// If it's zero, the TTL set at allocation (if any) is kept.
+	TTL time.Duration
 }
 
 type ResourceDB interface {
@@ -14,3 +26,50 @@ type ResourceDB interface {
 	Init(ctx context.Context, resources []Resource) error
 	Dealloc(ctx context.Context, ids []string) error
 }
+
+// ExpiringResourceDB is an optional extension of ResourceDB for allocations that expire
+// if they are not initialized in time (the lifetime of initialized resources is set by Resource.TTL)
+type ExpiringResourceDB interface {
+	// AllocWithTTL allocates resources that expire after the ttl (they never expire if it's zero)
+	AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) (ids []string, err error)
+}
+
+// AllocWithTTL allocates expiring resources if the ttl is positive, ErrTTLNotSupported is returned
+// if the database can't do that
+func AllocWithTTL(ctx context.Context, db ResourceDB, amount int, ttl time.Duration) ([]string, error) {
+	if ttl <= 0 {
+		return db.Alloc(ctx, amount)
+	}
+
+	expiring, ok := db.(ExpiringResourceDB)
+	if !ok {
+		return nil, ErrTTLNotSupported
+	}
+
+	return expiring.AllocWithTTL(ctx, amount, ttl)
+}
+
+// StatusResourceDB is an optional extension of ResourceDB for databases that can check statuses of resources
+// without loading their payloads
+type StatusResourceDB interface {
+	// LoadStatuses loads resources without their data
+	LoadStatuses(ctx context.Context, ids ...string) ([]Resource, error)
+}
+
+// LoadStatuses loads resources without data if the database supports that, otherwise the data is loaded too
+func LoadStatuses(ctx context.Context, db ResourceDB, ids ...string) ([]Resource, error) {
+	if statuses, ok := db.(StatusResourceDB); ok {
+		return statuses.LoadStatuses(ctx, ids...)
+	}
+
+	return db.Load(ctx, ids...)
+}
+
+// StreamingResourceDB is an optional extension of ResourceDB for payloads that should not be kept in memory
+// (resources.Streaming provides a fallback over the byte-slice API)
+type StreamingResourceDB interface {
+	// OpenReader streams the data of a ready resource
+	OpenReader(ctx context.Context, id string) (io.ReadCloser, error)
+	// OpenWriter streams the data of an allocated resource, which is initialized on Close
+	OpenWriter(ctx context.Context, id string) (io.WriteCloser, error)
+}
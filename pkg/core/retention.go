@@ -0,0 +1,40 @@
+package core
+
+import "time"
+
+// Retention returns how long the task is kept after it's finished (info.retention).
+//
+// The retention is either a duration string ("72h") or a number of seconds.
+func (task Task) Retention() (time.Duration, bool, error) {
+	raw, ok := task.Info["retention"]
+	if !ok || raw == nil {
+		return 0, false, nil
+	}
+
+	retention, err := ParseTimeout(raw)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return retention, true, nil
+}
+
+// FinishedAt returns the time the task was finished or cancelled at (info.finished_at, unix seconds)
+func (task Task) FinishedAt() (time.Time, bool) {
+	seconds, ok := number(task.Info["finished_at"])
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0), true
+}
+
+// UpdatedAt returns the time of the task's last status transition (info.updated_at, unix seconds)
+func (task Task) UpdatedAt() (time.Time, bool) {
+	seconds, ok := number(task.Info["updated_at"])
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0), true
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy describes how a failed task is retried (info.retry)
+type RetryPolicy struct {
+	// MaxAttempts is the total number of executions (including the first one)
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff limits the delay (unlimited if not set)
+	MaxBackoff time.Duration
+	// Multiplier is applied to the delay after every attempt (2 if not set)
+	Multiplier float64
+	// Jitter randomizes the delay by ±Jitter*delay (0 <= Jitter <= 1)
+	Jitter float64
+	// RetryOn lists retryable error codes reported by executors (see executor.WithCode),
+	// every failed or timed out execution is retried if it's not set
+	RetryOn []string
+}
+
+// Retryable reports whether the task finished with the sub status and the error code should be retried
+func (policy RetryPolicy) Retryable(subStatus, errorCode string, attempt int) bool {
+	if attempt >= policy.MaxAttempts {
+		return false
+	}
+
+	if subStatus != TaskSubStatuses.Failed && subStatus != TaskSubStatuses.TimedOut {
+		return false
+	}
+
+	return len(policy.RetryOn) == 0 || lo.Contains(policy.RetryOn, errorCode)
+}
+
+// Backoff returns the delay before the retry of the attempt (starting from 1)
+func (policy RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	backoff := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 {
+		backoff = math.Min(backoff, float64(policy.MaxBackoff))
+	}
+
+	if policy.Jitter > 0 {
+		backoff += backoff * policy.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(backoff)
+}
+
+func (policy RetryPolicy) AsInfo() map[string]any {
+	return map[string]any{
+		"max_attempts":    policy.MaxAttempts,
+		"initial_backoff": policy.InitialBackoff.String(),
+		"max_backoff":     policy.MaxBackoff.String(),
+		"multiplier":      policy.Multiplier,
+		"jitter":          policy.Jitter,
+		"retry_on":        policy.RetryOn,
+	}
+}
+
+// ParseRetryPolicy parses a policy stored in task info (or specification meta).
+// Durations are either duration strings ("1m30s") or numbers of seconds.
+func ParseRetryPolicy(raw any) (RetryPolicy, error) {
+	info, ok := raw.(map[string]any)
+	if !ok {
+		return RetryPolicy{}, fmt.Errorf("unexpected retry policy type: %T", raw)
+	}
+
+	var (
+		policy RetryPolicy
+		err    error
+	)
+
+	if value, ok := info["max_attempts"]; ok {
+		attempts, ok := number(value)
+		if !ok {
+			return RetryPolicy{}, fmt.Errorf("unexpected max_attempts type: %T", value)
+		}
+		policy.MaxAttempts = int(attempts)
+	}
+
+	if value, ok := info["initial_backoff"]; ok {
+		if policy.InitialBackoff, err = ParseTimeout(value); err != nil {
+			return RetryPolicy{}, fmt.Errorf("failed to parse initial_backoff: %w", err)
+		}
+	}
+
+	if value, ok := info["max_backoff"]; ok {
+		if policy.MaxBackoff, err = ParseTimeout(value); err != nil {
+			return RetryPolicy{}, fmt.Errorf("failed to parse max_backoff: %w", err)
+		}
+	}
+
+	if value, ok := info["multiplier"]; ok {
+		if policy.Multiplier, ok = number(value); !ok {
+			return RetryPolicy{}, fmt.Errorf("unexpected multiplier type: %T", value)
+		}
+	}
+
+	if value, ok := info["jitter"]; ok {
+		if policy.Jitter, ok = number(value); !ok {
+			return RetryPolicy{}, fmt.Errorf("unexpected jitter type: %T", value)
+		}
+	}
+
+	switch retryOn := info["retry_on"].(type) {
+	case nil:
+	case []string:
+		policy.RetryOn = retryOn
+	case []any:
+		for _, subStatus := range retryOn {
+			str, ok := subStatus.(string)
+			if !ok {
+				return RetryPolicy{}, fmt.Errorf("unexpected retry_on item type: %T", subStatus)
+			}
+			policy.RetryOn = append(policy.RetryOn, str)
+		}
+	default:
+		return RetryPolicy{}, fmt.Errorf("unexpected retry_on type: %T", retryOn)
+	}
+
+	return policy, nil
+}
+
+// RetryPolicy returns the retry policy of the task (info.retry)
+func (task Task) RetryPolicy() (RetryPolicy, bool, error) {
+	raw, ok := task.Info["retry"]
+	if !ok || raw == nil {
+		return RetryPolicy{}, false, nil
+	}
+
+	policy, err := ParseRetryPolicy(raw)
+	if err != nil {
+		return RetryPolicy{}, false, err
+	}
+
+	return policy, true, nil
+}
+
+// ErrorCode returns the code of the task's failure reported by its executor (info.error_code)
+func (task Task) ErrorCode() string {
+	code, _ := task.Info["error_code"].(string)
+	return code
+}
+
+// RetryAttempt returns the number of the task's execution attempt (info.retry_attempt, 1 if not set)
+func (task Task) RetryAttempt() int {
+	attempt, ok := number(task.Info["retry_attempt"])
+	if !ok || attempt < 1 {
+		return 1
+	}
+
+	return int(attempt)
+}
+
+// RetryAt returns the time of the scheduled retry (info.retry_at, unix milliseconds)
+func (task Task) RetryAt() (time.Time, bool) {
+	millis, ok := number(task.Info["retry_at"])
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.UnixMilli(int64(millis)), true
+}
@@ -0,0 +1,34 @@
+package core_test
+
+import (
+	"github.com/ischenkx/kantoku/pkg/core"
+	"testing"
+)
+
+func TestRetryable(t *testing.T) {
+	any := core.RetryPolicy{MaxAttempts: 3}
+	coded := core.RetryPolicy{MaxAttempts: 3, RetryOn: []string{"rate_limited"}}
+
+	cases := []struct {
+		name      string
+		policy    core.RetryPolicy
+		subStatus string
+		code      string
+		attempt   int
+		retryable bool
+	}{
+		{"any failure", any, core.TaskSubStatuses.Failed, "", 1, true},
+		{"timeout", any, core.TaskSubStatuses.TimedOut, "", 2, true},
+		{"cancelled", any, core.TaskSubStatuses.Cancelled, "", 1, false},
+		{"attempts exhausted", any, core.TaskSubStatuses.Failed, "", 3, false},
+		{"matching code", coded, core.TaskSubStatuses.Failed, "rate_limited", 1, true},
+		{"other code", coded, core.TaskSubStatuses.Failed, "invalid_input", 1, false},
+		{"no code", coded, core.TaskSubStatuses.Failed, "", 1, false},
+	}
+
+	for _, c := range cases {
+		if retryable := c.policy.Retryable(c.subStatus, c.code, c.attempt); retryable != c.retryable {
+			t.Errorf("%s: expected %t, got %t", c.name, c.retryable, retryable)
+		}
+	}
+}
@@ -2,23 +2,35 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/common/data/codec"
 	"github.com/ischenkx/kantoku/pkg/common/service"
 	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"log/slog"
 	"sync"
+	"time"
+)
+
+var (
+	errCancelled = errors.New("task cancelled")
+	errTimedOut  = errors.New("task timed out")
 )
 
 type process struct {
 	cancel context.CancelFunc
 }
 
+// DefaultGracePeriod is the time an interrupted executor is given to return before the task is reported
+const DefaultGracePeriod = 10 * time.Second
+
 type executionController struct {
 	System      core.AbstractSystem
 	Executor    Executor
 	ResultCodec codec.Codec[Result, []byte]
 	Service     service.Core
+	GracePeriod time.Duration
 
 	runningProcesses map[string]process
 	mu               sync.Mutex
@@ -56,11 +68,24 @@ func (controller *executionController) processReadyTask(ctx context.Context, id
 	}
 
 	result := Result{TaskID: id, Status: OK}
-	if err := controller.execute(ctx, id); err != nil {
+	running, err := controller.execute(ctx, id)
+	// the worker is not released until the executor returns
+	if running != nil {
+		defer controller.waitFor(id, running)
+	}
+	if err != nil {
 		result.Data = []byte(err.Error())
 		result.Status = Failed
+		result.Code = ErrorCode(err)
+		switch {
+		case errors.Is(err, errCancelled):
+			result.Status = Cancelled
+		case errors.Is(err, errTimedOut):
+			result.Status = TimedOut
+		}
 		// TODO: may be remove
-		controller.Service.Logger().Warn("execution error:", err)
+		controller.Service.Logger().Warn("execution error",
+			slog.String("error", err.Error()))
 	}
 
 	encodedResult, err := controller.ResultCodec.Encode(result)
@@ -76,32 +101,125 @@ func (controller *executionController) processReadyTask(ctx context.Context, id
 	return nil
 }
 
-func (controller *executionController) execute(ctx context.Context, id string) error {
+// execute runs the task.
+//
+// If the executor doesn't return within the grace period after the task is interrupted,
+// the interruption is reported right away and the returned channel is closed when the executor returns.
+func (controller *executionController) execute(ctx context.Context, id string) (running <-chan error, err error) {
 	localContext, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	controller.createProcess(id, cancel)
 	defer controller.deleteProcess(id)
 
 	t, err := controller.System.Task(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to load task: %w", err)
+		return nil, fmt.Errorf("failed to load task: %w", err)
 	}
 
 	if err := controller.validateReadyTask(localContext, t); err != nil {
-		return fmt.Errorf("failed to validate a task: %w", err)
+		var transitionErr *core.TransitionError
+		if errors.As(err, &transitionErr) && transitionErr.From == core.TaskStatuses.Cancelled {
+			return nil, fmt.Errorf("%w: %s", errCancelled, err)
+		}
+		return nil, fmt.Errorf("failed to validate a task: %w", err)
 	}
 
-	err = controller.Executor.Execute(localContext, controller.System, t)
+	deadline, hasDeadline, err := t.ExecutionDeadline(time.Now())
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to get the execution deadline: %w", err)
+	}
+	if hasDeadline {
+		var cancelByDeadline context.CancelFunc
+		localContext, cancelByDeadline = context.WithDeadline(localContext, deadline)
+		defer cancelByDeadline()
 	}
 
-	return nil
+	if localContext.Err() != nil {
+		return nil, interruption(ctx, localContext, localContext.Err())
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		done <- controller.Executor.Execute(localContext, controller.System, t)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			if localContext.Err() != nil {
+				return nil, interruption(ctx, localContext, err)
+			}
+			return nil, err
+		}
+	case <-localContext.Done():
+		interrupted := interruption(ctx, localContext, localContext.Err())
+
+		grace := controller.gracePeriod()
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+
+		select {
+		case <-done:
+			return nil, interrupted
+		case <-timer.C:
+			controller.Service.Logger().Warn("the executor has not returned within the grace period",
+				slog.String("task_id", id),
+				slog.Duration("grace_period", grace))
+			return done, interrupted
+		}
+	}
+
+	return nil, nil
+}
+
+// waitFor blocks until an interrupted executor returns
+func (controller *executionController) waitFor(id string, running <-chan error) {
+	start := time.Now()
+	<-running
+
+	controller.Service.Logger().Info("the interrupted executor has returned",
+		slog.String("task_id", id),
+		slog.Duration("overdue", time.Since(start)))
+}
+
+func (controller *executionController) gracePeriod() time.Duration {
+	if controller.GracePeriod <= 0 {
+		return DefaultGracePeriod
+	}
+
+	return controller.GracePeriod
+}
+
+// interruption explains why the local context has been cancelled
+func interruption(ctx, localContext context.Context, err error) error {
+	switch {
+	// the service itself is stopping
+	case ctx.Err() != nil:
+		return err
+	case errors.Is(localContext.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", errTimedOut, err)
+	// the local context is cancelled by a "cancelled" event
+	default:
+		return fmt.Errorf("%w: %s", errCancelled, err)
+	}
 }
 
 func (controller *executionController) validateReadyTask(ctx context.Context, t core.Task) error {
-	if rawStatus, ok := t.Info["status"]; ok {
-		if value, ok := rawStatus.(string); ok && value == core.TaskStatuses.Cancelled {
-			return fmt.Errorf("task canceled")
+	status := t.Status()
+
+	// the status service might be behind ("") or might have already processed the "received" event
+	if status == "" || status == core.TaskStatuses.Received {
+		return nil
+	}
+
+	if !core.TaskStates.CanTransition(status, t.SubStatus(), core.TaskStatuses.Received, "") {
+		return &core.TransitionError{
+			TaskID:        t.ID,
+			From:          status,
+			FromSubStatus: t.SubStatus(),
+			To:            core.TaskStatuses.Received,
 		}
 	}
 
@@ -0,0 +1,54 @@
+package executor
+
+import (
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+)
+
+// DefaultConcurrency is the number of workers of the default pool
+const DefaultConcurrency = 100
+
+type pool struct {
+	name     string
+	size     int
+	messages chan broker.Message[core.Event]
+}
+
+// newPool creates a pool of the size, its backlog is limited by the size if it's not positive
+func newPool(name string, size, backlog int) *pool {
+	if backlog <= 0 {
+		backlog = size
+	}
+
+	return &pool{
+		name:     name,
+		size:     size,
+		messages: make(chan broker.Message[core.Event], backlog),
+	}
+}
+
+// push adds the message to the backlog of the pool.
+//
+// A message that doesn't fit into a full backlog is returned to the broker (false is returned),
+// so a busy pool never blocks the dispatcher and other pools.
+func (p *pool) push(message broker.Message[core.Event]) bool {
+	select {
+	case p.messages <- message:
+		return true
+	default:
+		message.Nack()
+		return false
+	}
+}
+
+// drain returns the messages that have not been processed (must be called after the workers are stopped)
+func (p *pool) drain() {
+	for {
+		select {
+		case message := <-p.messages:
+			message.Nack()
+		default:
+			return
+		}
+	}
+}
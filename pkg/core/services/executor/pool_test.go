@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"github.com/ischenkx/kantoku/pkg/core"
+	"sync/atomic"
+	"testing"
+)
+
+type testMessage struct {
+	nacked *atomic.Int32
+}
+
+func (message testMessage) Item() core.Event { return core.Event{} }
+func (message testMessage) Ack()             {}
+func (message testMessage) Nack()            { message.nacked.Add(1) }
+
+func TestPoolBacklogIsBounded(t *testing.T) {
+	p := newPool("test", 1, 2)
+	nacked := &atomic.Int32{}
+
+	// nobody reads the messages, so the backlog is filled up
+	for i := 0; i < 2; i++ {
+		if !p.push(testMessage{nacked: nacked}) {
+			t.Fatal("a message has not been accepted by a free backlog")
+		}
+	}
+
+	// a full backlog returns messages instead of blocking
+	if p.push(testMessage{nacked: nacked}) {
+		t.Fatal("a message has been accepted by a full backlog")
+	}
+	if nacked.Load() != 1 {
+		t.Fatalf("expected the rejected message to be returned, nacked: %d", nacked.Load())
+	}
+
+	// a worker frees a place in the backlog
+	<-p.messages
+
+	if !p.push(testMessage{nacked: nacked}) {
+		t.Fatal("a message has not been accepted after the backlog has been freed")
+	}
+
+	p.drain()
+
+	if nacked.Load() != 3 {
+		t.Fatalf("expected the backlog to be returned, nacked: %d", nacked.Load())
+	}
+}
@@ -1,14 +1,49 @@
 package executor
 
+import "errors"
+
 type Status string
 
 const (
-	OK     Status = "ok"
-	Failed        = "failed"
+	OK        Status = "ok"
+	Failed           = "failed"
+	Cancelled        = "cancelled"
+	TimedOut         = "timed_out"
 )
 
 type Result struct {
 	TaskID string
 	Status Status
-	Data   []byte
+	// Code classifies the failure (see WithCode), retry policies are matched against it
+	Code string
+	Data []byte
+}
+
+// Error is an executor error with a code (e.g. "rate_limited") classifying the failure
+type Error struct {
+	Code string
+	Err  error
+}
+
+func (err *Error) Error() string {
+	return err.Err.Error()
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// WithCode makes the task fail with the code (see core.RetryPolicy.RetryOn)
+func WithCode(code string, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+// ErrorCode returns the code of the error ("" if it has none)
+func ErrorCode(err error) string {
+	var codedErr *Error
+	if errors.As(err, &codedErr) {
+		return codedErr.Code
+	}
+
+	return ""
 }
@@ -7,8 +7,10 @@ import (
 	"github.com/ischenkx/kantoku/pkg/common/service"
 	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
+	"time"
 )
 
 const QueueName = "executor"
@@ -17,10 +19,34 @@ type Service struct {
 	System      core.AbstractSystem
 	ResultCodec codec.Codec[Result, []byte]
 	Executor    Executor
+	// Concurrency is the number of workers executing tasks without a dedicated pool (DefaultConcurrency if not set)
+	Concurrency int
+	// Pools maps task types to the number of workers dedicated to them,
+	// so slow tasks of one type can't starve tasks of other types
+	Pools map[string]int
+	// Backlog is the number of received tasks waiting for a worker in each pool (the size of the pool if not set).
+	// Tasks that don't fit into a full backlog are returned to the broker.
+	Backlog int
+	// GracePeriod is the time an interrupted (cancelled or timed out) executor is given to return
+	// before the task is reported, its worker stays busy until the executor returns (DefaultGracePeriod if not set)
+	GracePeriod time.Duration
 
 	service.Core
 }
 
+// Info reports the capacity of the service
+func (srvc *Service) Info() map[string]any {
+	pools := map[string]any{}
+	for typ, size := range srvc.Pools {
+		pools[typ] = size
+	}
+
+	return map[string]any{
+		"concurrency": srvc.concurrency(),
+		"pools":       pools,
+	}
+}
+
 func (srvc *Service) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -29,6 +55,7 @@ func (srvc *Service) Run(ctx context.Context) error {
 		Executor:    srvc.Executor,
 		ResultCodec: srvc.ResultCodec,
 		Service:     srvc.Core,
+		GracePeriod: srvc.GracePeriod,
 	}
 
 	readyTaskEvents, err := srvc.System.Events().Consume(ctx,
@@ -39,37 +66,36 @@ func (srvc *Service) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to read events: %w", err)
 	}
 
-	for i := 0; i < 100; i++ {
-		i := i
-		g.Go(func() error {
-			srvc.Logger().Info("starting a processor",
-				"worker", i+1)
-			broker.Processor[core.Event]{
-				Handler: func(ctx context.Context, ev core.Event) error {
-					taskId := string(ev.Data)
-
-					srvc.Logger().Info("received a task", "task_id", taskId)
-
-					if err := executionService.processReadyTask(ctx, taskId); err != nil {
-						return err
-					}
-
-					return nil
-				},
-				ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
-					taskId := string(ev.Data)
-
-					srvc.Logger().
-						Error("failed to process a ready task",
-							slog.String("id", taskId),
-							slog.String("error", err.Error()))
-				},
-			}.Process(ctx, readyTaskEvents)
+	defaultPool := newPool("default", srvc.concurrency(), srvc.Backlog)
+	typ2pool := map[string]*pool{}
+	for typ, size := range srvc.Pools {
+		if size <= 0 {
+			return fmt.Errorf("pool size must be positive (type='%s' size=%d)", typ, size)
+		}
+		typ2pool[typ] = newPool(typ, size, srvc.Backlog)
+	}
 
-			return nil
-		})
+	pools := append([]*pool{defaultPool}, lo.Values(typ2pool)...)
+	for _, p := range pools {
+		p := p
+		for i := 0; i < p.size; i++ {
+			i := i
+			g.Go(func() error {
+				srvc.Logger().Info("starting a processor",
+					slog.String("pool", p.name),
+					slog.Int("worker", i+1))
+				srvc.processor(executionService).Process(ctx, p.messages)
+
+				return nil
+			})
+		}
 	}
 
+	g.Go(func() error {
+		srvc.dispatch(ctx, readyTaskEvents, defaultPool, typ2pool)
+		return nil
+	})
+
 	g.Go(func() error {
 		if err := executionService.start(ctx); err != nil {
 			return fmt.Errorf("failed to start the controller: %w", err)
@@ -77,9 +103,90 @@ func (srvc *Service) Run(ctx context.Context) error {
 		return nil
 	})
 
-	if err := g.Wait(); err != nil {
+	err = g.Wait()
+
+	for _, p := range pools {
+		p.drain()
+	}
+
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// dispatch sends ready tasks to the pools of their types
+func (srvc *Service) dispatch(ctx context.Context, messages <-chan broker.Message[core.Event], defaultPool *pool, typ2pool map[string]*pool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			p := defaultPool
+			// without dedicated pools there is no need to load the task
+			if len(typ2pool) > 0 {
+				if typed, ok := typ2pool[srvc.taskType(ctx, string(message.Item().Data))]; ok {
+					p = typed
+				}
+			}
+
+			if !p.push(message) {
+				srvc.Logger().Debug("the backlog of the pool is full, the task is returned",
+					slog.String("pool", p.name),
+					slog.String("id", string(message.Item().Data)))
+			}
+		}
+	}
+}
+
+func (srvc *Service) taskType(ctx context.Context, id string) string {
+	task, err := srvc.System.Task(ctx, id)
+	if err != nil {
+		// the task will fail in the default pool with a proper error
+		srvc.Logger().Error("failed to load a task to find its pool",
+			slog.String("id", id),
+			slog.String("error", err.Error()))
+		return ""
+	}
+
+	typ, _ := task.Info["type"].(string)
+
+	return typ
+}
+
+func (srvc *Service) processor(executionService *executionController) broker.Processor[core.Event] {
+	return broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			taskId := string(ev.Data)
+
+			srvc.Logger().Info("received a task", "task_id", taskId)
+
+			if err := executionService.processReadyTask(ctx, taskId); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			taskId := string(ev.Data)
+
+			srvc.Logger().
+				Error("failed to process a ready task",
+					slog.String("id", taskId),
+					slog.String("error", err.Error()))
+		},
+	}
+}
+
+func (srvc *Service) concurrency() int {
+	if srvc.Concurrency <= 0 {
+		return DefaultConcurrency
+	}
+
+	return srvc.Concurrency
+}
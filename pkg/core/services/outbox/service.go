@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"log/slog"
+	"time"
+)
+
+// Service (relay) delivers events that were stored in task outboxes but never published
+// (e.g. the spawning process crashed right after inserting the task).
+//
+// Delivery is at-least-once: an event can be published again if the relay fails
+// before marking the outbox as sent. Consumers deduplicate events by core.Event.ID.
+type Service struct {
+	System core.AbstractSystem
+	// Interval between outbox scans
+	Interval time.Duration
+	// Delay is the minimal age of an event before the relay picks it up.
+	// It gives the spawning process a chance to publish the event itself.
+	Delay time.Duration
+	// PageSize is the maximal amount of outboxes relayed by a scan (DefaultPageSize if not positive)
+	PageSize int
+
+	cursor core.PageCursor
+
+	service.Core
+}
+
+const DefaultPageSize = 1000
+
+func (srvc *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(srvc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := srvc.Drain(ctx); err != nil {
+				srvc.Logger().Error("failed to drain the outbox",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// Drain publishes pending outbox events that are older than Delay.
+//
+// Outboxes are scanned by pages ordered by the time of their newest events (if the task db is a core.PagedTaskDB),
+// the next call continues from where the previous one has stopped and the last page wraps around to the first one.
+func (srvc *Service) Drain(ctx context.Context) error {
+	tasks, err := srvc.nextPage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load pending tasks: %w", err)
+	}
+
+	threshold := time.Now().Add(-srvc.Delay).UnixNano()
+
+	for _, task := range tasks {
+		if err := srvc.relay(ctx, task, threshold); err != nil {
+			srvc.Logger().Error("failed to relay the outbox",
+				slog.String("task_id", task.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// nextPage returns tasks with pending outboxes after the cursor and moves it (task dbs that can't page are scanned entirely)
+func (srvc *Service) nextPage(ctx context.Context) ([]core.Task, error) {
+	filter := map[string][]any{
+		"info." + core.OutboxInfoKey + ".status": {core.OutboxStatuses.Pending},
+	}
+
+	pager, ok := srvc.System.Tasks().(core.PagedTaskDB)
+	if !ok {
+		return srvc.System.Tasks().GetWithProperties(ctx, filter)
+	}
+
+	pageSize := srvc.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	page, err := pager.GetPage(ctx, filter, "info."+core.OutboxInfoKey+".updated_at", srvc.cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page) < pageSize {
+		srvc.cursor = core.PageCursor{}
+	} else {
+		last := page[len(page)-1]
+		outbox, _ := last.Info[core.OutboxInfoKey].(map[string]any)
+		updatedAt, _ := toInt64(outbox["updated_at"])
+		srvc.cursor = core.PageCursor{Value: updatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
+
+func (srvc *Service) relay(ctx context.Context, task core.Task, threshold int64) error {
+	events, err := task.OutboxEvents()
+	if err != nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+
+	for _, event := range events {
+		if event.Timestamp > threshold {
+			return nil
+		}
+	}
+
+	for _, event := range events {
+		if err := srvc.System.Events().Send(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish an event (id='%s'): %w", event.ID, err)
+		}
+	}
+
+	// the outbox stays pending if it has got a newer event since it was loaded
+	if err := core.MarkOutboxSent(ctx, srvc.System.Tasks(), task.OutboxLastEventID()); err != nil {
+		return err
+	}
+
+	srvc.Logger().Debug("relayed the outbox",
+		slog.String("task_id", task.ID),
+		slog.Int("events", len(events)))
+
+	return nil
+}
+
+func toInt64(value any) (int64, bool) {
+	switch value := value.(type) {
+	case int64:
+		return value, true
+	case int:
+		return int64(value), true
+	case int32:
+		return int64(value), true
+	case float64:
+		return int64(value), true
+	default:
+		return 0, false
+	}
+}
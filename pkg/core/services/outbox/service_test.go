@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestRelayDeliversEventsAfterCrash(t *testing.T) {
+	ctx := context.Background()
+
+	events := &memoryBroker{failing: true}
+	sys := core.NewSystem(events, resourcedb.NewMockDB(), inmem.New(), slog.Default())
+
+	// the broker is down, so the event is never published by Spawn
+	task, err := sys.Spawn(ctx, core.New())
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	if len(events.sent) != 0 {
+		t.Fatalf("expected no events to be sent, got %d", len(events.sent))
+	}
+
+	stored, err := sys.Task(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("failed to load the task: %s", err)
+	}
+	if stored.OutboxStatus() != core.OutboxStatuses.Pending {
+		t.Fatalf("expected the outbox to be pending, got '%s'", stored.OutboxStatus())
+	}
+
+	events.failing = false
+
+	relay := &Service{
+		System: sys,
+		Core:   service.NewCore("outbox-relay", "test", slog.Default()),
+	}
+
+	// the same outbox must be relayed only once
+	for i := 0; i < 2; i++ {
+		if err := relay.Drain(ctx); err != nil {
+			t.Fatalf("failed to drain: %s", err)
+		}
+	}
+
+	if len(events.sent) != 1 {
+		t.Fatalf("expected 1 event to be sent, got %d", len(events.sent))
+	}
+
+	sent := events.sent[0]
+	if sent.Topic != core.OnTask.Created || string(sent.Data) != task.ID {
+		t.Fatalf("unexpected event: topic='%s' data='%s'", sent.Topic, sent.Data)
+	}
+
+	stored, err = sys.Task(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("failed to load the task: %s", err)
+	}
+	if stored.OutboxStatus() != core.OutboxStatuses.Sent {
+		t.Fatalf("expected the outbox to be sent, got '%s'", stored.OutboxStatus())
+	}
+}
+
+func TestRelaySkipsFreshEvents(t *testing.T) {
+	ctx := context.Background()
+
+	events := &memoryBroker{failing: true}
+	sys := core.NewSystem(events, resourcedb.NewMockDB(), inmem.New(), slog.Default())
+
+	if _, err := sys.Spawn(ctx, core.New()); err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	events.failing = false
+
+	relay := &Service{
+		System: sys,
+		Delay:  time.Hour,
+		Core:   service.NewCore("outbox-relay", "test", slog.Default()),
+	}
+	if err := relay.Drain(ctx); err != nil {
+		t.Fatalf("failed to drain: %s", err)
+	}
+
+	if len(events.sent) != 0 {
+		t.Fatalf("expected no events to be sent, got %d", len(events.sent))
+	}
+}
+
+func TestRelayKeepsEventsAddedLater(t *testing.T) {
+	ctx := context.Background()
+
+	events := &memoryBroker{failing: true}
+	sys := core.NewSystem(events, resourcedb.NewMockDB(), inmem.New(), slog.Default())
+
+	task, err := sys.Spawn(ctx, core.New())
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	stored, err := sys.Task(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("failed to load the task: %s", err)
+	}
+	createdId := stored.OutboxLastEventID()
+
+	// the "cancelled" event is added next to the pending "created" one
+	if err := sys.Cancel(ctx, task.ID, ""); err != nil {
+		t.Fatalf("failed to cancel: %s", err)
+	}
+
+	// a late flush of the "created" event must not mark the "cancelled" one as sent
+	if err := core.MarkOutboxSent(ctx, sys.Tasks(), createdId); err != nil {
+		t.Fatalf("failed to mark the outbox: %s", err)
+	}
+
+	events.failing = false
+
+	relay := &Service{
+		System: sys,
+		Core:   service.NewCore("outbox-relay", "test", slog.Default()),
+	}
+	if err := relay.Drain(ctx); err != nil {
+		t.Fatalf("failed to drain: %s", err)
+	}
+
+	var topics []string
+	for _, event := range events.sent {
+		topics = append(topics, event.Topic)
+	}
+	if len(topics) != 2 || topics[0] != core.OnTask.Created || topics[1] != core.OnTask.Cancelled {
+		t.Fatalf("unexpected events: %v", topics)
+	}
+}
+
+func TestRelayPages(t *testing.T) {
+	ctx := context.Background()
+
+	events := &memoryBroker{failing: true}
+	sys := core.NewSystem(events, resourcedb.NewMockDB(), inmem.New(), slog.Default())
+
+	for i := 0; i < 3; i++ {
+		if _, err := sys.Spawn(ctx, core.New()); err != nil {
+			t.Fatalf("failed to spawn: %s", err)
+		}
+	}
+
+	events.failing = false
+
+	relay := &Service{
+		System:   sys,
+		PageSize: 2,
+		Core:     service.NewCore("outbox-relay", "test", slog.Default()),
+	}
+
+	for _, expected := range []int{2, 3, 3} {
+		if err := relay.Drain(ctx); err != nil {
+			t.Fatalf("failed to drain: %s", err)
+		}
+		if len(events.sent) != expected {
+			t.Fatalf("expected %d events to be sent, got %d", expected, len(events.sent))
+		}
+	}
+}
+
+type memoryBroker struct {
+	failing bool
+	sent    []core.Event
+}
+
+func (b *memoryBroker) Send(ctx context.Context, event core.Event) error {
+	if b.failing {
+		return errors.New("broker is unavailable")
+	}
+	b.sent = append(b.sent, event)
+	return nil
+}
+
+func (b *memoryBroker) Consume(ctx context.Context, events []string, settings broker.ConsumerSettings) (<-chan core.BrokerEvent, error) {
+	return nil, errors.New("not supported")
+}
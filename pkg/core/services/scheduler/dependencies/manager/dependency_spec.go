@@ -3,4 +3,6 @@ package manager
 type DependencySpec struct {
 	Name string
 	Data any
+	// Optional dependencies are satisfied even if they fail
+	Optional bool
 }
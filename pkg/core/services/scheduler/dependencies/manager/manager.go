@@ -2,31 +2,49 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
 	"github.com/ischenkx/kantoku/pkg/common/dependency"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
 	"github.com/mitchellh/mapstructure"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
 )
 
+const resourceDependency = "resource_db"
+
 type Manager struct {
 	System       core.AbstractSystem
 	Dependencies dependency.Manager
 	TaskToGroup  TaskToGroup
 	Resolvers    map[string]Resolver
-	Logger       *slog.Logger
+	// ResultCodec encodes results of tasks failed because of their dependencies
+	ResultCodec codec.Codec[executor.Result, []byte]
+	Logger      *slog.Logger
 }
 
 type dependenciesInfo struct {
 	Specs     []DependencySpec
-	GroupID   string
+	Mode      dependency.Mode
+	GroupID   string `mapstructure:"group_id"`
 	Instances []string
+	// Bound is set when every instance is bound to its resolver
+	Bound bool
+	// Initialized is set when the group is initialized (the registration is complete)
+	Initialized bool
+	// FailedInputs are inputs whose producers have failed before the task was registered
+	FailedInputs map[string]bool `mapstructure:"failed_inputs"`
 }
 
+// Register creates a dependency group of the task.
+//
+// The progress is saved to the task after every step, so a registration interrupted by a crash
+// is resumed from the last completed step and registering a task twice is a no-op.
+// Instances and the group are saved with compare-and-set, so concurrent registrations agree on them.
 func (manager *Manager) Register(ctx context.Context, id string) error {
-	// TODO: use compensating transactions for consistency
 	t, err := manager.System.Task(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get task: %w", err)
@@ -37,19 +55,114 @@ func (manager *Manager) Register(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to decode dependencies: %w", err)
 	}
 
-	specs := info.Specs
+	if info.Initialized {
+		return nil
+	}
 
-	dependencies, err := manager.Dependencies.NewDependencies(ctx, len(specs))
+	if info.Instances == nil {
+		if info, err = manager.allocate(ctx, id, info); err != nil {
+			return err
+		}
+	}
+
+	if !info.Bound {
+		if err := manager.bind(ctx, id, info); err != nil {
+			return err
+		}
+		info.Bound = true
+	}
+
+	if info.GroupID == "" {
+		if info, err = manager.createGroup(ctx, id, info); err != nil {
+			return err
+		}
+	}
+
+	if err := manager.TaskToGroup.Save(ctx, id, info.GroupID); err != nil {
+		return fmt.Errorf("failed to save a task-group binding: %w", err)
+	}
+
+	mode := dependency.Mode{Required: info.Mode.Required}
+	for index, spec := range info.Specs {
+		if spec.Optional {
+			mode.Optional = append(mode.Optional, info.Instances[index])
+		}
+	}
+
+	// the group has been initialized by an interrupted registration
+	err = manager.Dependencies.InitializeGroup(ctx, info.GroupID, mode, info.Instances...)
+	if err != nil && !errors.Is(err, dependency.ErrGroupInitialized) {
+		return fmt.Errorf("failed to initialize group: %w", err)
+	}
+
+	manager.Logger.Info("saving", slog.Any("instances", info.Instances))
+
+	err = manager.System.Tasks().UpdateByIDs(ctx, []string{id}, map[string]any{
+		"info.dependencies.initialized": true,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to allocate new dependencies: %w", err)
+		return fmt.Errorf("failed to update task's info: %w", err)
+	}
+
+	// inputs might have been produced by tasks that had failed before the task was registered
+	if err := manager.failFromFailedProducers(ctx, t, info); err != nil {
+		return fmt.Errorf("failed to check producers of inputs: %w", err)
+	}
+
+	// failures recorded by FailDependents while the task was being registered
+	if info, err = manager.reload(ctx, id); err != nil {
+		return err
+	}
+	failedInputs := map[string]struct{}{}
+	for input, failed := range info.FailedInputs {
+		if failed {
+			failedInputs[input] = struct{}{}
+		}
+	}
+	if err := manager.Dependencies.Resolve(ctx, resourceDependencies(info, failedInputs)...); err != nil {
+		return fmt.Errorf("failed to resolve dependencies of failed inputs: %w", err)
+	}
+
+	return nil
+}
+
+// allocate creates dependencies of the task (the ones saved by a concurrent registration are used if it wins)
+func (manager *Manager) allocate(ctx context.Context, id string, info dependenciesInfo) (dependenciesInfo, error) {
+	dependencies, err := manager.Dependencies.NewDependencies(ctx, len(info.Specs))
+	if err != nil {
+		return info, fmt.Errorf("failed to allocate new dependencies: %w", err)
 	}
 
 	depIDs := lo.Map(dependencies, func(dep dependency.Dependency, _ int) string {
 		return dep.ID
 	})
 
-	for index, depId := range depIDs {
-		spec := specs[index]
+	saved, err := manager.saveOnce(ctx, id, "instances", depIDs)
+	if err != nil {
+		return info, err
+	}
+	if !saved {
+		if err := manager.Dependencies.DeleteDependencies(ctx, depIDs...); err != nil {
+			manager.Logger.Warn("failed to delete unused dependencies",
+				slog.Any("dependency_ids", depIDs),
+				slog.String("error", err.Error()))
+		}
+
+		return manager.reload(ctx, id)
+	}
+
+	info.Instances = depIDs
+	return info, nil
+}
+
+// bind binds instances to resolvers, a binding might be repeated if the registration is interrupted
+func (manager *Manager) bind(ctx context.Context, id string, info dependenciesInfo) error {
+	if len(info.Instances) != len(info.Specs) {
+		return fmt.Errorf("unexpected amount of instances: %d (specs: %d)", len(info.Instances), len(info.Specs))
+	}
+
+	for index, depId := range info.Instances {
+		spec := info.Specs[index]
 
 		resolver, ok := manager.Resolvers[spec.Name]
 		if !ok {
@@ -61,42 +174,222 @@ func (manager *Manager) Register(ctx context.Context, id string) error {
 		}
 	}
 
+	err := manager.System.Tasks().UpdateByIDs(ctx, []string{id}, map[string]any{
+		"info.dependencies.bound": true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update task's info: %w", err)
+	}
+
+	return nil
+}
+
+// createGroup creates a group of the task (the one saved by a concurrent registration is used if it wins)
+func (manager *Manager) createGroup(ctx context.Context, id string, info dependenciesInfo) (dependenciesInfo, error) {
 	groupId, err := manager.Dependencies.NewGroup(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create a dependency group: %w", err)
+		return info, fmt.Errorf("failed to create a dependency group: %w", err)
 	}
 
-	if err := manager.TaskToGroup.Save(ctx, id, groupId); err != nil {
-		return fmt.Errorf("failed to save a task-group binding: %w", err)
+	saved, err := manager.saveOnce(ctx, id, "group_id", groupId)
+	if err != nil {
+		return info, err
 	}
+	if !saved {
+		if err := manager.Dependencies.DeleteGroups(ctx, groupId); err != nil {
+			manager.Logger.Warn("failed to delete an unused group",
+				slog.String("group_id", groupId),
+				slog.String("error", err.Error()))
+		}
 
-	if err := manager.Dependencies.InitializeGroup(ctx, groupId, depIDs...); err != nil {
-		return fmt.Errorf("failed to initialize group: %w", err)
+		return manager.reload(ctx, id)
+	}
+
+	info.GroupID = groupId
+	return info, nil
+}
+
+// saveOnce sets the property of the task's dependencies if it's not set yet
+func (manager *Manager) saveOnce(ctx context.Context, id, property string, value any) (bool, error) {
+	key := "info.dependencies." + property
+
+	updated, err := manager.System.Tasks().UpdateWithProperties(ctx,
+		map[string][]any{
+			"id": {id},
+			key:  {nil},
+		},
+		map[string]any{
+			key: value,
+		})
+	if err != nil {
+		return false, fmt.Errorf("failed to save %s: %w", property, err)
 	}
 
-	manager.Logger.Info("saving", slog.Any("instances", depIDs))
+	return updated > 0, nil
+}
 
-	err = manager.System.
-		Tasks().
-		UpdateByIDs(
-			ctx,
-			[]string{id},
-			map[string]any{
-				"info.dependencies.group_id":  groupId,
-				"info.dependencies.instances": depIDs,
-			},
-		)
+func (manager *Manager) reload(ctx context.Context, id string) (dependenciesInfo, error) {
+	t, err := manager.System.Task(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to update task's info: %w", err)
+		return dependenciesInfo{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	var info dependenciesInfo
+	if err := mapstructure.Decode(t.Info["dependencies"], &info); err != nil {
+		return dependenciesInfo{}, fmt.Errorf("failed to decode dependencies: %w", err)
+	}
+
+	return info, nil
+}
+
+// FailDependents fails dependencies of tasks waiting for outputs of the task that will never be initialized.
+// The failure is recorded on dependents that are not registered yet (info.dependencies.failed_inputs),
+// so their registration fails the dependencies.
+func (manager *Manager) FailDependents(ctx context.Context, task core.Task) error {
+	if len(task.Outputs) == 0 {
+		return nil
+	}
+
+	dependents, err := manager.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"inputs": lo.ToAnySlice(task.Outputs),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load dependent tasks: %w", err)
+	}
+
+	outputs := lo.SliceToMap(task.Outputs, func(id string) (string, struct{}) { return id, struct{}{} })
+
+	var failed []dependency.Dependency
+	var unregistered []string
+	for _, dependent := range dependents {
+		var info dependenciesInfo
+		if err := mapstructure.Decode(dependent.Info["dependencies"], &info); err != nil {
+			manager.Logger.Warn("failed to decode dependencies",
+				slog.String("task_id", dependent.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		// the registration might have checked the producer before its failure was visible,
+		// so the failure is left to the registration
+		if !info.Initialized && dependsOnResources(info, outputs) && !finished(dependent) {
+			unregistered = append(unregistered, dependent.ID)
+			continue
+		}
+
+		failed = append(failed, resourceDependencies(info, outputs)...)
+	}
+
+	if err := manager.Dependencies.Resolve(ctx, failed...); err != nil {
+		return fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	if len(unregistered) > 0 {
+		properties := make(map[string]any, len(task.Outputs))
+		for _, output := range task.Outputs {
+			properties["info.dependencies.failed_inputs."+output] = true
+		}
+
+		if err := manager.System.Tasks().UpdateByIDs(ctx, unregistered, properties); err != nil {
+			return fmt.Errorf("failed to record failed inputs of unregistered dependents: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func (manager *Manager) Ready(ctx context.Context) (tasks <-chan string, err error) {
+func (manager *Manager) failFromFailedProducers(ctx context.Context, t core.Task, info dependenciesInfo) error {
+	var inputs []any
+	for _, spec := range info.Specs {
+		if id, ok := spec.Data.(string); ok && spec.Name == resourceDependency {
+			inputs = append(inputs, id)
+		}
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	producers, err := manager.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"outputs": inputs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load producers: %w", err)
+	}
+
+	failedOutputs := map[string]struct{}{}
+	for _, producer := range producers {
+		if !failedPermanently(producer) {
+			continue
+		}
+		for _, id := range producer.Outputs {
+			failedOutputs[id] = struct{}{}
+		}
+	}
+
+	if err := manager.Dependencies.Resolve(ctx, resourceDependencies(info, failedOutputs)...); err != nil {
+		return fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	return nil
+}
+
+// resourceDependencies returns failed dependencies bound to the resources
+func resourceDependencies(info dependenciesInfo, resources map[string]struct{}) []dependency.Dependency {
+	var result []dependency.Dependency
+	for index, spec := range info.Specs {
+		if spec.Name != resourceDependency || index >= len(info.Instances) {
+			continue
+		}
+
+		id, _ := spec.Data.(string)
+		if _, ok := resources[id]; !ok {
+			continue
+		}
+
+		result = append(result, dependency.Dependency{
+			ID:     info.Instances[index],
+			Status: dependency.Failed,
+		})
+	}
+
+	return result
+}
+
+// dependsOnResources reports whether any of the resource dependencies is bound to the resources
+func dependsOnResources(info dependenciesInfo, resources map[string]struct{}) bool {
+	return lo.SomeBy(info.Specs, func(spec DependencySpec) bool {
+		id, _ := spec.Data.(string)
+		_, ok := resources[id]
+		return spec.Name == resourceDependency && ok
+	})
+}
+
+func finished(task core.Task) bool {
+	return task.Status() == core.TaskStatuses.Finished || task.Status() == core.TaskStatuses.Cancelled
+}
+
+// failedPermanently reports whether the task will never initialize its outputs
+func failedPermanently(task core.Task) bool {
+	switch task.Status() {
+	case core.TaskStatuses.Cancelled:
+		return true
+	case core.TaskStatuses.Finished:
+		return task.SubStatus() != core.TaskSubStatuses.OK && !task.WillRetry(task.SubStatus(), task.ErrorCode())
+	default:
+		return false
+	}
+}
+
+// ReadyTask is a task whose dependencies are ready, it must be acknowledged once it's handled (see Manager.Ack)
+type ReadyTask struct {
+	ID      string
+	GroupID string
+}
+
+func (manager *Manager) Ready(ctx context.Context) (tasks <-chan ReadyTask, err error) {
 	g, ctx := errgroup.WithContext(ctx)
 
-	_tasks := make(chan string, 1024)
+	_tasks := make(chan ReadyTask, 1024)
 
 	manager.resolveDependencies(ctx, g)
 
@@ -117,11 +410,12 @@ func (manager *Manager) Ready(ctx context.Context) (tasks <-chan string, err err
 
 func (manager *Manager) resolveDependencies(ctx context.Context, g *errgroup.Group) {
 	for label, resolver := range manager.Resolvers {
+		label, resolver := label, resolver
 		g.Go(func() error {
 			manager.Logger.Info("starting a resolver",
 				slog.String("label", label))
 
-			depsChannel, err := resolver.Ready(ctx)
+			depsChannel, err := resolutions(ctx, resolver)
 			if err != nil {
 				return err
 			}
@@ -133,12 +427,10 @@ func (manager *Manager) resolveDependencies(ctx context.Context, g *errgroup.Gro
 					break depResolver
 				case dep := <-depsChannel:
 					manager.Logger.Debug("received a dependency from resolver",
-						slog.String("dependency_id", dep),
+						slog.String("dependency_id", dep.ID),
+						slog.String("status", string(dep.Status)),
 						slog.String("resolver", label))
-					err := manager.Dependencies.Resolve(ctx, dependency.Dependency{
-						ID:     dep,
-						Status: dependency.OK,
-					})
+					err := manager.Dependencies.Resolve(ctx, dep)
 					if err != nil {
 						manager.Logger.Error("failed to resolve a dependency",
 							slog.String("error", err.Error()))
@@ -150,7 +442,51 @@ func (manager *Manager) resolveDependencies(ctx context.Context, g *errgroup.Gro
 	}
 }
 
-func (manager *Manager) collectReadyTasks(ctx context.Context, tasks chan<- string) error {
+func resolutions(ctx context.Context, resolver Resolver) (<-chan dependency.Dependency, error) {
+	if statusResolver, ok := resolver.(StatusResolver); ok {
+		return statusResolver.Resolutions(ctx)
+	}
+
+	ids, err := resolver.Ready(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make(chan dependency.Dependency, 1024)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case id := <-ids:
+				select {
+				case <-ctx.Done():
+					return
+				case deps <- dependency.Dependency{ID: id, Status: dependency.OK}:
+				}
+			}
+		}
+	}()
+
+	return deps, nil
+}
+
+// Ack acknowledges that the ready task has been handled, so its group is not delivered again
+// (unacknowledged groups are redelivered by managers that support it, see dependency.GroupAcknowledger)
+func (manager *Manager) Ack(ctx context.Context, task ReadyTask) error {
+	acknowledger, ok := manager.Dependencies.(dependency.GroupAcknowledger)
+	if !ok {
+		return nil
+	}
+
+	if err := acknowledger.AckGroups(ctx, task.GroupID); err != nil {
+		return fmt.Errorf("failed to acknowledge the group: %w", err)
+	}
+
+	return nil
+}
+
+func (manager *Manager) collectReadyTasks(ctx context.Context, tasks chan<- ReadyTask) error {
 	channel, err := manager.Dependencies.ReadyGroups(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to read ready groups: %w", err)
@@ -170,10 +506,27 @@ collector:
 				slog.String("group_id", id),
 				slog.String("task_id", task))
 
+			ready, err := manager.handleFailures(ctx, id, task)
+			if err != nil {
+				manager.Logger.Error("failed to handle failed dependencies",
+					slog.String("task_id", task),
+					slog.String("error", err.Error()))
+				continue
+			}
+			if !ready {
+				// the task has been failed, so the group is handled
+				if err := manager.Ack(ctx, ReadyTask{ID: task, GroupID: id}); err != nil {
+					manager.Logger.Error("failed to acknowledge a ready task",
+						slog.String("task_id", task),
+						slog.String("error", err.Error()))
+				}
+				continue
+			}
+
 			select {
 			case <-ctx.Done():
 				break collector
-			case tasks <- task:
+			case tasks <- ReadyTask{ID: task, GroupID: id}:
 			}
 		case <-ctx.Done():
 			break collector
@@ -182,3 +535,79 @@ collector:
 
 	return nil
 }
+
+// handleFailures fails the task if its group can't be satisfied because of failed dependencies
+// (unless it runs on upstream failures)
+func (manager *Manager) handleFailures(ctx context.Context, groupId, taskId string) (ready bool, err error) {
+	groups, err := manager.Dependencies.LoadGroups(ctx, groupId)
+	if err != nil {
+		return false, fmt.Errorf("failed to load the group: %w", err)
+	}
+
+	failed := lo.SomeBy(groups, func(group dependency.Group) bool {
+		_, satisfied := group.State()
+		return !satisfied
+	})
+	if !failed {
+		return true, nil
+	}
+
+	task, err := manager.System.Task(ctx, taskId)
+	if err != nil {
+		return false, fmt.Errorf("failed to load the task: %w", err)
+	}
+
+	if task.UpstreamFailurePolicy() == core.UpstreamFailurePolicies.Run {
+		return true, nil
+	}
+
+	// the "created" event might not be processed by the status service yet
+	if task.Status() == "" {
+		err := core.TaskStates.Transition(ctx, manager.System.Tasks(), taskId, core.TaskStatuses.Initialized, "", nil)
+		if err != nil && !errors.Is(err, core.ErrIllegalTransition) {
+			return false, fmt.Errorf("failed to initialize the task: %w", err)
+		}
+	}
+
+	data, err := manager.ResultCodec.Encode(executor.Result{
+		TaskID: taskId,
+		Status: executor.Failed,
+		Data:   []byte(core.TaskFailureReasons.UpstreamFailed),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode the result: %w", err)
+	}
+
+	// the event lets other services (and the failure propagation of the task's dependents) know about the failure,
+	// it's stored in the outbox along with the transition, so it's delivered by the relay if the publishing fails
+	finishedEvent := core.NewEvent(core.OnTask.Finished, data)
+
+	err = core.TaskStates.Transition(ctx, manager.System.Tasks(), taskId,
+		core.TaskStatuses.Finished,
+		core.TaskSubStatuses.Failed,
+		lo.Assign(core.OutboxProperties(finishedEvent), map[string]any{
+			"info.failure_reason": core.TaskFailureReasons.UpstreamFailed,
+		}))
+	if errors.Is(err, core.ErrIllegalTransition) {
+		// the task has been cancelled or the group has been redelivered
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to finish the task: %w", err)
+	}
+
+	if err := manager.System.Events().Send(ctx, finishedEvent); err != nil {
+		manager.Logger.Warn("failed to publish an event, leaving it to the outbox relay",
+			slog.String("task_id", taskId),
+			slog.String("error", err.Error()))
+	} else if err := core.MarkOutboxSent(ctx, manager.System.Tasks(), finishedEvent.ID); err != nil {
+		manager.Logger.Warn("failed to mark the outbox as sent",
+			slog.String("task_id", taskId),
+			slog.String("error", err.Error()))
+	}
+
+	manager.Logger.Info("failed a task because of its dependencies",
+		slog.String("task_id", taskId))
+
+	return false, nil
+}
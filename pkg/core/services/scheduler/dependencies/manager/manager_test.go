@@ -0,0 +1,322 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/dependency/inmem"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	resourceResolver "github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/resource_resolver"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/task2group"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/mitchellh/mapstructure"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestUpstreamFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	mngr := &Manager{
+		System:       sys,
+		Dependencies: inmem.New(),
+		TaskToGroup:  task2group.NewMemoryStorage(),
+		Resolvers: map[string]Resolver{
+			"resource_db": &resourceResolver.Resolver{
+				System:  sys,
+				Storage: resourceResolver.NewMemoryStorage(),
+				Logger:  logger,
+			},
+		},
+		ResultCodec: codec.JSON[executor.Result](),
+		Logger:      logger,
+	}
+
+	spawn := func(inputs, outputs []string, options ...core.Option) core.Task {
+		options = append([]core.Option{
+			taskopts.WithInputs(inputs...),
+			taskopts.WithOutputs(outputs...),
+			taskopts.DependOnInputs(),
+		}, options...)
+
+		task, err := sys.Spawn(ctx, core.New(options...))
+		if err != nil {
+			t.Fatalf("failed to spawn a task: %s", err)
+		}
+
+		if len(inputs) > 0 {
+			if err := mngr.Register(ctx, task.ID); err != nil {
+				t.Fatalf("failed to register a task: %s", err)
+			}
+		}
+
+		return task
+	}
+
+	resources, err := sys.Resources().Alloc(ctx, 3)
+	if err != nil {
+		t.Fatalf("failed to allocate resources: %s", err)
+	}
+
+	// upstream -> dependent -> transitive, upstream -> tolerant
+	upstream := spawn(nil, resources[:1])
+	dependent := spawn(resources[:1], resources[1:2])
+	transitive := spawn(resources[1:2], nil)
+	tolerant := spawn(resources[:1], resources[2:],
+		taskopts.WithUpstreamFailurePolicy(core.UpstreamFailurePolicies.Run))
+
+	err = sys.Tasks().UpdateByIDs(ctx, []string{upstream.ID}, map[string]any{
+		"info.status":     core.TaskStatuses.Finished,
+		"info.sub_status": core.TaskSubStatuses.Failed,
+	})
+	if err != nil {
+		t.Fatalf("failed to fail a task: %s", err)
+	}
+
+	finished, err := sys.Events().Consume(ctx, []string{core.OnTask.Finished}, broker.ConsumerSettings{Group: "test"})
+	if err != nil {
+		t.Fatalf("failed to consume events: %s", err)
+	}
+
+	ready, err := mngr.Ready(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the manager: %s", err)
+	}
+
+	if err := mngr.FailDependents(ctx, upstream); err != nil {
+		t.Fatalf("failed to fail dependents: %s", err)
+	}
+
+	// a task registered after the failure of its upstream
+	late := spawn(resources[:1], nil)
+
+	failed := map[string]bool{}
+	tolerantReady := false
+	deadline := time.After(5 * time.Second)
+	for !failed[dependent.ID] || !failed[transitive.ID] || !failed[late.ID] || !tolerantReady {
+		select {
+		case task := <-ready:
+			if task.ID != tolerant.ID {
+				t.Fatalf("unexpected ready task: %s", task.ID)
+			}
+			tolerantReady = true
+		case message := <-finished:
+			message.Ack()
+
+			result, err := mngr.ResultCodec.Decode(message.Item().Data)
+			if err != nil {
+				t.Fatalf("failed to decode a result: %s", err)
+			}
+			failed[result.TaskID] = true
+
+			// the scheduler service propagates failures of finished tasks
+			task, err := sys.Task(ctx, result.TaskID)
+			if err != nil {
+				t.Fatalf("failed to load a task: %s", err)
+			}
+			if err := mngr.FailDependents(ctx, task); err != nil {
+				t.Fatalf("failed to fail dependents: %s", err)
+			}
+		case <-deadline:
+			t.Fatalf("tasks have not been processed (failed: %v, tolerant ready: %t)", failed, tolerantReady)
+		}
+	}
+
+	task, err := sys.Task(ctx, transitive.ID)
+	if err != nil {
+		t.Fatalf("failed to load a task: %s", err)
+	}
+	if task.Status() != core.TaskStatuses.Finished ||
+		task.SubStatus() != core.TaskSubStatuses.Failed ||
+		task.FailureReason() != core.TaskFailureReasons.UpstreamFailed {
+		t.Fatalf("unexpected task state: %s/%s (%s)", task.Status(), task.SubStatus(), task.FailureReason())
+	}
+
+	if task.OutboxStatus() != core.OutboxStatuses.Sent {
+		t.Fatalf("unexpected outbox status: '%s'", task.OutboxStatus())
+	}
+	// the "finished" event is added next to the "created" one
+	events, err := task.OutboxEvents()
+	if err != nil || len(events) != 2 || events[1].Topic != core.OnTask.Finished {
+		t.Fatalf("unexpected outbox events: %+v (error: %v)", events, err)
+	}
+
+	if failed[tolerant.ID] {
+		t.Fatal("a task running on upstream failures has been failed")
+	}
+}
+
+func TestFailDependentsWaitsForRegistration(t *testing.T) {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	mngr := &Manager{
+		System:       sys,
+		Dependencies: inmem.New(),
+		TaskToGroup:  task2group.NewMemoryStorage(),
+		Resolvers: map[string]Resolver{
+			"resource_db": &resourceResolver.Resolver{
+				System:  sys,
+				Storage: resourceResolver.NewMemoryStorage(),
+				Logger:  logger,
+			},
+		},
+		ResultCodec: codec.JSON[executor.Result](),
+		Logger:      logger,
+	}
+
+	resources, err := sys.Resources().Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate resources: %s", err)
+	}
+
+	upstream, err := sys.Spawn(ctx, core.New(taskopts.WithOutputs(resources...)))
+	if err != nil {
+		t.Fatalf("failed to spawn a task: %s", err)
+	}
+
+	dependent, err := sys.Spawn(ctx, core.New(taskopts.WithInputs(resources...), taskopts.DependOnInputs()))
+	if err != nil {
+		t.Fatalf("failed to spawn a task: %s", err)
+	}
+
+	// the failure is propagated before the dependent is registered, so it's recorded
+	if err := mngr.FailDependents(ctx, upstream); err != nil {
+		t.Fatalf("failed to fail dependents: %s", err)
+	}
+
+	if err := mngr.Register(ctx, dependent.ID); err != nil {
+		t.Fatalf("failed to register a task: %s", err)
+	}
+
+	info, err := mngr.reload(ctx, dependent.ID)
+	if err != nil {
+		t.Fatalf("failed to load dependencies: %s", err)
+	}
+
+	deps, err := mngr.Dependencies.LoadDependencies(ctx, info.Instances...)
+	if err != nil || len(deps) != 1 {
+		t.Fatalf("failed to load dependencies: %v (%+v)", err, deps)
+	}
+	if deps[0].Status != dependency.Failed {
+		t.Fatalf("the recorded failure has not been picked up by the registration: %+v", deps[0])
+	}
+}
+
+type flakyResolver struct {
+	failures int
+	bound    []string
+}
+
+func (resolver *flakyResolver) Bind(ctx context.Context, id string, data any) error {
+	if resolver.failures > 0 {
+		resolver.failures--
+		return errors.New("unavailable")
+	}
+	resolver.bound = append(resolver.bound, id)
+	return nil
+}
+
+func (resolver *flakyResolver) Ready(ctx context.Context) (<-chan string, error) {
+	return make(chan string), nil
+}
+
+func TestRegisterResumes(t *testing.T) {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &flakyResolver{failures: 1}
+	deps := inmem.New()
+	taskToGroup := task2group.NewMemoryStorage()
+	mngr := &Manager{
+		System:       sys,
+		Dependencies: deps,
+		TaskToGroup:  taskToGroup,
+		Resolvers:    map[string]Resolver{"flaky": resolver},
+		ResultCodec:  codec.JSON[executor.Result](),
+		Logger:       logger,
+	}
+
+	task, err := sys.Spawn(ctx, core.New(taskopts.WithDependencies(
+		dependency.AllOf(),
+		taskopts.Dependency{Name: "flaky", Data: 1},
+		taskopts.Dependency{Name: "flaky", Data: 2},
+	)))
+	if err != nil {
+		t.Fatalf("failed to spawn a task: %s", err)
+	}
+
+	if err := mngr.Register(ctx, task.ID); err == nil {
+		t.Fatal("the first registration has not failed")
+	}
+
+	// the registration is resumed, then repeated (e.g. on a redelivered event)
+	for i := 0; i < 2; i++ {
+		if err := mngr.Register(ctx, task.ID); err != nil {
+			t.Fatalf("failed to register a task: %s", err)
+		}
+	}
+
+	registered, err := sys.Task(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("failed to load a task: %s", err)
+	}
+
+	var info dependenciesInfo
+	if err := mapstructure.Decode(registered.Info["dependencies"], &info); err != nil {
+		t.Fatalf("failed to decode dependencies: %s", err)
+	}
+
+	if !info.Initialized || len(info.Instances) != 2 {
+		t.Fatalf("unexpected dependencies: %+v", info)
+	}
+
+	if len(resolver.bound) != 2 || resolver.bound[0] != info.Instances[0] || resolver.bound[1] != info.Instances[1] {
+		t.Fatalf("unexpected bindings: %v (instances: %v)", resolver.bound, info.Instances)
+	}
+
+	groupId, err := taskToGroup.GroupByTask(ctx, task.ID)
+	if err != nil || groupId != info.GroupID {
+		t.Fatalf("unexpected group of the task: '%s' (expected '%s', error: %v)", groupId, info.GroupID, err)
+	}
+
+	groups, err := deps.LoadGroups(ctx, groupId)
+	if err != nil {
+		t.Fatalf("failed to load the group: %s", err)
+	}
+	if len(groups) != 1 || len(groups[0].Dependencies) != 2 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
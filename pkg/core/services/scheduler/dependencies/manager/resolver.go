@@ -1,8 +1,23 @@
 package manager
 
-import "context"
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+)
 
 type Resolver interface {
 	Bind(ctx context.Context, id string, data any) error
 	Ready(ctx context.Context) (<-chan string, error)
 }
+
+// StatusResolver reports failed dependencies as well (the manager uses Resolutions instead of Ready)
+type StatusResolver interface {
+	Resolver
+	Resolutions(ctx context.Context) (<-chan dependency.Dependency, error)
+}
+
+// CollectableResolver can delete bindings of dependencies that are not needed anymore (see gc.Service)
+type CollectableResolver interface {
+	Resolver
+	DeleteBindings(ctx context.Context, dependencyIds ...string) error
+}
@@ -48,6 +48,20 @@ func (storage *MongoStorage) Resolve(ctx context.Context, dependencyIds ...strin
 	return err
 }
 
+func (storage *MongoStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.DeleteMany(ctx,
+		bson.M{
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		})
+
+	return err
+}
+
 func (storage *MongoStorage) Poll(ctx context.Context, limit int) ([]Binding, error) {
 	updatedAtLowerBoundary := time.Now().Add(-storage.PollTimeout).Unix()
 
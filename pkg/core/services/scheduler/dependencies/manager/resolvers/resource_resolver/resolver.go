@@ -3,18 +3,28 @@ package resourceResolver
 import (
 	"context"
 	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/samber/lo"
 	"log/slog"
 	"time"
 )
 
+// Notifications reports ids of resources that have become ready (e.g. postgres.DB from resource_db/postgres)
+type Notifications interface {
+	Listen(ctx context.Context) (<-chan string, error)
+}
+
+// Resolver resolves dependencies when their resources are initialized
+// and fails them if the resources expire before that.
 type Resolver struct {
 	System       core.AbstractSystem
 	Storage      Storage
 	PollLimit    int
 	PollInterval time.Duration
-	Logger       *slog.Logger
+	// Notifications (optional) trigger polling as soon as resources are initialized
+	Notifications Notifications
+	Logger        *slog.Logger
 }
 
 func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) error {
@@ -30,15 +40,53 @@ func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) erro
 	return nil
 }
 
+func (resolver *Resolver) DeleteBindings(ctx context.Context, dependencyIds ...string) error {
+	if err := resolver.Storage.Delete(ctx, dependencyIds...); err != nil {
+		return fmt.Errorf("failed to delete bindings: %w", err)
+	}
+
+	return nil
+}
+
+// Ready returns only dependencies of initialized resources, failed ones are reported by Resolutions
 func (resolver *Resolver) Ready(ctx context.Context) (<-chan string, error) {
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	depIds := make(chan string, 1024)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case dep := <-resolutions:
+				if dep.Status != dependency.OK {
+					continue
+				}
 
-	go resolver.collectResolvedDependencies(ctx, depIds)
+				select {
+				case <-ctx.Done():
+					return
+				case depIds <- dep.ID:
+				}
+			}
+		}
+	}()
 
 	return depIds, nil
 }
 
-func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, ids chan<- string) {
+func (resolver *Resolver) Resolutions(ctx context.Context) (<-chan dependency.Dependency, error) {
+	deps := make(chan dependency.Dependency, 1024)
+
+	go resolver.collectResolvedDependencies(ctx, deps)
+
+	return deps, nil
+}
+
+func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, deps chan<- dependency.Dependency) {
 
 	pollLimit := resolver.PollLimit
 	if pollLimit <= 0 {
@@ -55,52 +103,103 @@ func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, ids c
 	resolver.Logger.Info("collecting resolved dependencies",
 		slog.Duration("interval", pollInterval))
 
+	var notifications <-chan string
+	if resolver.Notifications != nil {
+		var err error
+		notifications, err = resolver.Notifications.Listen(ctx)
+		if err != nil {
+			resolver.Logger.Error("failed to listen to notifications, falling back to polling",
+				slog.String("error", err.Error()))
+		}
+	}
+
 poller:
 	for {
 		select {
 		case <-ctx.Done():
 			break poller
 
-		case <-ticker.C:
-			bindings, err := resolver.Storage.Poll(ctx, pollLimit)
-			if err != nil {
-				resolver.Logger.Error("failed to poll bindings",
-					slog.String("error", err.Error()))
+		case _, ok := <-notifications:
+			if !ok {
+				resolver.Logger.Warn("notifications stopped, falling back to polling")
+				notifications = nil
 				continue
 			}
+			// notifications come in bursts, so the pending ones are drained to poll once
+			drain(notifications)
+			if err := resolver.poll(ctx, deps, pollLimit); err != nil {
+				break poller
+			}
 
-			resource2dependencies := lo.GroupBy(bindings, func(binding Binding) string {
-				return binding.ResourceId
-			})
-
-			resourceIds := lo.Keys(resource2dependencies)
-			resources, err := resolver.System.Resources().Load(ctx, resourceIds...)
-			if err != nil {
-				resolver.Logger.Error("failed to load resources",
-					slog.String("error", err.Error()))
+		case <-ticker.C:
+			if err := resolver.poll(ctx, deps, pollLimit); err != nil {
+				break poller
 			}
+		}
+	}
+}
 
-			var resolvedIds []string
+// poll sends dependencies of ready (and expired) resources, it fails only if the context is done
+func (resolver *Resolver) poll(ctx context.Context, deps chan<- dependency.Dependency, pollLimit int) error {
+	bindings, err := resolver.Storage.Poll(ctx, pollLimit)
+	if err != nil {
+		resolver.Logger.Error("failed to poll bindings",
+			slog.String("error", err.Error()))
+		return nil
+	}
 
-			for _, res := range resources {
-				if res.Status != core.ResourceStatuses.Ready {
-					continue
-				}
+	resource2dependencies := lo.GroupBy(bindings, func(binding Binding) string {
+		return binding.ResourceId
+	})
 
-				resourceBindings := resource2dependencies[res.ID]
-				for _, binding := range resourceBindings {
-					select {
-					case <-ctx.Done():
-						break poller
-					case ids <- binding.DependencyId:
-						resolvedIds = append(resolvedIds, binding.DependencyId)
-					}
-				}
+	resourceIds := lo.Keys(resource2dependencies)
+	resources, err := core.LoadStatuses(ctx, resolver.System.Resources(), resourceIds...)
+	if err != nil {
+		resolver.Logger.Error("failed to load resources",
+			slog.String("error", err.Error()))
+	}
+
+	var resolvedIds []string
+
+	for _, res := range resources {
+		var status dependency.Status
+		switch res.Status {
+		case core.ResourceStatuses.Ready:
+			status = dependency.OK
+		case core.ResourceStatuses.Expired:
+			// expired resources will never be ready
+			status = dependency.Failed
+		default:
+			continue
+		}
+
+		resourceBindings := resource2dependencies[res.ID]
+		for _, binding := range resourceBindings {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case deps <- dependency.Dependency{ID: binding.DependencyId, Status: status}:
+				resolvedIds = append(resolvedIds, binding.DependencyId)
 			}
-			if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
-				resolver.Logger.Error("failed to resolve resources",
-					slog.String("error", err.Error()))
+		}
+	}
+	if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
+		resolver.Logger.Error("failed to resolve resources",
+			slog.String("error", err.Error()))
+	}
+
+	return nil
+}
+
+func drain(notifications <-chan string) {
+	for {
+		select {
+		case _, ok := <-notifications:
+			if !ok {
+				return
 			}
+		default:
+			return
 		}
 	}
 }
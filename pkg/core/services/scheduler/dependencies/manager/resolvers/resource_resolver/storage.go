@@ -10,5 +10,7 @@ type Binding struct {
 type Storage interface {
 	Save(ctx context.Context, dependencyId string, resourceId string) error
 	Resolve(ctx context.Context, resourceIds ...string) error
+	// Delete deletes bindings of the dependencies whatever their status is
+	Delete(ctx context.Context, dependencyIds ...string) error
 	Poll(ctx context.Context, limit int) ([]Binding, error)
 }
@@ -0,0 +1,91 @@
+package resourceResolverV2
+
+import (
+	"context"
+	"sync"
+)
+
+type MemoryStorage struct {
+	bindings []Binding
+	mu       sync.Mutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{}
+}
+
+func (storage *MemoryStorage) Save(ctx context.Context, dependencyId string, resourceId string) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.bindings = append(storage.bindings, Binding{
+		DependencyId: dependencyId,
+		ResourceId:   resourceId,
+	})
+
+	return nil
+}
+
+func (storage *MemoryStorage) ByResources(ctx context.Context, resourceIds ...string) ([]Binding, error) {
+	ids := make(map[string]struct{}, len(resourceIds))
+	for _, id := range resourceIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.ResourceId]; ok {
+			result = append(result, binding)
+		}
+	}
+
+	return result, nil
+}
+
+func (storage *MemoryStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]struct{}, len(dependencyIds))
+	for _, id := range dependencyIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	pending := storage.bindings[:0]
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.DependencyId]; ok {
+			continue
+		}
+		pending = append(pending, binding)
+	}
+	storage.bindings = pending
+
+	return nil
+}
+
+func (storage *MemoryStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	// resolved bindings are not kept in memory, so deleting is the same as resolving
+	return storage.Resolve(ctx, dependencyIds...)
+}
+
+func (storage *MemoryStorage) Poll(ctx context.Context, limit int) ([]Binding, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		result = append(result, binding)
+	}
+
+	return result, nil
+}
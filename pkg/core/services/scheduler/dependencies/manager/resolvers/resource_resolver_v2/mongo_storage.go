@@ -0,0 +1,112 @@
+package resourceResolverV2
+
+import (
+	"context"
+	"fmt"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// MongoStorage keeps bindings in the same format as the polling resolver's storage
+type MongoStorage struct {
+	Collection  *mongo.Collection
+	PollTimeout time.Duration
+}
+
+func (storage *MongoStorage) Save(ctx context.Context, dependencyId string, resourceId string) error {
+	_, err := storage.Collection.InsertOne(ctx, bson.M{
+		"dependency_id": dependencyId,
+		"resource_id":   resourceId,
+		"status":        "pending",
+		"updated_at":    time.Now().Unix(),
+	})
+	return err
+}
+
+func (storage *MongoStorage) ByResources(ctx context.Context, resourceIds ...string) ([]Binding, error) {
+	if len(resourceIds) == 0 {
+		return nil, nil
+	}
+
+	return storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"resource_id": bson.M{
+				"$in": resourceIds,
+			},
+		},
+		options.Find())
+}
+
+func (storage *MongoStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.UpdateMany(ctx,
+		bson.M{
+			"status": "pending",
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"status":     "resolved",
+				"updated_at": time.Now().Unix(),
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.DeleteMany(ctx,
+		bson.M{
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Poll(ctx context.Context, limit int) ([]Binding, error) {
+	updatedAtLowerBoundary := time.Now().Add(-storage.PollTimeout).Unix()
+
+	return storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"updated_at": bson.M{
+				"$lte": updatedAtLowerBoundary,
+			},
+		},
+		options.Find().SetLimit(int64(limit)))
+}
+
+func (storage *MongoStorage) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]Binding, error) {
+	cursor, err := storage.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending bindings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rawBindings []bson.M
+	if err := cursor.All(ctx, &rawBindings); err != nil {
+		return nil, fmt.Errorf("failed to decode bindings: %w", err)
+	}
+
+	bindings := lo.Map(rawBindings, func(rawBinding bson.M, _ int) Binding {
+		return Binding{
+			DependencyId: rawBinding["dependency_id"].(string),
+			ResourceId:   rawBinding["resource_id"].(string),
+		}
+	})
+
+	return bindings, nil
+}
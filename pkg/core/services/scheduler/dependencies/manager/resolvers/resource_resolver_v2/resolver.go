@@ -3,15 +3,27 @@ package resourceResolverV2
 import (
 	"context"
 	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/samber/lo"
 	"log/slog"
 	"time"
 )
 
+const ConsumerGroup = "resource_resolver"
+
+// Resolver resolves dependencies as soon as their resources are initialized.
+//
+// It consumes ids of initialized resources published to ReadyResourcesTopic (see resources.Notifier),
+// bindings are also polled with a low frequency in case some events are missed.
+// Dependencies of resources that expire before they are initialized fail.
 type Resolver struct {
 	System              core.AbstractSystem
+	Storage             Storage
 	ReadyResourcesTopic string
+	PollLimit           int
+	PollInterval        time.Duration
 	Logger              *slog.Logger
 }
 
@@ -25,19 +37,111 @@ func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) erro
 		return fmt.Errorf("failed to bind resource_db and dependency: %w", err)
 	}
 
+	resources, err := core.LoadStatuses(ctx, resolver.System.Resources(), resourceId)
+	if err != nil {
+		return fmt.Errorf("failed to load the resource: %w", err)
+	}
+
+	// the resource has been initialized before the binding, so its event is sent again to resolve the binding right away
+	finished := lo.SomeBy(resources, func(res core.Resource) bool {
+		return res.Status == core.ResourceStatuses.Ready || res.Status == core.ResourceStatuses.Expired
+	})
+	if finished {
+		if err := resolver.System.Events().Send(ctx, core.NewEvent(resolver.ReadyResourcesTopic, []byte(resourceId))); err != nil {
+			return fmt.Errorf("failed to send a ready resource event: %w", err)
+		}
+	}
+
 	return nil
 }
 
+func (resolver *Resolver) DeleteBindings(ctx context.Context, dependencyIds ...string) error {
+	if err := resolver.Storage.Delete(ctx, dependencyIds...); err != nil {
+		return fmt.Errorf("failed to delete bindings: %w", err)
+	}
+
+	return nil
+}
+
+// Ready returns only dependencies of initialized resources, failed ones are reported by Resolutions
 func (resolver *Resolver) Ready(ctx context.Context) (<-chan string, error) {
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	depIds := make(chan string, 1024)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case dep := <-resolutions:
+				if dep.Status != dependency.OK {
+					continue
+				}
 
-	go resolver.collectResolvedDependencies(ctx, depIds)
+				select {
+				case <-ctx.Done():
+					return
+				case depIds <- dep.ID:
+				}
+			}
+		}
+	}()
 
 	return depIds, nil
 }
 
-func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, ids chan<- string) {
+func (resolver *Resolver) Resolutions(ctx context.Context) (<-chan dependency.Dependency, error) {
+	events, err := resolver.System.Events().Consume(ctx,
+		[]string{resolver.ReadyResourcesTopic},
+		broker.ConsumerSettings{
+			Group:                ConsumerGroup,
+			InitializationPolicy: broker.NewestOffset,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume ready resources: %w", err)
+	}
+
+	deps := make(chan dependency.Dependency, 1024)
+
+	go broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			return resolver.handle(ctx, deps, string(ev.Data))
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			resolver.Logger.Error("failed to handle a ready resource",
+				slog.String("resource_id", string(ev.Data)),
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, events)
 
+	go resolver.poll(ctx, deps)
+
+	return deps, nil
+}
+
+// handle resolves dependencies bound to the initialized (or expired) resource
+func (resolver *Resolver) handle(ctx context.Context, deps chan<- dependency.Dependency, resourceId string) error {
+	bindings, err := resolver.Storage.ByResources(ctx, resourceId)
+	if err != nil {
+		return fmt.Errorf("failed to load bindings: %w", err)
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	resources, err := core.LoadStatuses(ctx, resolver.System.Resources(), resourceId)
+	if err != nil {
+		return fmt.Errorf("failed to load the resource: %w", err)
+	}
+
+	return resolver.resolve(ctx, deps, bindings, statuses(resources))
+}
+
+// poll periodically resolves dependencies of ready resources whose events have been missed
+func (resolver *Resolver) poll(ctx context.Context, deps chan<- dependency.Dependency) {
 	pollLimit := resolver.PollLimit
 	if pollLimit <= 0 {
 		pollLimit = 1024
@@ -45,19 +149,19 @@ func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, ids c
 
 	pollInterval := resolver.PollInterval
 	if pollInterval <= 0 {
-		pollInterval = time.Second * 5
+		pollInterval = time.Minute
 	}
 
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 	resolver.Logger.Info("collecting resolved dependencies",
+		slog.String("topic", resolver.ReadyResourcesTopic),
 		slog.Duration("interval", pollInterval))
 
-poller:
 	for {
 		select {
 		case <-ctx.Done():
-			break poller
+			return
 
 		case <-ticker.C:
 			bindings, err := resolver.Storage.Poll(ctx, pollLimit)
@@ -67,38 +171,64 @@ poller:
 				continue
 			}
 
-			resource2dependencies := lo.GroupBy(bindings, func(binding Binding) string {
+			resourceIds := lo.Uniq(lo.Map(bindings, func(binding Binding, _ int) string {
 				return binding.ResourceId
-			})
-
-			resourceIds := lo.Keys(resource2dependencies)
-			resources, err := resolver.System.Resources().Load(ctx, resourceIds...)
+			}))
+			resources, err := core.LoadStatuses(ctx, resolver.System.Resources(), resourceIds...)
 			if err != nil {
 				resolver.Logger.Error("failed to load resources",
 					slog.String("error", err.Error()))
+				continue
 			}
 
-			var resolvedIds []string
-
-			for _, res := range resources {
-				if res.Status != core.Ready {
-					continue
-				}
-
-				resourceBindings := resource2dependencies[res.ID]
-				for _, binding := range resourceBindings {
-					select {
-					case <-ctx.Done():
-						break poller
-					case ids <- binding.DependencyId:
-						resolvedIds = append(resolvedIds, binding.DependencyId)
-					}
-				}
-			}
-			if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
-				resolver.Logger.Error("failed to resolve resources",
+			err = resolver.resolve(ctx, deps, bindings, statuses(resources))
+			if err != nil {
+				resolver.Logger.Error("failed to resolve dependencies",
 					slog.String("error", err.Error()))
 			}
 		}
 	}
 }
+
+// resolve resolves bindings of resources with known statuses, the rest are left pending
+func (resolver *Resolver) resolve(ctx context.Context, deps chan<- dependency.Dependency, bindings []Binding, resourceStatuses map[string]dependency.Status) error {
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	resolvedIds := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		status, ok := resourceStatuses[binding.ResourceId]
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case deps <- dependency.Dependency{ID: binding.DependencyId, Status: status}:
+			resolvedIds = append(resolvedIds, binding.DependencyId)
+		}
+	}
+
+	if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
+		return fmt.Errorf("failed to resolve bindings: %w", err)
+	}
+
+	return nil
+}
+
+// statuses maps ids of ready resources to OK and ids of expired ones (they will never be ready) to Failed
+func statuses(resources []core.Resource) map[string]dependency.Status {
+	result := map[string]dependency.Status{}
+	for _, res := range resources {
+		switch res.Status {
+		case core.ResourceStatuses.Ready:
+			result[res.ID] = dependency.OK
+		case core.ResourceStatuses.Expired:
+			result[res.ID] = dependency.Failed
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,173 @@
+package resourceResolverV2
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/lib/resources"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestResolveOnEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	events := eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]())
+	sys := core.NewSystem(
+		events,
+		resources.Observe(resourcedb.NewMockDB(), resources.Notifier{
+			Broker: events,
+			Topic:  "resources.ready",
+			Logger: logger,
+		}),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:              sys,
+		Storage:             NewMemoryStorage(),
+		ReadyResourcesTopic: "resources.ready",
+		// the safety net must not be the one resolving the dependency
+		PollInterval: time.Hour,
+		Logger:       logger,
+	}
+
+	ids, err := sys.Resources().Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate resources: %s", err)
+	}
+
+	for index, id := range ids {
+		if err := resolver.Bind(ctx, "dep-"+id, id); err != nil {
+			t.Fatalf("failed to bind dependency %d: %s", index, err)
+		}
+	}
+
+	ready, err := resolver.Ready(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	if err := sys.Resources().Init(ctx, []core.Resource{{ID: ids[0], Data: []byte("42")}}); err != nil {
+		t.Fatalf("failed to initialize a resource: %s", err)
+	}
+
+	select {
+	case depId := <-ready:
+		if depId != "dep-"+ids[0] {
+			t.Fatalf("unexpected dependency: %s", depId)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the dependency has not been resolved")
+	}
+
+	pending, err := resolver.Storage.ByResources(ctx, ids...)
+	if err != nil {
+		t.Fatalf("failed to load bindings: %s", err)
+	}
+	if len(pending) != 1 || pending[0].ResourceId != ids[1] {
+		t.Fatalf("unexpected pending bindings: %v", pending)
+	}
+}
+
+func TestBindInitializedResource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:              sys,
+		Storage:             NewMemoryStorage(),
+		ReadyResourcesTopic: "resources.ready",
+		PollInterval:        time.Hour,
+		Logger:              logger,
+	}
+
+	ready, err := resolver.Ready(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	ids, err := sys.Resources().Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate resources: %s", err)
+	}
+
+	if err := sys.Resources().Init(ctx, []core.Resource{{ID: ids[0], Data: []byte("42")}}); err != nil {
+		t.Fatalf("failed to initialize a resource: %s", err)
+	}
+
+	if err := resolver.Bind(ctx, "late", ids[0]); err != nil {
+		t.Fatalf("failed to bind a dependency: %s", err)
+	}
+
+	select {
+	case depId := <-ready:
+		if depId != "late" {
+			t.Fatalf("unexpected dependency: %s", depId)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the dependency has not been resolved")
+	}
+}
+
+func TestExpiredResourceFails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:              sys,
+		Storage:             NewMemoryStorage(),
+		ReadyResourcesTopic: "resources.ready",
+		PollInterval:        time.Hour,
+		Logger:              logger,
+	}
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	ids, err := core.AllocWithTTL(ctx, sys.Resources(), 1, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to allocate resources: %s", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if err := resolver.Bind(ctx, "expired", ids[0]); err != nil {
+		t.Fatalf("failed to bind a dependency: %s", err)
+	}
+
+	select {
+	case dep := <-resolutions:
+		if dep.ID != "expired" || dep.Status != dependency.Failed {
+			t.Fatalf("unexpected dependency: %+v", dep)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the dependency has not been resolved")
+	}
+}
@@ -9,6 +9,10 @@ type Binding struct {
 
 type Storage interface {
 	Save(ctx context.Context, dependencyId string, resourceId string) error
-	PrepareForResolution(ctx context.Context, resourceIds ...string) error
-	Resolve(ctx context.Context, resourceIds ...string) error
+	// ByResources returns unresolved bindings of the resources
+	ByResources(ctx context.Context, resourceIds ...string) ([]Binding, error)
+	Resolve(ctx context.Context, dependencyIds ...string) error
+	// Delete deletes bindings of the dependencies whatever their status is
+	Delete(ctx context.Context, dependencyIds ...string) error
+	Poll(ctx context.Context, limit int) ([]Binding, error)
 }
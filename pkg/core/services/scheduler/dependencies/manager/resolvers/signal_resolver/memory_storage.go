@@ -0,0 +1,120 @@
+package signalResolver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryStorage struct {
+	bindings []Binding
+	signals  map[string]Signal
+	mu       sync.Mutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{signals: map[string]Signal{}}
+}
+
+func (storage *MemoryStorage) Save(ctx context.Context, binding Binding) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.bindings = append(storage.bindings, binding)
+
+	return nil
+}
+
+func (storage *MemoryStorage) ByNames(ctx context.Context, names ...string) ([]Binding, error) {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if _, ok := set[binding.Name]; ok {
+			result = append(result, binding)
+		}
+	}
+
+	return result, nil
+}
+
+func (storage *MemoryStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]struct{}, len(dependencyIds))
+	for _, id := range dependencyIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	pending := storage.bindings[:0]
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.DependencyId]; ok {
+			continue
+		}
+		pending = append(pending, binding)
+	}
+	storage.bindings = pending
+
+	return nil
+}
+
+func (storage *MemoryStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	// resolved bindings are not kept in memory, so deleting is the same as resolving
+	return storage.Resolve(ctx, dependencyIds...)
+}
+
+func (storage *MemoryStorage) Expired(ctx context.Context, until time.Time, limit int) ([]Binding, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if !binding.Expired(until) {
+			continue
+		}
+		result = append(result, binding)
+	}
+
+	return result, nil
+}
+
+func (storage *MemoryStorage) SaveSignal(ctx context.Context, signal Signal) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	// a redelivered signal must not replace a newer one
+	if saved, ok := storage.signals[signal.Name]; ok && saved.ExpiresAt > signal.ExpiresAt {
+		return nil
+	}
+	storage.signals[signal.Name] = signal
+
+	return nil
+}
+
+func (storage *MemoryStorage) Signals(ctx context.Context, names ...string) ([]Signal, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Signal
+	for _, name := range names {
+		if signal, ok := storage.signals[name]; ok {
+			result = append(result, signal)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package signalResolver
+
+import (
+	"context"
+	"fmt"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type MongoStorage struct {
+	Collection *mongo.Collection
+	// SignalCollection stores the latest signal of every name
+	SignalCollection *mongo.Collection
+}
+
+func (storage *MongoStorage) Save(ctx context.Context, binding Binding) error {
+	var expiresAt int64
+	if !binding.ExpiresAt.IsZero() {
+		expiresAt = binding.ExpiresAt.UnixMilli()
+	}
+
+	_, err := storage.Collection.InsertOne(ctx, bson.M{
+		"dependency_id": binding.DependencyId,
+		"name":          binding.Name,
+		"output":        binding.Output,
+		"expires_at":    expiresAt,
+		"status":        "pending",
+		"updated_at":    time.Now().Unix(),
+	})
+	return err
+}
+
+func (storage *MongoStorage) ByNames(ctx context.Context, names ...string) ([]Binding, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	return storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"name": bson.M{
+				"$in": names,
+			},
+		},
+		options.Find())
+}
+
+func (storage *MongoStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.UpdateMany(ctx,
+		bson.M{
+			"status": "pending",
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"status":     "resolved",
+				"updated_at": time.Now().Unix(),
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.DeleteMany(ctx,
+		bson.M{
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Expired(ctx context.Context, until time.Time, limit int) ([]Binding, error) {
+	return storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"expires_at": bson.M{
+				"$gt":  0,
+				"$lte": until.UnixMilli(),
+			},
+		},
+		options.Find().SetLimit(int64(limit)))
+}
+
+func (storage *MongoStorage) SaveSignal(ctx context.Context, signal Signal) error {
+	// a redelivered signal must not replace a newer one
+	_, err := storage.SignalCollection.UpdateOne(ctx,
+		bson.M{
+			"_id": signal.Name,
+			"expires_at": bson.M{
+				"$lte": signal.ExpiresAt,
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"resource":   signal.Resource,
+				"expires_at": signal.ExpiresAt,
+			},
+		},
+		options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("failed to save the signal: %w", err)
+	}
+
+	return nil
+}
+
+func (storage *MongoStorage) Signals(ctx context.Context, names ...string) ([]Signal, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := storage.SignalCollection.Find(ctx, bson.M{
+		"_id": bson.M{
+			"$in": names,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rawSignals []bson.M
+	if err := cursor.All(ctx, &rawSignals); err != nil {
+		return nil, fmt.Errorf("failed to decode signals: %w", err)
+	}
+
+	signals := lo.Map(rawSignals, func(rawSignal bson.M, _ int) Signal {
+		name, _ := rawSignal["_id"].(string)
+		resource, _ := rawSignal["resource"].(string)
+		expiresAt, _ := rawSignal["expires_at"].(int64)
+		return Signal{
+			Name:      name,
+			Resource:  resource,
+			ExpiresAt: expiresAt,
+		}
+	})
+
+	return signals, nil
+}
+
+func (storage *MongoStorage) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]Binding, error) {
+	cursor, err := storage.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending bindings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rawBindings []bson.M
+	if err := cursor.All(ctx, &rawBindings); err != nil {
+		return nil, fmt.Errorf("failed to decode bindings: %w", err)
+	}
+
+	bindings := lo.Map(rawBindings, func(rawBinding bson.M, _ int) Binding {
+		output, _ := rawBinding["output"].(string)
+		binding := Binding{
+			DependencyId: rawBinding["dependency_id"].(string),
+			Name:         rawBinding["name"].(string),
+			Output:       output,
+		}
+		if expiresAt, _ := rawBinding["expires_at"].(int64); expiresAt > 0 {
+			binding.ExpiresAt = time.UnixMilli(expiresAt)
+		}
+		return binding
+	})
+
+	return bindings, nil
+}
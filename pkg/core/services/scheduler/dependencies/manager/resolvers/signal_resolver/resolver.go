@@ -0,0 +1,274 @@
+package signalResolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
+	"log/slog"
+	"time"
+)
+
+const ConsumerGroup = "signal_resolver"
+
+// Resolver resolves dependencies on external signals (see Send and taskopts.SignalDependency).
+//
+// A signal resolves every dependency waiting for it, its payload is copied to the outputs of the bindings.
+// Signals are kept until they expire (see Retention), so dependencies bound after a signal are resolved by it too.
+// Dependencies that are not signalled before their expiration fail.
+type Resolver struct {
+	System       core.AbstractSystem
+	Storage      Storage
+	PollLimit    int
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+type bindingData struct {
+	Name   string `mapstructure:"name"`
+	Output string `mapstructure:"output"`
+	// ExpiresAt is a unix timestamp in milliseconds
+	ExpiresAt int64 `mapstructure:"expires_at"`
+}
+
+func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) error {
+	var decoded bindingData
+	if err := mapstructure.Decode(data, &decoded); err != nil {
+		return fmt.Errorf("unexpected data: %w", err)
+	}
+	if decoded.Name == "" {
+		return fmt.Errorf("unexpected data: no name in %v", data)
+	}
+
+	binding := Binding{
+		DependencyId: depId,
+		Name:         decoded.Name,
+		Output:       decoded.Output,
+	}
+	if decoded.ExpiresAt > 0 {
+		binding.ExpiresAt = time.UnixMilli(decoded.ExpiresAt)
+	}
+
+	if err := resolver.Storage.Save(ctx, binding); err != nil {
+		return fmt.Errorf("failed to bind a signal and a dependency: %w", err)
+	}
+
+	signals, err := resolver.Storage.Signals(ctx, binding.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load signals: %w", err)
+	}
+
+	// the signal has been sent before the binding, so it's sent again to resolve the binding right away
+	for _, signal := range signals {
+		if signal.Expired(time.Now()) {
+			continue
+		}
+
+		data, err := json.Marshal(signal)
+		if err != nil {
+			return fmt.Errorf("failed to encode the signal: %w", err)
+		}
+
+		if err := resolver.System.Events().Send(ctx, core.NewEvent(Topic, data)); err != nil {
+			return fmt.Errorf("failed to send the signal: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (resolver *Resolver) DeleteBindings(ctx context.Context, dependencyIds ...string) error {
+	if err := resolver.Storage.Delete(ctx, dependencyIds...); err != nil {
+		return fmt.Errorf("failed to delete bindings: %w", err)
+	}
+
+	return nil
+}
+
+// Ready returns only signalled dependencies, expired ones are reported by Resolutions
+func (resolver *Resolver) Ready(ctx context.Context) (<-chan string, error) {
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	depIds := make(chan string, 1024)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case dep := <-resolutions:
+				if dep.Status != dependency.OK {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case depIds <- dep.ID:
+				}
+			}
+		}
+	}()
+
+	return depIds, nil
+}
+
+func (resolver *Resolver) Resolutions(ctx context.Context) (<-chan dependency.Dependency, error) {
+	events, err := resolver.System.Events().Consume(ctx,
+		[]string{Topic},
+		broker.ConsumerSettings{
+			Group:                ConsumerGroup,
+			InitializationPolicy: broker.OldestOffset,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume signals: %w", err)
+	}
+
+	deps := make(chan dependency.Dependency, 1024)
+
+	go broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			return resolver.handle(ctx, deps, ev)
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			resolver.Logger.Error("failed to handle a signal",
+				slog.String("event_id", ev.ID),
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, events)
+
+	go resolver.expire(ctx, deps)
+
+	return deps, nil
+}
+
+func (resolver *Resolver) handle(ctx context.Context, deps chan<- dependency.Dependency, ev core.Event) error {
+	var signal Signal
+	if err := json.Unmarshal(ev.Data, &signal); err != nil {
+		return fmt.Errorf("failed to decode the signal: %w", err)
+	}
+
+	if signal.Expired(time.Now()) {
+		resolver.Logger.Debug("skipping an expired signal",
+			slog.String("name", signal.Name))
+		return nil
+	}
+
+	if err := resolver.Storage.SaveSignal(ctx, signal); err != nil {
+		return fmt.Errorf("failed to save the signal: %w", err)
+	}
+
+	bindings, err := resolver.Storage.ByNames(ctx, signal.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load bindings: %w", err)
+	}
+
+	// expired bindings are left for the poller
+	now := time.Now()
+	bindings = lo.Filter(bindings, func(binding Binding, _ int) bool {
+		return !binding.Expired(now)
+	})
+
+	if err := resolver.deliver(ctx, signal, bindings); err != nil {
+		return err
+	}
+
+	return resolver.resolve(ctx, deps, bindings, dependency.OK)
+}
+
+// deliver copies the payload of the signal to the outputs of the bindings
+func (resolver *Resolver) deliver(ctx context.Context, signal Signal, bindings []Binding) error {
+	outputs := lo.Uniq(lo.FilterMap(bindings, func(binding Binding, _ int) (string, bool) {
+		return binding.Output, binding.Output != ""
+	}))
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	resources, err := resolver.System.Resources().Load(ctx, append([]string{signal.Resource}, outputs...)...)
+	if err != nil {
+		return fmt.Errorf("failed to load resources: %w", err)
+	}
+
+	payload := resources[0]
+	if payload.Status != core.ResourceStatuses.Ready {
+		return fmt.Errorf("the payload is not ready (status='%s')", payload.Status)
+	}
+
+	// outputs might have been initialized by a previous delivery of the signal
+	initialized := lo.FilterMap(resources[1:], func(res core.Resource, _ int) (core.Resource, bool) {
+		return core.Resource{ID: res.ID, Data: payload.Data}, res.Status == core.ResourceStatuses.Allocated
+	})
+
+	if err := resolver.System.Resources().Init(ctx, initialized); err != nil {
+		return fmt.Errorf("failed to initialize outputs: %w", err)
+	}
+
+	return nil
+}
+
+// expire periodically fails dependencies that have not been signalled in time
+func (resolver *Resolver) expire(ctx context.Context, deps chan<- dependency.Dependency) {
+	pollLimit := resolver.PollLimit
+	if pollLimit <= 0 {
+		pollLimit = 1024
+	}
+
+	pollInterval := resolver.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	resolver.Logger.Info("collecting expired dependencies",
+		slog.Duration("interval", pollInterval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			bindings, err := resolver.Storage.Expired(ctx, time.Now(), pollLimit)
+			if err != nil {
+				resolver.Logger.Error("failed to poll bindings",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			if err := resolver.resolve(ctx, deps, bindings, dependency.Failed); err != nil {
+				resolver.Logger.Error("failed to fail expired dependencies",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func (resolver *Resolver) resolve(ctx context.Context, deps chan<- dependency.Dependency, bindings []Binding, status dependency.Status) error {
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	resolvedIds := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case deps <- dependency.Dependency{ID: binding.DependencyId, Status: status}:
+			resolvedIds = append(resolvedIds, binding.DependencyId)
+		}
+	}
+
+	if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
+		return fmt.Errorf("failed to resolve bindings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package signalResolver
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:       sys,
+		Storage:      NewMemoryStorage(),
+		PollInterval: 50 * time.Millisecond,
+		Logger:       logger,
+	}
+
+	bind := func(depId string, dep taskopts.Dependency) {
+		if err := resolver.Bind(ctx, depId, dep.Data); err != nil {
+			t.Fatalf("failed to bind: %s", err)
+		}
+	}
+
+	outputs, err := sys.Resources().Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate a resource: %s", err)
+	}
+
+	bind("approved", taskopts.SignalPayload(taskopts.SignalDependency("approval"), outputs[0]))
+	bind("expiring", taskopts.ExpiringSignalDependency("approval", time.Now().Add(time.Hour)))
+	bind("expired", taskopts.ExpiringSignalDependency("rejection", time.Now().Add(100*time.Millisecond)))
+	bind("waiting", taskopts.SignalDependency("other"))
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	if _, err := Send(ctx, sys, "approval", []byte("ok")); err != nil {
+		t.Fatalf("failed to send a signal: %s", err)
+	}
+
+	expected := map[string]dependency.Status{
+		"approved": dependency.OK,
+		"expiring": dependency.OK,
+		"expired":  dependency.Failed,
+	}
+
+	deadline := time.After(5 * time.Second)
+	for len(expected) > 0 {
+		select {
+		case dep := <-resolutions:
+			status, ok := expected[dep.ID]
+			if !ok {
+				t.Fatalf("unexpected dependency: %s", dep.ID)
+			}
+			if status != dep.Status {
+				t.Fatalf("unexpected status of '%s': %s", dep.ID, dep.Status)
+			}
+			delete(expected, dep.ID)
+		case <-deadline:
+			t.Fatalf("dependencies have not been resolved: %v", expected)
+		}
+	}
+
+	resources, err := sys.Resources().Load(ctx, outputs[0])
+	if err != nil {
+		t.Fatalf("failed to load a resource: %s", err)
+	}
+	if resources[0].Status != core.ResourceStatuses.Ready || string(resources[0].Data) != "ok" {
+		t.Fatalf("the payload has not been delivered: %+v", resources[0])
+	}
+}
+
+func TestBindingAfterSignal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	storage := NewMemoryStorage()
+	resolver := &Resolver{
+		System:  sys,
+		Storage: storage,
+		Logger:  logger,
+	}
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	if _, err := Send(ctx, sys, "approval", []byte("ok")); err != nil {
+		t.Fatalf("failed to send a signal: %s", err)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for {
+		signals, err := storage.Signals(ctx, "approval")
+		if err != nil {
+			t.Fatalf("failed to load signals: %s", err)
+		}
+		if len(signals) > 0 {
+			break
+		}
+
+		select {
+		case <-deadline:
+			t.Fatal("the signal has not been saved")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	outputs, err := sys.Resources().Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate a resource: %s", err)
+	}
+
+	dep := taskopts.SignalPayload(taskopts.SignalDependency("approval"), outputs[0])
+	if err := resolver.Bind(ctx, "late", dep.Data); err != nil {
+		t.Fatalf("failed to bind: %s", err)
+	}
+
+	select {
+	case dep := <-resolutions:
+		if dep.ID != "late" || dep.Status != dependency.OK {
+			t.Fatalf("unexpected dependency: %+v", dep)
+		}
+	case <-deadline:
+		t.Fatal("the dependency has not been resolved")
+	}
+
+	resources, err := sys.Resources().Load(ctx, outputs[0])
+	if err != nil {
+		t.Fatalf("failed to load a resource: %s", err)
+	}
+	if resources[0].Status != core.ResourceStatuses.Ready || string(resources[0].Data) != "ok" {
+		t.Fatalf("the payload has not been delivered: %+v", resources[0])
+	}
+}
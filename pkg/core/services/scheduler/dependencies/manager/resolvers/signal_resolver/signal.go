@@ -0,0 +1,59 @@
+package signalResolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"time"
+)
+
+// Topic is the topic signals are sent to
+const Topic = "signals"
+
+// Retention is how long a signal (and its payload) is kept to resolve dependencies bound after it's sent
+const Retention = 24 * time.Hour
+
+type Signal struct {
+	Name string `json:"name"`
+	// Resource stores the payload of the signal
+	Resource string `json:"resource"`
+	// ExpiresAt is a unix timestamp in milliseconds (the signal never expires if it's zero)
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+func (signal Signal) Expired(now time.Time) bool {
+	return signal.ExpiresAt > 0 && signal.ExpiresAt <= now.UnixMilli()
+}
+
+// Send stores the payload as a resource and sends a signal with it, the id of the resource is returned.
+// The payload expires along with the signal (see Retention).
+func Send(ctx context.Context, system core.AbstractSystem, name string, payload []byte) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty signal name")
+	}
+
+	ids, err := system.Resources().Alloc(ctx, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate a resource: %w", err)
+	}
+
+	if err := system.Resources().Init(ctx, []core.Resource{{ID: ids[0], Data: payload, TTL: Retention}}); err != nil {
+		return "", fmt.Errorf("failed to initialize a resource: %w", err)
+	}
+
+	data, err := json.Marshal(Signal{
+		Name:      name,
+		Resource:  ids[0],
+		ExpiresAt: time.Now().Add(Retention).UnixMilli(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the signal: %w", err)
+	}
+
+	if err := system.Events().Send(ctx, core.NewEvent(Topic, data)); err != nil {
+		return "", fmt.Errorf("failed to send the signal: %w", err)
+	}
+
+	return ids[0], nil
+}
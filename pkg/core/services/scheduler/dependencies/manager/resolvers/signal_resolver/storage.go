@@ -0,0 +1,34 @@
+package signalResolver
+
+import (
+	"context"
+	"time"
+)
+
+type Binding struct {
+	DependencyId string
+	Name         string
+	// Output is a resource the payload of the signal is copied to (optional)
+	Output string
+	// ExpiresAt is the moment the dependency fails if no signal is received (never if zero)
+	ExpiresAt time.Time
+}
+
+func (binding Binding) Expired(now time.Time) bool {
+	return !binding.ExpiresAt.IsZero() && !binding.ExpiresAt.After(now)
+}
+
+type Storage interface {
+	Save(ctx context.Context, binding Binding) error
+	// ByNames returns unresolved bindings waiting for the signals
+	ByNames(ctx context.Context, names ...string) ([]Binding, error)
+	Resolve(ctx context.Context, dependencyIds ...string) error
+	// Delete deletes bindings of the dependencies whatever their status is
+	Delete(ctx context.Context, dependencyIds ...string) error
+	// Expired returns unresolved bindings that expire before the moment
+	Expired(ctx context.Context, until time.Time, limit int) ([]Binding, error)
+	// SaveSignal remembers the signal, only the latest signal of a name is kept
+	SaveSignal(ctx context.Context, signal Signal) error
+	// Signals returns the latest signals of the names
+	Signals(ctx context.Context, names ...string) ([]Signal, error)
+}
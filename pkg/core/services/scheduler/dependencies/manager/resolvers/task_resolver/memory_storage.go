@@ -0,0 +1,90 @@
+package taskResolver
+
+import (
+	"context"
+	"sync"
+)
+
+type MemoryStorage struct {
+	bindings []Binding
+	mu       sync.Mutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{}
+}
+
+func (storage *MemoryStorage) Save(ctx context.Context, binding Binding) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.bindings = append(storage.bindings, binding)
+
+	return nil
+}
+
+func (storage *MemoryStorage) ByTasks(ctx context.Context, taskIds ...string) ([]Binding, error) {
+	ids := make(map[string]struct{}, len(taskIds))
+	for _, id := range taskIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.TaskId]; ok {
+			result = append(result, binding)
+		}
+	}
+
+	return result, nil
+}
+
+func (storage *MemoryStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]struct{}, len(dependencyIds))
+	for _, id := range dependencyIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	pending := storage.bindings[:0]
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.DependencyId]; ok {
+			continue
+		}
+		pending = append(pending, binding)
+	}
+	storage.bindings = pending
+
+	return nil
+}
+
+func (storage *MemoryStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	// resolved bindings are not kept in memory, so deleting is the same as resolving
+	return storage.Resolve(ctx, dependencyIds...)
+}
+
+func (storage *MemoryStorage) Poll(ctx context.Context, limit int) ([]Binding, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	if limit <= 0 || limit > len(storage.bindings) {
+		limit = len(storage.bindings)
+	}
+
+	result := make([]Binding, limit)
+	copy(result, storage.bindings)
+
+	// polled bindings are moved to the end, so the next poll returns the others
+	storage.bindings = append(storage.bindings[limit:], result...)
+
+	return result, nil
+}
@@ -0,0 +1,143 @@
+package taskResolver
+
+import (
+	"context"
+	"fmt"
+	"github.com/samber/lo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type MongoStorage struct {
+	Collection *mongo.Collection
+	// PollTimeout is the minimal age of polled bindings (fresh ones are expected to be resolved by events)
+	PollTimeout time.Duration
+}
+
+func (storage *MongoStorage) Save(ctx context.Context, binding Binding) error {
+	_, err := storage.Collection.InsertOne(ctx, bson.M{
+		"dependency_id": binding.DependencyId,
+		"task_id":       binding.TaskId,
+		"sub_status":    binding.SubStatus,
+		"status":        "pending",
+		"updated_at":    time.Now().Unix(),
+	})
+	return err
+}
+
+func (storage *MongoStorage) ByTasks(ctx context.Context, taskIds ...string) ([]Binding, error) {
+	if len(taskIds) == 0 {
+		return nil, nil
+	}
+
+	return storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"task_id": bson.M{
+				"$in": taskIds,
+			},
+		},
+		options.Find())
+}
+
+func (storage *MongoStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.UpdateMany(ctx,
+		bson.M{
+			"status": "pending",
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"status":     "resolved",
+				"updated_at": time.Now().Unix(),
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.DeleteMany(ctx,
+		bson.M{
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Poll(ctx context.Context, limit int) ([]Binding, error) {
+	now := time.Now()
+	updatedAtLowerBoundary := now.Add(-storage.PollTimeout).Unix()
+
+	bindings, err := storage.find(ctx,
+		bson.M{
+			"status": "pending",
+			"updated_at": bson.M{
+				"$lte": updatedAtLowerBoundary,
+			},
+		},
+		options.Find().
+			SetSort(bson.M{"updated_at": 1}).
+			SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+
+	// polled bindings are touched, so the next poll returns the others
+	_, err = storage.Collection.UpdateMany(ctx,
+		bson.M{
+			"status": "pending",
+			"dependency_id": bson.M{
+				"$in": lo.Map(bindings, func(binding Binding, _ int) string { return binding.DependencyId }),
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"updated_at": now.Unix(),
+			},
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to touch polled bindings: %w", err)
+	}
+
+	return bindings, nil
+}
+
+func (storage *MongoStorage) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]Binding, error) {
+	cursor, err := storage.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending bindings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rawBindings []bson.M
+	if err := cursor.All(ctx, &rawBindings); err != nil {
+		return nil, fmt.Errorf("failed to decode bindings: %w", err)
+	}
+
+	bindings := lo.Map(rawBindings, func(rawBinding bson.M, _ int) Binding {
+		subStatus, _ := rawBinding["sub_status"].(string)
+		return Binding{
+			DependencyId: rawBinding["dependency_id"].(string),
+			TaskId:       rawBinding["task_id"].(string),
+			SubStatus:    subStatus,
+		}
+	})
+
+	return bindings, nil
+}
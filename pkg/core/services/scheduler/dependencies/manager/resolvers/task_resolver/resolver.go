@@ -0,0 +1,376 @@
+package taskResolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
+	"log/slog"
+	"time"
+)
+
+const ConsumerGroup = "task_resolver"
+
+// FinishedTasksTopic receives ids of tasks that had finished before some of their bindings were saved
+const FinishedTasksTopic = "task_resolver:finished"
+
+// Resolver resolves dependencies on tasks when they finish (see taskopts.TaskDependency).
+//
+// A dependency requiring a sub status fails if the task finishes with another one.
+// Retried tasks are followed, so a dependency is resolved by the last attempt.
+// Dependencies are resolved on "finished" and "cancelled" events, tasks finished before the binding are
+// resolved right away by Bind. Bindings are also polled with a low frequency in case some events are missed.
+// Dependencies on tasks that don't exist fail.
+type Resolver struct {
+	System       core.AbstractSystem
+	Storage      Storage
+	ResultCodec  codec.Codec[executor.Result, []byte]
+	PollLimit    int
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+type bindingData struct {
+	TaskID    string `mapstructure:"task_id"`
+	SubStatus string `mapstructure:"sub_status"`
+}
+
+func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) error {
+	var decoded bindingData
+	if err := mapstructure.Decode(data, &decoded); err != nil {
+		return fmt.Errorf("unexpected data: %w", err)
+	}
+	if decoded.TaskID == "" {
+		return fmt.Errorf("unexpected data: no task_id in %v", data)
+	}
+
+	err := resolver.Storage.Save(ctx, Binding{
+		DependencyId: depId,
+		TaskId:       decoded.TaskID,
+		SubStatus:    decoded.SubStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind a task and a dependency: %w", err)
+	}
+
+	done := true
+	task, err := resolver.System.Task(ctx, decoded.TaskID)
+	switch {
+	case errors.Is(err, core.ErrTaskNotFound):
+		// the task will never finish, so the binding is failed by the resolver
+	case err != nil:
+		return fmt.Errorf("failed to load the task: %w", err)
+	default:
+		_, done, err = resolver.outcome(ctx, task)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the task has finished before the binding, so it's sent to the resolver again to resolve the binding right away
+	if done {
+		if err := resolver.System.Events().Send(ctx, core.NewEvent(FinishedTasksTopic, []byte(decoded.TaskID))); err != nil {
+			return fmt.Errorf("failed to send a finished task event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (resolver *Resolver) DeleteBindings(ctx context.Context, dependencyIds ...string) error {
+	if err := resolver.Storage.Delete(ctx, dependencyIds...); err != nil {
+		return fmt.Errorf("failed to delete bindings: %w", err)
+	}
+
+	return nil
+}
+
+// Ready returns only resolved dependencies, failed ones are reported by Resolutions
+func (resolver *Resolver) Ready(ctx context.Context) (<-chan string, error) {
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	depIds := make(chan string, 1024)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case dep := <-resolutions:
+				if dep.Status != dependency.OK {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case depIds <- dep.ID:
+				}
+			}
+		}
+	}()
+
+	return depIds, nil
+}
+
+func (resolver *Resolver) Resolutions(ctx context.Context) (<-chan dependency.Dependency, error) {
+	events, err := resolver.System.Events().Consume(ctx,
+		[]string{core.OnTask.Finished, core.OnTask.Cancelled, FinishedTasksTopic},
+		broker.ConsumerSettings{
+			Group:                ConsumerGroup,
+			InitializationPolicy: broker.NewestOffset,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume events: %w", err)
+	}
+
+	deps := make(chan dependency.Dependency, 1024)
+
+	go broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			return resolver.handle(ctx, deps, ev)
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			resolver.Logger.Error("failed to handle a finished task",
+				slog.String("event_id", ev.ID),
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, events)
+
+	go resolver.poll(ctx, deps)
+
+	return deps, nil
+}
+
+func (resolver *Resolver) handle(ctx context.Context, deps chan<- dependency.Dependency, ev core.Event) error {
+	if ev.Topic == FinishedTasksTopic {
+		return resolver.handleFinished(ctx, deps, string(ev.Data))
+	}
+
+	taskId, subStatus, errorCode := string(ev.Data), core.TaskSubStatuses.Cancelled, ""
+	if ev.Topic == core.OnTask.Finished {
+		result, err := resolver.ResultCodec.Decode(ev.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode the result: %w", err)
+		}
+		taskId, subStatus, errorCode = result.TaskID, string(result.Status), result.Code
+	}
+
+	// the status of the task might not be updated yet, so the sub status is taken from the event
+	task, err := resolver.System.Task(ctx, taskId)
+	if err != nil {
+		return fmt.Errorf("failed to load the task: %w", err)
+	}
+	if ev.Topic == core.OnTask.Finished && subStatus != core.TaskSubStatuses.OK && task.WillRetry(subStatus, errorCode) {
+		return nil
+	}
+
+	// dependencies might be bound to any of the previous attempts
+	taskIds, err := resolver.attempts(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	bindings, err := resolver.Storage.ByTasks(ctx, taskIds...)
+	if err != nil {
+		return fmt.Errorf("failed to load bindings: %w", err)
+	}
+
+	return resolver.resolve(ctx, deps, bindings, func(binding Binding) (dependency.Status, bool) {
+		return status(binding, subStatus), true
+	})
+}
+
+// handleFinished resolves bindings of a task whose outcome is already stored
+func (resolver *Resolver) handleFinished(ctx context.Context, deps chan<- dependency.Dependency, taskId string) error {
+	task, err := resolver.System.Task(ctx, taskId)
+	if errors.Is(err, core.ErrTaskNotFound) {
+		bindings, err := resolver.Storage.ByTasks(ctx, taskId)
+		if err != nil {
+			return fmt.Errorf("failed to load bindings: %w", err)
+		}
+
+		return resolver.resolve(ctx, deps, bindings, func(Binding) (dependency.Status, bool) { return dependency.Failed, true })
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load the task: %w", err)
+	}
+
+	subStatus, done, err := resolver.outcome(ctx, task)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return nil
+	}
+
+	bindings, err := resolver.Storage.ByTasks(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load bindings: %w", err)
+	}
+
+	return resolver.resolve(ctx, deps, bindings, func(binding Binding) (dependency.Status, bool) {
+		return status(binding, subStatus), true
+	})
+}
+
+// poll periodically resolves dependencies on finished tasks whose events have been missed
+func (resolver *Resolver) poll(ctx context.Context, deps chan<- dependency.Dependency) {
+	pollLimit := resolver.PollLimit
+	if pollLimit <= 0 {
+		pollLimit = 1024
+	}
+
+	pollInterval := resolver.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Minute
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	resolver.Logger.Info("collecting resolved dependencies",
+		slog.Duration("interval", pollInterval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			bindings, err := resolver.Storage.Poll(ctx, pollLimit)
+			if err != nil {
+				resolver.Logger.Error("failed to poll bindings",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			taskIds := lo.Uniq(lo.Map(bindings, func(binding Binding, _ int) string {
+				return binding.TaskId
+			}))
+			tasks, err := resolver.System.Tasks().ByIDs(ctx, taskIds)
+			if err != nil {
+				resolver.Logger.Error("failed to load tasks",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			outcomes := make(map[string]string, len(tasks))
+			found := make(map[string]bool, len(tasks))
+			for _, task := range tasks {
+				found[task.ID] = true
+				subStatus, done, err := resolver.outcome(ctx, task)
+				if err != nil {
+					resolver.Logger.Error("failed to get the outcome of a task",
+						slog.String("task_id", task.ID),
+						slog.String("error", err.Error()))
+					continue
+				}
+				if done {
+					outcomes[task.ID] = subStatus
+				}
+			}
+
+			err = resolver.resolve(ctx, deps, bindings, func(binding Binding) (dependency.Status, bool) {
+				if !found[binding.TaskId] {
+					return dependency.Failed, true
+				}
+
+				subStatus, ok := outcomes[binding.TaskId]
+				return status(binding, subStatus), ok
+			})
+			if err != nil {
+				resolver.Logger.Error("failed to resolve dependencies",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// resolve sends dependencies of bindings whose tasks are finished (according to the status function)
+func (resolver *Resolver) resolve(ctx context.Context, deps chan<- dependency.Dependency, bindings []Binding, statusOf func(Binding) (dependency.Status, bool)) error {
+	var resolvedIds []string
+	for _, binding := range bindings {
+		depStatus, ok := statusOf(binding)
+		if !ok {
+			continue
+		}
+
+		dep := dependency.Dependency{
+			ID:     binding.DependencyId,
+			Status: depStatus,
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case deps <- dep:
+			resolvedIds = append(resolvedIds, binding.DependencyId)
+		}
+	}
+
+	if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
+		return fmt.Errorf("failed to resolve bindings: %w", err)
+	}
+
+	return nil
+}
+
+// status fails the dependency if the task has finished with a sub status other than the required one
+func status(binding Binding, subStatus string) dependency.Status {
+	if binding.SubStatus != "" && binding.SubStatus != subStatus {
+		return dependency.Failed
+	}
+
+	return dependency.OK
+}
+
+// outcome returns the sub status of the task (or of its last retry) if it's not going to change
+func (resolver *Resolver) outcome(ctx context.Context, task core.Task) (subStatus string, done bool, err error) {
+	switch task.Status() {
+	case core.TaskStatuses.Cancelled:
+		return core.TaskSubStatuses.Cancelled, true, nil
+	case core.TaskStatuses.Finished:
+		// the outcome of a restarted task is the outcome of its retry
+		if retryId, _ := task.Info["restarted_by"].(string); retryId != "" {
+			retry, err := resolver.System.Task(ctx, retryId)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to load the retry: %w", err)
+			}
+			return resolver.outcome(ctx, retry)
+		}
+
+		subStatus := task.SubStatus()
+		if subStatus != core.TaskSubStatuses.OK && task.WillRetry(subStatus, task.ErrorCode()) {
+			return "", false, nil
+		}
+		return subStatus, true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// attempts returns ids of the task and of the tasks it has retried
+func (resolver *Resolver) attempts(ctx context.Context, task core.Task) ([]string, error) {
+	ids := []string{task.ID}
+	for {
+		parentId, _ := task.Info["restart_parent"].(string)
+		if parentId == "" || lo.Contains(ids, parentId) {
+			return ids, nil
+		}
+		ids = append(ids, parentId)
+
+		parent, err := resolver.System.Task(ctx, parentId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load a previous attempt: %w", err)
+		}
+		task = parent
+	}
+}
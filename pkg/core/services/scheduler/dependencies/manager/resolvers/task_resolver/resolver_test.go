@@ -0,0 +1,229 @@
+package taskResolver
+
+import (
+	"context"
+	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:       sys,
+		Storage:      NewMemoryStorage(),
+		ResultCodec:  codec.JSON[executor.Result](),
+		PollInterval: 50 * time.Millisecond,
+		Logger:       logger,
+	}
+
+	spawn := func() core.Task {
+		task, err := sys.Spawn(ctx, core.New())
+		if err != nil {
+			t.Fatalf("failed to spawn a task: %s", err)
+		}
+		return task
+	}
+	bind := func(depId string, dep taskopts.Dependency) {
+		if err := resolver.Bind(ctx, depId, dep.Data); err != nil {
+			t.Fatalf("failed to bind: %s", err)
+		}
+	}
+	finish := func(task core.Task, status executor.Status) {
+		data, err := resolver.ResultCodec.Encode(executor.Result{TaskID: task.ID, Status: status})
+		if err != nil {
+			t.Fatalf("failed to encode a result: %s", err)
+		}
+		if err := sys.Events().Send(ctx, core.NewEvent(core.OnTask.Finished, data)); err != nil {
+			t.Fatalf("failed to send an event: %s", err)
+		}
+	}
+
+	succeeded, failed, finishedBefore := spawn(), spawn(), spawn()
+
+	bind("any", taskopts.TaskDependency(failed.ID))
+	bind("ok", taskopts.TaskSubStatusDependency(succeeded.ID, core.TaskSubStatuses.OK))
+	bind("not ok", taskopts.TaskSubStatusDependency(failed.ID, core.TaskSubStatuses.OK))
+
+	// a task that had finished before the binding is found by polling
+	err := core.TaskStates.Transition(ctx, sys.Tasks(), finishedBefore.ID, core.TaskStatuses.Initialized, "", nil)
+	if err == nil {
+		err = core.TaskStates.Transition(ctx, sys.Tasks(), finishedBefore.ID, core.TaskStatuses.Finished, core.TaskSubStatuses.OK, nil)
+	}
+	if err != nil {
+		t.Fatalf("failed to finish a task: %s", err)
+	}
+	bind("polled", taskopts.TaskDependency(finishedBefore.ID))
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	finish(succeeded, executor.OK)
+	finish(failed, executor.Failed)
+
+	expected := map[string]dependency.Status{
+		"any":    dependency.OK,
+		"ok":     dependency.OK,
+		"not ok": dependency.Failed,
+		"polled": dependency.OK,
+	}
+
+	deadline := time.After(5 * time.Second)
+	for len(expected) > 0 {
+		select {
+		case dep := <-resolutions:
+			status, ok := expected[dep.ID]
+			if !ok {
+				t.Fatalf("unexpected dependency: %s", dep.ID)
+			}
+			if status != dep.Status {
+				t.Fatalf("unexpected status of '%s': %s", dep.ID, dep.Status)
+			}
+			delete(expected, dep.ID)
+		case <-deadline:
+			t.Fatalf("dependencies have not been resolved: %v", expected)
+		}
+	}
+}
+
+func TestBindFinishedTask(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	// polling is too slow to resolve the binding
+	resolver := &Resolver{
+		System:       sys,
+		Storage:      NewMemoryStorage(),
+		ResultCodec:  codec.JSON[executor.Result](),
+		PollInterval: time.Hour,
+		Logger:       logger,
+	}
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	task, err := sys.Spawn(ctx, core.New())
+	if err != nil {
+		t.Fatalf("failed to spawn a task: %s", err)
+	}
+	err = core.TaskStates.Transition(ctx, sys.Tasks(), task.ID, core.TaskStatuses.Initialized, "", nil)
+	if err == nil {
+		err = core.TaskStates.Transition(ctx, sys.Tasks(), task.ID, core.TaskStatuses.Finished, core.TaskSubStatuses.Failed, nil)
+	}
+	if err != nil {
+		t.Fatalf("failed to finish a task: %s", err)
+	}
+
+	if err := resolver.Bind(ctx, "dep", taskopts.TaskSubStatusDependency(task.ID, core.TaskSubStatuses.OK).Data); err != nil {
+		t.Fatalf("failed to bind: %s", err)
+	}
+
+	select {
+	case dep := <-resolutions:
+		if dep.ID != "dep" || dep.Status != dependency.Failed {
+			t.Fatalf("unexpected dependency: %s (%s)", dep.ID, dep.Status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dependency has not been resolved")
+	}
+}
+
+func TestBindMissingTask(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	resolver := &Resolver{
+		System:       sys,
+		Storage:      NewMemoryStorage(),
+		ResultCodec:  codec.JSON[executor.Result](),
+		PollInterval: time.Hour,
+		Logger:       logger,
+	}
+
+	resolutions, err := resolver.Resolutions(ctx)
+	if err != nil {
+		t.Fatalf("failed to start the resolver: %s", err)
+	}
+
+	if err := resolver.Bind(ctx, "dep", taskopts.TaskDependency("missing").Data); err != nil {
+		t.Fatalf("failed to bind: %s", err)
+	}
+
+	select {
+	case dep := <-resolutions:
+		if dep.ID != "dep" || dep.Status != dependency.Failed {
+			t.Fatalf("unexpected dependency: %s (%s)", dep.ID, dep.Status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dependency has not been resolved")
+	}
+}
+
+func TestMemoryStoragePoll(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryStorage()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := storage.Save(ctx, Binding{DependencyId: id, TaskId: id}); err != nil {
+			t.Fatalf("failed to save: %s", err)
+		}
+	}
+
+	// every binding is reached even if the first ones are never resolved
+	var polled []string
+	for i := 0; i < 3; i++ {
+		bindings, err := storage.Poll(ctx, 2)
+		if err != nil {
+			t.Fatalf("failed to poll: %s", err)
+		}
+		for _, binding := range bindings {
+			polled = append(polled, binding.DependencyId)
+		}
+	}
+
+	expected := []string{"a", "b", "c", "a", "b", "c"}
+	if strings.Join(polled, ",") != strings.Join(expected, ",") {
+		t.Fatalf("unexpected polled bindings: %v", polled)
+	}
+}
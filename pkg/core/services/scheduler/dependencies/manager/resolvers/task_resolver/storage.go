@@ -0,0 +1,21 @@
+package taskResolver
+
+import "context"
+
+type Binding struct {
+	DependencyId string
+	TaskId       string
+	// SubStatus the task must finish with (any if empty)
+	SubStatus string
+}
+
+type Storage interface {
+	Save(ctx context.Context, binding Binding) error
+	// ByTasks returns unresolved bindings of the tasks
+	ByTasks(ctx context.Context, taskIds ...string) ([]Binding, error)
+	Resolve(ctx context.Context, dependencyIds ...string) error
+	// Delete deletes bindings of the dependencies whatever their status is
+	Delete(ctx context.Context, dependencyIds ...string) error
+	// Poll returns the least recently polled unresolved bindings, so all of them are eventually polled
+	Poll(ctx context.Context, limit int) ([]Binding, error)
+}
@@ -0,0 +1,77 @@
+package timerResolver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryStorage struct {
+	bindings []Binding
+	mu       sync.Mutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{}
+}
+
+func (storage *MemoryStorage) Save(ctx context.Context, dependencyId string, at time.Time) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.bindings = append(storage.bindings, Binding{
+		DependencyId: dependencyId,
+		At:           at,
+	})
+
+	return nil
+}
+
+func (storage *MemoryStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]struct{}, len(dependencyIds))
+	for _, id := range dependencyIds {
+		ids[id] = struct{}{}
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	// resolved bindings are dropped, so the storage doesn't grow forever
+	pending := storage.bindings[:0]
+	for _, binding := range storage.bindings {
+		if _, ok := ids[binding.DependencyId]; ok {
+			continue
+		}
+		pending = append(pending, binding)
+	}
+	storage.bindings = pending
+
+	return nil
+}
+
+func (storage *MemoryStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	// resolved bindings are not kept in memory, so deleting is the same as resolving
+	return storage.Resolve(ctx, dependencyIds...)
+}
+
+func (storage *MemoryStorage) Poll(ctx context.Context, until time.Time, limit int) ([]Binding, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	var result []Binding
+	for _, binding := range storage.bindings {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if binding.At.After(until) {
+			continue
+		}
+		result = append(result, binding)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package timerResolver
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type MongoStorage struct {
+	Collection *mongo.Collection
+}
+
+// Save upserts the binding, so a resumed registration doesn't create duplicates (or reset a resolved binding).
+// The dependency id is used as _id, so concurrent registrations can't insert it twice.
+func (storage *MongoStorage) Save(ctx context.Context, dependencyId string, at time.Time) error {
+	_, err := storage.Collection.UpdateOne(ctx,
+		bson.M{
+			"dependency_id": dependencyId,
+		},
+		bson.M{
+			"$setOnInsert": bson.M{
+				"_id":        dependencyId,
+				"at":         at.UnixMilli(),
+				"status":     "pending",
+				"updated_at": time.Now().Unix(),
+			},
+		},
+		options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("failed to save the binding: %w", err)
+	}
+
+	return nil
+}
+
+func (storage *MongoStorage) Resolve(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.UpdateMany(ctx,
+		bson.M{
+			"status": "pending",
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"status":     "resolved",
+				"updated_at": time.Now().Unix(),
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Delete(ctx context.Context, dependencyIds ...string) error {
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+	_, err := storage.Collection.DeleteMany(ctx,
+		bson.M{
+			"dependency_id": bson.M{
+				"$in": dependencyIds,
+			},
+		})
+
+	return err
+}
+
+func (storage *MongoStorage) Poll(ctx context.Context, until time.Time, limit int) ([]Binding, error) {
+	cursor, err := storage.Collection.Find(
+		ctx,
+		bson.M{
+			"status": "pending",
+			"at": bson.M{
+				"$lte": until.UnixMilli(),
+			},
+		},
+		options.Find().
+			SetSort(bson.M{"at": 1}).
+			SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending bindings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rawBindings []bson.M
+	if err := cursor.All(ctx, &rawBindings); err != nil {
+		return nil, fmt.Errorf("failed to decode bindings: %w", err)
+	}
+
+	// numbers might be decoded as int32 or float64 (e.g. if bindings are written by other clients)
+	bindings := make([]Binding, 0, len(rawBindings))
+	for _, rawBinding := range rawBindings {
+		dependencyId, ok := rawBinding["dependency_id"].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected dependency_id of a binding: %v", rawBinding["dependency_id"])
+		}
+
+		at, err := parseTimestamp(rawBinding["at"])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the timestamp of a binding (dependency_id='%s'): %w", dependencyId, err)
+		}
+
+		bindings = append(bindings, Binding{
+			DependencyId: dependencyId,
+			At:           at,
+		})
+	}
+
+	return bindings, nil
+}
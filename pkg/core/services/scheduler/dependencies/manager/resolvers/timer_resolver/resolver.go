@@ -0,0 +1,110 @@
+package timerResolver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Resolver resolves dependencies when their timestamps (unix milliseconds) pass.
+//
+// Bindings are kept in the storage, so pending timers survive restarts of the scheduler.
+type Resolver struct {
+	Storage      Storage
+	PollLimit    int
+	PollInterval time.Duration
+	Logger       *slog.Logger
+}
+
+func (resolver *Resolver) Bind(ctx context.Context, depId string, data any) error {
+	at, err := parseTimestamp(data)
+	if err != nil {
+		return err
+	}
+
+	if err := resolver.Storage.Save(ctx, depId, at); err != nil {
+		return fmt.Errorf("failed to bind a timer and a dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (resolver *Resolver) DeleteBindings(ctx context.Context, dependencyIds ...string) error {
+	if err := resolver.Storage.Delete(ctx, dependencyIds...); err != nil {
+		return fmt.Errorf("failed to delete bindings: %w", err)
+	}
+
+	return nil
+}
+
+func (resolver *Resolver) Ready(ctx context.Context) (<-chan string, error) {
+	depIds := make(chan string, 1024)
+
+	go resolver.collectResolvedDependencies(ctx, depIds)
+
+	return depIds, nil
+}
+
+func (resolver *Resolver) collectResolvedDependencies(ctx context.Context, ids chan<- string) {
+	pollLimit := resolver.PollLimit
+	if pollLimit <= 0 {
+		pollLimit = 1024
+	}
+
+	pollInterval := resolver.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	resolver.Logger.Info("collecting resolved dependencies",
+		slog.Duration("interval", pollInterval))
+
+poller:
+	for {
+		select {
+		case <-ctx.Done():
+			break poller
+
+		case <-ticker.C:
+			bindings, err := resolver.Storage.Poll(ctx, time.Now(), pollLimit)
+			if err != nil {
+				resolver.Logger.Error("failed to poll bindings",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			var resolvedIds []string
+			for _, binding := range bindings {
+				select {
+				case <-ctx.Done():
+					break poller
+				case ids <- binding.DependencyId:
+					resolvedIds = append(resolvedIds, binding.DependencyId)
+				}
+			}
+
+			if err := resolver.Storage.Resolve(ctx, resolvedIds...); err != nil {
+				resolver.Logger.Error("failed to resolve timers",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func parseTimestamp(data any) (time.Time, error) {
+	switch value := data.(type) {
+	case int64:
+		return time.UnixMilli(value), nil
+	case int:
+		return time.UnixMilli(int64(value)), nil
+	case int32:
+		return time.UnixMilli(int64(value)), nil
+	case float64:
+		return time.UnixMilli(int64(value)), nil
+	default:
+		return time.Time{}, fmt.Errorf("unexpected data: %v", data)
+	}
+}
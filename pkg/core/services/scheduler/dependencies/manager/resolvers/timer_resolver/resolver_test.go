@@ -0,0 +1,32 @@
+package timerResolver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.UnixMilli(1700000000000)
+
+	// numbers are decoded differently by json, bson and mapstructure
+	for _, value := range []any{int64(1700000000000), 1700000000000, float64(1700000000000)} {
+		parsed, err := parseTimestamp(value)
+		if err != nil {
+			t.Fatalf("failed to parse %T: %s", value, err)
+		}
+		if !parsed.Equal(expected) {
+			t.Fatalf("unexpected timestamp of %T: %s", value, parsed)
+		}
+	}
+
+	parsed, err := parseTimestamp(int32(1000))
+	if err != nil || !parsed.Equal(time.UnixMilli(1000)) {
+		t.Fatalf("failed to parse int32: %s (%v)", parsed, err)
+	}
+
+	for _, value := range []any{"1700000000000", nil} {
+		if _, err := parseTimestamp(value); err == nil {
+			t.Fatalf("expected an error for %T", value)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package timerResolver
+
+import (
+	"context"
+	"time"
+)
+
+type Binding struct {
+	DependencyId string
+	At           time.Time
+}
+
+type Storage interface {
+	Save(ctx context.Context, dependencyId string, at time.Time) error
+	Resolve(ctx context.Context, dependencyIds ...string) error
+	// Delete deletes bindings of the dependencies whatever their status is
+	Delete(ctx context.Context, dependencyIds ...string) error
+	// Poll returns pending bindings that are due at the specified time
+	Poll(ctx context.Context, until time.Time, limit int) ([]Binding, error)
+}
@@ -6,4 +6,6 @@ type TaskToGroup interface {
 	Save(ctx context.Context, task string, group string) error
 	TaskByGroup(ctx context.Context, group string) (task string, err error)
 	GroupByTask(ctx context.Context, task string) (group string, err error)
+	// Delete removes bindings of the tasks
+	Delete(ctx context.Context, tasks ...string) error
 }
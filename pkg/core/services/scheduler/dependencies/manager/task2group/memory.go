@@ -0,0 +1,71 @@
+package task2group
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type MemoryStorage struct {
+	task2group map[string]string
+	group2task map[string]string
+	mu         sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		task2group: map[string]string{},
+		group2task: map[string]string{},
+	}
+}
+
+func (storage *MemoryStorage) Save(ctx context.Context, task string, group string) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	storage.task2group[task] = group
+	storage.group2task[group] = task
+
+	return nil
+}
+
+func (storage *MemoryStorage) TaskByGroup(ctx context.Context, group string) (task string, err error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	task, ok := storage.group2task[group]
+	if !ok {
+		return "", fmt.Errorf("task not found for group '%s'", group)
+	}
+
+	return task, nil
+}
+
+func (storage *MemoryStorage) GroupByTask(ctx context.Context, task string) (group string, err error) {
+	storage.mu.RLock()
+	defer storage.mu.RUnlock()
+
+	group, ok := storage.task2group[task]
+	if !ok {
+		return "", fmt.Errorf("group not found for task '%s'", task)
+	}
+
+	return group, nil
+}
+
+func (storage *MemoryStorage) Delete(ctx context.Context, tasks ...string) error {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	for _, task := range tasks {
+		group, ok := storage.task2group[task]
+		if !ok {
+			continue
+		}
+
+		delete(storage.task2group, task)
+		delete(storage.group2task, group)
+	}
+
+	return nil
+}
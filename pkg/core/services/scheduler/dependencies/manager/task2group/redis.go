@@ -2,6 +2,7 @@ package task2group
 
 import (
 	"context"
+	"fmt"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -30,3 +31,33 @@ func (storage *RedisStorage) TaskByGroup(ctx context.Context, group string) (tas
 func (storage *RedisStorage) GroupByTask(ctx context.Context, task string) (group string, err error) {
 	return storage.Client.HGet(ctx, "task2group", task).Result()
 }
+
+func (storage *RedisStorage) Delete(ctx context.Context, tasks ...string) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	groups, err := storage.Client.HMGet(ctx, "task2group", tasks...).Result()
+	if err != nil {
+		return fmt.Errorf("failed to load groups: %w", err)
+	}
+
+	var groupIds []string
+	for _, group := range groups {
+		if group, ok := group.(string); ok {
+			groupIds = append(groupIds, group)
+		}
+	}
+
+	if len(groupIds) > 0 {
+		if err := storage.Client.HDel(ctx, "group2task", groupIds...).Err(); err != nil {
+			return fmt.Errorf("failed to delete group to task mappings: %w", err)
+		}
+	}
+
+	if err := storage.Client.HDel(ctx, "task2group", tasks...).Err(); err != nil {
+		return fmt.Errorf("failed to delete task to group mappings: %w", err)
+	}
+
+	return nil
+}
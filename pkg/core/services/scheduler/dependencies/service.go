@@ -6,6 +6,7 @@ import (
 	"github.com/ischenkx/kantoku/pkg/common/service"
 	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
 	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
@@ -32,6 +33,11 @@ func (srvc *Service) Run(ctx context.Context) error {
 		return srvc.processReadyTasks(ctx)
 	})
 
+	g.Go(func() error {
+		srvc.Logger().Info("processing failed tasks...")
+		return srvc.processFailedTasks(ctx)
+	})
+
 	if err := g.Wait(); err != nil {
 		return err
 	}
@@ -73,6 +79,55 @@ func (srvc *Service) processNewTasks(ctx context.Context) error {
 	return nil
 }
 
+// processFailedTasks fails dependencies on outputs of tasks that will never initialize them
+func (srvc *Service) processFailedTasks(ctx context.Context) error {
+	channel, err := srvc.System.Events().Consume(
+		ctx,
+		[]string{core.OnTask.Finished, core.OnTask.Cancelled},
+		broker.ConsumerSettings{Group: QueueName + ".failures"},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+
+	broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			taskId, subStatus, errorCode := string(ev.Data), core.TaskSubStatuses.Cancelled, ""
+			if ev.Topic == core.OnTask.Finished {
+				result, err := srvc.Manager.ResultCodec.Decode(ev.Data)
+				if err != nil {
+					return fmt.Errorf("failed to decode the result: %w", err)
+				}
+				if result.Status == executor.OK {
+					return nil
+				}
+				taskId, subStatus, errorCode = result.TaskID, string(result.Status), result.Code
+			}
+
+			// the status of the task might not be updated yet, so the sub status is taken from the event
+			task, err := srvc.System.Task(ctx, taskId)
+			if err != nil {
+				return fmt.Errorf("failed to load the task: %w", err)
+			}
+			if ev.Topic == core.OnTask.Finished && task.WillRetry(subStatus, errorCode) {
+				return nil
+			}
+
+			if err := srvc.Manager.FailDependents(ctx, task); err != nil {
+				return fmt.Errorf("failed to fail dependents (task_id='%s'): %w", taskId, err)
+			}
+
+			return nil
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			srvc.Logger().Error("failed to process a failed task",
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, channel)
+
+	return nil
+}
+
 func (srvc *Service) processReadyTasks(ctx context.Context) error {
 	channel, err := srvc.Manager.Ready(ctx)
 	if err != nil {
@@ -83,15 +138,23 @@ func (srvc *Service) processReadyTasks(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return nil
-		case taskId := <-channel:
+		case task := <-channel:
 			srvc.Logger().Debug("ready task",
-				slog.String("id", taskId))
-			err := srvc.System.Events().Send(ctx, core.NewEvent(core.OnTask.Ready, []byte(taskId)))
+				slog.String("id", task.ID))
+			err := srvc.System.Events().Send(ctx, core.NewEvent(core.OnTask.Ready, []byte(task.ID)))
 			if err != nil {
+				// the task is not acknowledged, so it's delivered again (see Manager.Ack)
 				srvc.Logger().Error("failed to publish an event",
-					slog.String("id", taskId),
+					slog.String("id", task.ID),
 					slog.String("event", core.OnTask.Ready),
 					slog.String("error", err.Error()))
+				continue
+			}
+
+			if err := srvc.Manager.Ack(ctx, task); err != nil {
+				srvc.Logger().Error("failed to acknowledge a ready task",
+					slog.String("id", task.ID),
+					slog.String("error", err.Error()))
 			}
 		}
 	}
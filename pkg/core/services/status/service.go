@@ -2,6 +2,7 @@ package status
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
 	"github.com/ischenkx/kantoku/pkg/common/service"
@@ -9,7 +10,6 @@ import (
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/core/services/executor"
 	"log/slog"
-	"time"
 )
 
 var QueueName = "status"
@@ -67,7 +67,7 @@ func (srvc *Service) processEvent(ctx context.Context, ev core.Event) error {
 
 		taskId := string(ev.Data)
 		newStatus := srvc.event2status(ev.Topic)
-		if err := srvc.updateStatus(ctx, taskId, newStatus, ""); err != nil {
+		if err := srvc.updateStatus(ctx, taskId, newStatus, "", nil); err != nil {
 			return fmt.Errorf("failed to update status (task_id='%s' status='%s'): %w",
 				taskId,
 				newStatus,
@@ -81,7 +81,13 @@ func (srvc *Service) processEvent(ctx context.Context, ev core.Event) error {
 
 		newStatus := core.TaskStatuses.Finished
 
-		if err := srvc.updateStatus(ctx, result.TaskID, newStatus, string(result.Status)); err != nil {
+		// the code is saved along with the status, so retry policies see both
+		var props map[string]any
+		if result.Code != "" {
+			props = map[string]any{"info.error_code": result.Code}
+		}
+
+		if err := srvc.updateStatus(ctx, result.TaskID, newStatus, string(result.Status), props); err != nil {
 			return fmt.Errorf("failed to update status (task_id='%s' status='%s'): %w",
 				result.TaskID,
 				newStatus,
@@ -116,42 +122,19 @@ func (srvc *Service) event2status(topic string) string {
 	}
 }
 
-func (srvc *Service) status2precedingStatuses(status string) []any {
-	switch status {
-	case core.TaskStatuses.Initialized:
-		return []any{nil}
-	case core.TaskStatuses.Ready:
-		return []any{core.TaskStatuses.Initialized}
-	case core.TaskStatuses.Received:
-		return []any{core.TaskStatuses.Initialized, core.TaskStatuses.Ready}
-	case core.TaskStatuses.Finished:
-		return []any{core.TaskStatuses.Initialized, core.TaskStatuses.Ready, core.TaskStatuses.Received}
-	case core.TaskStatuses.Cancelled:
-		return []any{}
-	default:
-		return []any{}
+func (srvc *Service) updateStatus(ctx context.Context, id string, status, subStatus string, props map[string]any) error {
+	err := core.TaskStates.Transition(ctx, srvc.System.Tasks(), id, status, subStatus, props)
+	if errors.Is(err, core.ErrIllegalTransition) {
+		// duplicate and late events are expected, they must not be redelivered
+		srvc.Logger().Info("status transition rejected",
+			slog.String("task_id", id),
+			slog.String("status", status),
+			slog.String("sub_status", subStatus),
+			slog.String("error", err.Error()))
+		return nil
 	}
-}
-
-func (srvc *Service) updateStatus(ctx context.Context, id string, status, subStatus string) error {
-	now := time.Now().Unix()
-
-	_, err := srvc.System.
-		Tasks().
-		UpdateWithProperties(
-			ctx,
-			map[string][]any{
-				"id":          {id},
-				"info.status": srvc.status2precedingStatuses(status),
-			},
-			map[string]any{
-				"info.status":     status,
-				"info.sub_status": subStatus,
-				"info.updated_at": now,
-			},
-		)
 	if err != nil {
-		return fmt.Errorf("failed to update records: %w", err)
+		return fmt.Errorf("failed to transition: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,253 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/samber/lo"
+	"time"
+)
+
+var ErrIllegalTransition = errors.New("illegal task status transition")
+
+// RestartedFlag marks failed tasks that have been retried (see TaskStates)
+const RestartedFlag = "info.restarted"
+
+// TransitionError is returned when a task can't be moved to the requested status
+type TransitionError struct {
+	TaskID        string
+	From          string
+	FromSubStatus string
+	To            string
+	ToSubStatus   string
+}
+
+func (err *TransitionError) Error() string {
+	return fmt.Sprintf("illegal transition of task '%s': '%s/%s' -> '%s/%s'",
+		err.TaskID,
+		err.From,
+		err.FromSubStatus,
+		err.To,
+		err.ToSubStatus)
+}
+
+func (err *TransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+// TaskTransition allows moving a task from one status to another.
+// If FromSubStatuses is not empty, the current sub status must be one of them.
+//
+// A transition with a Flag (a boolean property, e.g. info.restarted) is made only if the flag is set
+// by the properties passed to Transition and at most once (the flag must not be set yet).
+type TaskTransition struct {
+	From            string
+	FromSubStatuses []string
+	To              string
+	Flag            string
+}
+
+// TaskStateMachine declares task statuses, their sub statuses and legal transitions.
+//
+// A task without a status is in the "" state.
+type TaskStateMachine struct {
+	transitions []TaskTransition
+	// status -> allowed sub statuses (a status without an entry accepts only "")
+	subStatuses map[string][]string
+}
+
+// TaskStates is the state machine used by kantoku services
+var TaskStates *TaskStateMachine
+
+func newTaskStateMachine() *TaskStateMachine {
+	active := []string{TaskStatuses.Initialized, TaskStatuses.Ready, TaskStatuses.Received}
+	failures := []string{TaskSubStatuses.Failed, TaskSubStatuses.Cancelled, TaskSubStatuses.TimedOut}
+
+	machine := &TaskStateMachine{
+		subStatuses: map[string][]string{
+			TaskStatuses.Finished: append([]string{TaskSubStatuses.OK}, failures...),
+		},
+	}
+
+	machine.add("", TaskStatuses.Initialized)
+	// a task can be cancelled before the "created" event is processed
+	machine.add("", TaskStatuses.Cancelled)
+	machine.add(TaskStatuses.Initialized, TaskStatuses.Ready)
+	// events might be processed out of order, so intermediate statuses can be skipped
+	for _, from := range active[:2] {
+		machine.add(from, TaskStatuses.Received)
+	}
+	for _, from := range active {
+		machine.add(from, TaskStatuses.Finished)
+		machine.add(from, TaskStatuses.Cancelled)
+	}
+	// retrying: a failed task keeps its status and is marked as restarted, so it's retried once
+	machine.transitions = append(machine.transitions, TaskTransition{
+		From:            TaskStatuses.Finished,
+		FromSubStatuses: failures,
+		To:              TaskStatuses.Finished,
+		Flag:            RestartedFlag,
+	})
+
+	return machine
+}
+
+func (machine *TaskStateMachine) add(from, to string) {
+	machine.transitions = append(machine.transitions, TaskTransition{From: from, To: to})
+}
+
+func (machine *TaskStateMachine) Transitions() []TaskTransition {
+	return machine.transitions
+}
+
+// SubStatuses returns sub statuses allowed for the status
+func (machine *TaskStateMachine) SubStatuses(status string) []string {
+	return machine.subStatuses[status]
+}
+
+func (machine *TaskStateMachine) CanTransition(from, fromSubStatus, to, toSubStatus string) bool {
+	if !machine.validSubStatus(to, toSubStatus) {
+		return false
+	}
+
+	for _, transition := range machine.transitions {
+		// flagged transitions depend on the properties of the task
+		if transition.From != from || transition.To != to || transition.Flag != "" {
+			continue
+		}
+
+		if len(transition.FromSubStatuses) == 0 || lo.Contains(transition.FromSubStatuses, fromSubStatus) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition atomically moves the task to the status (with the sub status and additional properties).
+// A *TransitionError is returned if the task's current status doesn't allow it.
+//
+// If the properties set a flag of a transition (see TaskTransition.Flag), only flagged transitions are made.
+func (machine *TaskStateMachine) Transition(ctx context.Context, tasks TaskDB, id, to, subStatus string, properties map[string]any) error {
+	conditions, flagged := machine.conditions(to, properties)
+
+	now := time.Now().Unix()
+	newProperties := map[string]any{
+		"info.status":     to,
+		"info.sub_status": subStatus,
+		"info.updated_at": now,
+	}
+	// flagged transitions don't finish tasks, they mark finished ones
+	if (to == TaskStatuses.Finished || to == TaskStatuses.Cancelled) && !flagged {
+		newProperties["info.finished_at"] = now
+	}
+	for key, value := range properties {
+		newProperties[key] = value
+	}
+
+	if machine.validSubStatus(to, subStatus) {
+		for _, condition := range conditions {
+			condition["id"] = []any{id}
+
+			updated, err := tasks.UpdateWithProperties(ctx, condition, newProperties)
+			if err != nil {
+				return fmt.Errorf("failed to update the task: %w", err)
+			}
+
+			if updated > 0 {
+				return nil
+			}
+		}
+	}
+
+	loaded, err := tasks.ByIDs(ctx, []string{id})
+	if err != nil {
+		return fmt.Errorf("failed to load the task: %w", err)
+	}
+
+	if len(loaded) == 0 {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+
+	return &TransitionError{
+		TaskID:        id,
+		From:          loaded[0].Status(),
+		FromSubStatus: loaded[0].SubStatus(),
+		To:            to,
+		ToSubStatus:   subStatus,
+	}
+}
+
+func (machine *TaskStateMachine) validSubStatus(status, subStatus string) bool {
+	if subStatus == "" {
+		return true
+	}
+
+	return lo.Contains(machine.subStatuses[status], subStatus)
+}
+
+// conditions returns property queries matching tasks that can be moved to the status.
+// Transitions without sub status constraints are merged into a single query.
+// If the properties set a flag, only the transitions with this flag are used (flagged is true).
+func (machine *TaskStateMachine) conditions(to string, properties map[string]any) (result []map[string][]any, flagged bool) {
+	transitions := lo.Filter(machine.transitions, func(transition TaskTransition, _ int) bool {
+		return transition.To == to && transition.Flag != "" && properties[transition.Flag] == true
+	})
+	flagged = len(transitions) > 0
+	if !flagged {
+		transitions = lo.Filter(machine.transitions, func(transition TaskTransition, _ int) bool {
+			return transition.To == to && transition.Flag == ""
+		})
+	}
+
+	var unconstrained []any
+	for _, transition := range transitions {
+		from := statusValue(transition.From)
+
+		if len(transition.FromSubStatuses) == 0 && transition.Flag == "" {
+			unconstrained = append(unconstrained, from)
+			continue
+		}
+
+		condition := map[string][]any{
+			"info.status": {from},
+		}
+		if len(transition.FromSubStatuses) > 0 {
+			condition["info.sub_status"] = lo.ToAnySlice(transition.FromSubStatuses)
+		}
+		if transition.Flag != "" {
+			condition[transition.Flag] = []any{nil, false}
+		}
+
+		result = append(result, condition)
+	}
+
+	if len(unconstrained) > 0 {
+		result = append(result, map[string][]any{
+			"info.status": unconstrained,
+		})
+	}
+
+	return result, flagged
+}
+
+// statusValue maps the "" state to a missing property
+func statusValue(status string) any {
+	if status == "" {
+		return nil
+	}
+
+	return status
+}
+
+func (task Task) Status() string {
+	status, _ := task.Info["status"].(string)
+
+	return status
+}
+
+func (task Task) SubStatus() string {
+	subStatus, _ := task.Info["sub_status"].(string)
+
+	return subStatus
+}
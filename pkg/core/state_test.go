@@ -0,0 +1,102 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"testing"
+)
+
+func TestTransitions(t *testing.T) {
+	ctx := context.Background()
+	tasks := inmem.New()
+
+	if err := tasks.Insert(ctx, []core.Task{{ID: "1", Info: map[string]any{}}}); err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	steps := []struct {
+		status    string
+		subStatus string
+		legal     bool
+	}{
+		{core.TaskStatuses.Ready, "", false},
+		{core.TaskStatuses.Initialized, "", true},
+		{core.TaskStatuses.Received, "", true},
+		{core.TaskStatuses.Ready, "", false},
+		{core.TaskStatuses.Finished, "unknown", false},
+		{core.TaskStatuses.Cancelled, "", true},
+		{core.TaskStatuses.Finished, core.TaskSubStatuses.Cancelled, false},
+	}
+
+	for index, step := range steps {
+		err := core.TaskStates.Transition(ctx, tasks, "1", step.status, step.subStatus, nil)
+
+		if step.legal && err != nil {
+			t.Fatalf("step %d: unexpected error: %s", index, err)
+		}
+
+		if !step.legal {
+			var transitionErr *core.TransitionError
+			if !errors.As(err, &transitionErr) || !errors.Is(err, core.ErrIllegalTransition) {
+				t.Fatalf("step %d: expected a transition error, got %v", index, err)
+			}
+		}
+	}
+	// a missing task is not an illegal transition, so it's reported separately (e.g. as 404 by the http api)
+	err := core.TaskStates.Transition(ctx, tasks, "missing", core.TaskStatuses.Cancelled, "", nil)
+	if !errors.Is(err, core.ErrTaskNotFound) || errors.Is(err, core.ErrIllegalTransition) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRetryingTransition(t *testing.T) {
+	ctx := context.Background()
+	tasks := inmem.New()
+
+	err := tasks.Insert(ctx, []core.Task{
+		{ID: "failed", Info: map[string]any{"status": core.TaskStatuses.Finished, "sub_status": core.TaskSubStatuses.Failed, "finished_at": int64(1)}},
+		{ID: "ok", Info: map[string]any{"status": core.TaskStatuses.Finished, "sub_status": core.TaskSubStatuses.OK}},
+		{ID: "running", Info: map[string]any{"status": core.TaskStatuses.Received}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	retry := map[string]any{core.RestartedFlag: true}
+
+	// a finished task can't be finished again without the flag (e.g. by a duplicate event)
+	err = core.TaskStates.Transition(ctx, tasks, "failed", core.TaskStatuses.Finished, core.TaskSubStatuses.Failed, nil)
+	if !errors.Is(err, core.ErrIllegalTransition) {
+		t.Fatalf("expected a transition error, got %v", err)
+	}
+
+	for _, id := range []string{"ok", "running"} {
+		err := core.TaskStates.Transition(ctx, tasks, id, core.TaskStatuses.Finished, core.TaskSubStatuses.Failed, retry)
+		if !errors.Is(err, core.ErrIllegalTransition) {
+			t.Fatalf("%s: expected a transition error, got %v", id, err)
+		}
+	}
+
+	if err := core.TaskStates.Transition(ctx, tasks, "failed", core.TaskStatuses.Finished, core.TaskSubStatuses.Failed, retry); err != nil {
+		t.Fatalf("failed to retry: %s", err)
+	}
+
+	// a task is retried once
+	err = core.TaskStates.Transition(ctx, tasks, "failed", core.TaskStatuses.Finished, core.TaskSubStatuses.Failed, retry)
+	if !errors.Is(err, core.ErrIllegalTransition) {
+		t.Fatalf("expected a transition error, got %v", err)
+	}
+
+	loaded, err := tasks.ByIDs(ctx, []string{"failed", "running"})
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+	if loaded[0].SubStatus() != core.TaskSubStatuses.Failed || loaded[0].Info["finished_at"] != int64(1) {
+		t.Fatalf("the retried task must stay finished: %+v", loaded[0].Info)
+	}
+	if loaded[1].Status() != core.TaskStatuses.Received {
+		t.Fatalf("the running task has been moved: %+v", loaded[1].Info)
+	}
+}
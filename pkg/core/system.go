@@ -14,15 +14,24 @@ type AbstractSystem interface {
 	Events() Broker
 
 	Spawn(ctx context.Context, t Task) (Task, error)
+	SpawnBatch(ctx context.Context, tasks []Task) ([]Task, error)
 	Task(ctx context.Context, id string) (Task, error)
+	Cancel(ctx context.Context, id string, reason string) error
 }
 
 var _ AbstractSystem = (*System)(nil)
 
+// TaskDefaults sets properties of new tasks that are not set explicitly (e.g. from specifications of their types),
+// it gets all tasks of a batch at once
+type TaskDefaults interface {
+	Apply(ctx context.Context, tasks []Task) error
+}
+
 type System struct {
 	broker    Broker
 	resources ResourceDB
 	tasks     TaskDB
+	defaults  TaskDefaults
 
 	logger *slog.Logger
 }
@@ -36,6 +45,11 @@ func NewSystem(broker Broker, resources ResourceDB, tasks TaskDB, logger *slog.L
 	}
 }
 
+// SetDefaults makes the system apply the defaults to every spawned task
+func (system *System) SetDefaults(defaults TaskDefaults) {
+	system.defaults = defaults
+}
+
 func (system System) Tasks() TaskDB {
 	return system.tasks
 }
@@ -49,66 +63,119 @@ func (system System) Events() Broker {
 }
 
 func (system System) Spawn(ctx context.Context, newTask Task) (initializedTask Task, err error) {
-	type state struct {
-		task Task
+	tasks, err := system.SpawnBatch(ctx, []Task{newTask})
+	if err != nil {
+		return Task{}, err
+	}
+
+	return tasks[0], nil
+}
+
+// SpawnBatch inserts all tasks with a single TaskDB.Insert call.
+//
+// Either all tasks are spawned or none of them: if the insertion fails,
+// the partially inserted tasks are deleted.
+// Tasks with invalid timeouts are rejected with ErrInvalidTimeout.
+func (system System) SpawnBatch(ctx context.Context, newTasks []Task) ([]Task, error) {
+	if len(newTasks) == 0 {
+		return nil, nil
 	}
 
-	// shallow copying the info to avoid modification of the original object
-	shallowCopiedInfo := make(map[string]any)
-	for key, val := range newTask.Info {
-		shallowCopiedInfo[key] = val
+	newTasks = lo.Map(newTasks, func(newTask Task, _ int) Task {
+		// shallow copying the info to avoid modification of the original object
+		shallowCopiedInfo := make(map[string]any)
+		for key, val := range newTask.Info {
+			shallowCopiedInfo[key] = val
+		}
+		newTask.Info = shallowCopiedInfo
+
+		return newTask
+	})
+
+	if system.defaults != nil {
+		if err := system.defaults.Apply(ctx, newTasks); err != nil {
+			return nil, fmt.Errorf("failed to apply defaults: %w", err)
+		}
 	}
-	newTask.Info = shallowCopiedInfo
 
-	// initializing the execution context
-	if _, ok := newTask.Info["context_id"]; !ok {
-		newTask.Info["context_id"] = uid.Generate()
+	initializedTasks := make([]Task, 0, len(newTasks))
+	events := make([]Event, 0, len(newTasks))
+	for _, newTask := range newTasks {
+		if _, _, err := newTask.Timeout(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTimeout, err)
+		}
+
+		// initializing the execution context
+		if _, ok := newTask.Info["context_id"]; !ok {
+			newTask.Info["context_id"] = uid.Generate()
+		}
+
+		newTask.ID = uid.Generate()
+
+		// the task and its "created" event are stored in a single operation,
+		// the event is delivered either right away or later by the outbox relay
+		createdEvent := NewEvent(OnTask.Created, []byte(newTask.ID))
+		newTask.Info[OutboxInfoKey] = NewOutbox(createdEvent)
+
+		initializedTasks = append(initializedTasks, newTask)
+		events = append(events, createdEvent)
 	}
 
-	newTask.ID = uid.Generate()
-
-	// TODO: transactions must provide atomicity and eventual consistency guarantees
-	// Thus, the transaction below must be executed via Sagas
-	// (this pattern is very similar to this code but it allows retries for compensating transactions)
-	tx := lo.NewTransaction[state]().
-		Then(
-			func(state state) (state, error) {
-				err := system.Tasks().Insert(ctx, []Task{state.task})
-				if err != nil {
-					return state, err
-				}
-
-				return state, nil
-			},
-			func(state state) state {
-				err := system.tasks.Delete(ctx, []string{state.task.ID})
-				if err != nil {
-					system.logger.Error("failed to delete task info in the compensating transaction",
-						slog.String("id", state.task.ID),
-						slog.String("error", err.Error()))
-				}
-
-				return state
-			}).
-		Then(
-			func(s state) (state, error) {
-				// todo: enable back
-				err := system.Events().Send(ctx, NewEvent(OnTask.Created, []byte(s.task.ID)))
-				if err != nil {
-					return s, fmt.Errorf("failed to publish an event: %w", err)
-				}
-				return s, nil
-			},
-			func(s state) state {
-				return s
-			})
-
-	result, err := tx.Process(state{task: newTask})
+	ids := lo.Map(initializedTasks, func(t Task, _ int) string { return t.ID })
+
+	if err := system.Tasks().Insert(ctx, initializedTasks); err != nil {
+		// compensating a partial insertion
+		if deleteErr := system.Tasks().Delete(ctx, ids); deleteErr != nil {
+			system.logger.Error("failed to delete tasks in the compensating transaction",
+				slog.Int("tasks", len(ids)),
+				slog.String("error", deleteErr.Error()))
+		}
+
+		return nil, fmt.Errorf("failed to insert tasks: %w", err)
+	}
+
+	system.flushOutbox(ctx, events)
+
+	return initializedTasks, nil
+}
+
+// flushOutbox is a fast path that tries to publish freshly stored events without waiting for the relay.
+// Failures are not fatal: pending events stay in the outbox and get delivered by the relay.
+func (system System) flushOutbox(ctx context.Context, events []Event) {
+	// the events are sent with a single call when the broker supports batches,
+	// if it fails, none of the outboxes is marked (events that got through are deduplicated by consumers)
+	if err := SendBatch(ctx, system.Events(), events); err != nil {
+		system.logger.Warn("failed to publish events, leaving them to the outbox relay",
+			slog.Int("events", len(events)),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	eventIDs := lo.Map(events, func(event Event, _ int) string { return event.ID })
+	if err := MarkOutboxSent(ctx, system.Tasks(), eventIDs...); err != nil {
+		system.logger.Warn("failed to mark outboxes as sent",
+			slog.Int("events", len(eventIDs)),
+			slog.String("error", err.Error()))
+	}
+}
+
+// Cancel moves the task to the "cancelled" status and notifies executors, so a running task is interrupted.
+// A *TransitionError is returned if the task is not active anymore.
+func (system System) Cancel(ctx context.Context, id string, reason string) error {
+	// the "cancelled" event is stored together with the status, so it can't be lost
+	cancelledEvent := NewEvent(OnTask.Cancelled, []byte(id))
+
+	properties := OutboxProperties(cancelledEvent)
+	properties["info.cancel_reason"] = reason
+
+	err := TaskStates.Transition(ctx, system.Tasks(), id, TaskStatuses.Cancelled, "", properties)
 	if err != nil {
-		return Task{}, err
+		return fmt.Errorf("failed to cancel: %w", err)
 	}
 
-	return result.task, nil
+	system.flushOutbox(ctx, []Event{cancelledEvent})
+
+	return nil
 }
 
 func (system System) Task(ctx context.Context, id string) (Task, error) {
@@ -118,7 +185,7 @@ func (system System) Task(ctx context.Context, id string) (Task, error) {
 	}
 
 	if len(tasks) == 0 {
-		return Task{}, fmt.Errorf("task not found: %s", id)
+		return Task{}, fmt.Errorf("%w: %s", ErrTaskNotFound, id)
 	}
 
 	return tasks[0], nil
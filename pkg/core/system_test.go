@@ -0,0 +1,56 @@
+package core_test
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+type batchBroker struct {
+	sent    int
+	batches [][]core.Event
+}
+
+func (b *batchBroker) Send(ctx context.Context, event core.Event) error {
+	b.sent++
+	return nil
+}
+
+func (b *batchBroker) SendBatch(ctx context.Context, events []core.Event) error {
+	b.batches = append(b.batches, events)
+	return nil
+}
+
+func (b *batchBroker) Consume(ctx context.Context, events []string, settings broker.ConsumerSettings) (<-chan core.BrokerEvent, error) {
+	return nil, nil
+}
+
+func TestSpawnBatchSendsBatch(t *testing.T) {
+	ctx := context.Background()
+	events := &batchBroker{}
+	system := core.NewSystem(events, nil, inmem.New(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tasks, err := system.SpawnBatch(ctx, []core.Task{{}, {}, {}})
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	if events.sent != 0 || len(events.batches) != 1 || len(events.batches[0]) != len(tasks) {
+		t.Fatalf("expected a single batch of %d events, got %d sends and %d batches", len(tasks), events.sent, len(events.batches))
+	}
+
+	for _, task := range tasks {
+		stored, err := system.Task(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("failed to load the task: %s", err)
+		}
+
+		if stored.OutboxStatus() != core.OutboxStatuses.Sent {
+			t.Fatalf("expected the outbox of '%s' to be sent, got '%s'", task.ID, stored.OutboxStatus())
+		}
+	}
+}
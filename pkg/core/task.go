@@ -8,6 +8,8 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+var ErrTaskNotFound = errors.New("task not found")
+
 type Task struct {
 	Inputs  []string
 	Outputs []string
@@ -74,3 +76,16 @@ type TaskDB interface {
 	GetWithProperties(ctx context.Context, propertiesToValues map[string][]any) ([]Task, error)
 	UpdateWithProperties(ctx context.Context, propertiesToValues map[string][]any, newProperties map[string]any) (updatedDocs int, err error)
 }
+
+// PageCursor is a position in tasks ordered by a numeric property and the id
+type PageCursor struct {
+	Value int64
+	ID    string
+}
+
+// PagedTaskDB loads tasks page by page (e.g. the garbage collector pages through finished tasks by info.finished_at)
+type PagedTaskDB interface {
+	// GetPage returns at most limit tasks matching the properties that come after the cursor
+	// in the order of the numeric property and the id. Tasks without the property are skipped.
+	GetPage(ctx context.Context, propertiesToValues map[string][]any, orderBy string, after PageCursor, limit int) ([]Task, error)
+}
@@ -1,13 +1,23 @@
 package taskopts
 
 import (
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/samber/lo"
+	"time"
 )
 
 type Dependency struct {
 	Name string
 	Data any
+	// Optional dependencies are satisfied even if they fail
+	Optional bool
+}
+
+// Optional makes the dependency satisfied even if it fails
+func Optional(dep Dependency) Dependency {
+	dep.Optional = true
+	return dep
 }
 
 func WithInputs(inputs ...string) core.Option {
@@ -39,11 +49,11 @@ func WithInfo(info map[string]any) core.Option {
 
 func DependOnInputs() core.Option {
 	return func(t *core.Task) {
-		WithDependencies(
+		addDependencies(t,
 			lo.Map(t.Inputs, func(id string, _ int) Dependency {
 				return ResourceDependency(id)
 			})...,
-		)(t)
+		)
 	}
 }
 
@@ -54,29 +64,108 @@ func ResourceDependency(id string) Dependency {
 	}
 }
 
-func WithDependencies(dependencies ...Dependency) core.Option {
+// TaskDependency is resolved when the task finishes (with any sub status)
+func TaskDependency(id string) Dependency {
+	return Dependency{
+		Name: "task",
+		Data: map[string]any{
+			"task_id": id,
+		},
+	}
+}
+
+// TaskSubStatusDependency is resolved when the task finishes with the sub status and fails otherwise
+func TaskSubStatusDependency(id, subStatus string) Dependency {
+	return Dependency{
+		Name: "task",
+		Data: map[string]any{
+			"task_id":    id,
+			"sub_status": subStatus,
+		},
+	}
+}
+
+// SignalDependency is resolved when the signal is sent (see signalResolver.Send)
+func SignalDependency(name string) Dependency {
+	return Dependency{
+		Name: "signal",
+		Data: map[string]any{
+			"name": name,
+		},
+	}
+}
+
+// ExpiringSignalDependency is resolved when the signal is sent and fails if it's not sent before expiresAt
+func ExpiringSignalDependency(name string, expiresAt time.Time) Dependency {
+	return Dependency{
+		Name: "signal",
+		Data: map[string]any{
+			"name":       name,
+			"expires_at": expiresAt.UnixMilli(),
+		},
+	}
+}
+
+// SignalPayload makes the payload of the signal be copied to the output (an allocated resource)
+func SignalPayload(dep Dependency, output string) Dependency {
+	data, _ := dep.Data.(map[string]any)
+	dep.Data = lo.Assign(data, map[string]any{"output": output})
+	return dep
+}
+
+// TimerDependency is resolved when the time passes
+func TimerDependency(at time.Time) Dependency {
+	return Dependency{
+		Name: "timer",
+		Data: at.UnixMilli(),
+	}
+}
+
+// WithNotBefore makes the task ready not earlier than at
+func WithNotBefore(at time.Time) core.Option {
 	return func(t *core.Task) {
-		existingDependencies, ok := t.Info["dependencies"].(map[string]any)
-		if !ok {
-			existingDependencies = map[string]any{}
-			t.Info["dependencies"] = existingDependencies
-		}
+		addDependencies(t, TimerDependency(at))
+	}
+}
 
-		existingSpecs, ok := existingDependencies["specs"].([]map[string]any)
-		if !ok {
-			existingSpecs = []map[string]any{}
-			existingDependencies["specs"] = existingSpecs
-		}
+// WithDependencies adds dependencies to the task, the mode tells how many of them must be satisfied
+// for the task to become ready (see dependency.AllOf, dependency.AnyOf and dependency.KOf).
+// Optional dependencies are set with Optional, not with the mode.
+func WithDependencies(mode dependency.Mode, dependencies ...Dependency) core.Option {
+	return func(t *core.Task) {
+		addDependencies(t, dependencies...)
 
-		for _, dep := range dependencies {
-			existingSpecs = append(existingSpecs, map[string]any{
-				"name": dep.Name,
-				"data": dep.Data,
-			})
+		existingDependencies := t.Info["dependencies"].(map[string]any)
+		existingDependencies["mode"] = map[string]any{
+			"required": mode.Required,
 		}
-		existingDependencies["specs"] = existingSpecs
+	}
+}
+
+// addDependencies appends dependencies to the task keeping its mode
+func addDependencies(t *core.Task, dependencies ...Dependency) {
+	existingDependencies, ok := t.Info["dependencies"].(map[string]any)
+	if !ok {
+		existingDependencies = map[string]any{}
 		t.Info["dependencies"] = existingDependencies
 	}
+
+	existingSpecs, ok := existingDependencies["specs"].([]map[string]any)
+	if !ok {
+		existingSpecs = []map[string]any{}
+	}
+
+	for _, dep := range dependencies {
+		spec := map[string]any{
+			"name": dep.Name,
+			"data": dep.Data,
+		}
+		if dep.Optional {
+			spec["optional"] = true
+		}
+		existingSpecs = append(existingSpecs, spec)
+	}
+	existingDependencies["specs"] = existingSpecs
 }
 
 func WithContextID(ctxId string) core.Option {
@@ -86,3 +175,29 @@ func WithContextID(ctxId string) core.Option {
 func WithType(t string) core.Option {
 	return WithProperty("type", t)
 }
+
+// WithTimeout limits the execution time of the task (info.timeout)
+func WithTimeout(timeout time.Duration) core.Option {
+	return WithProperty("timeout", timeout.String())
+}
+
+// WithDeadline sets the time by which the task must be executed (info.deadline, unix seconds)
+func WithDeadline(deadline time.Time) core.Option {
+	return WithProperty("deadline", deadline.Unix())
+}
+
+// WithRetryPolicy makes the retrier service restart the task if it fails (info.retry)
+func WithRetryPolicy(policy core.RetryPolicy) core.Option {
+	return WithProperty("retry", policy.AsInfo())
+}
+
+// WithRetention makes the garbage collector keep the task's execution context
+// for at least the duration after it's finished (info.retention)
+func WithRetention(retention time.Duration) core.Option {
+	return WithProperty("retention", retention.String())
+}
+
+// WithUpstreamFailurePolicy sets what happens to the task if one of its dependencies fails (info.on_upstream_failure)
+func WithUpstreamFailurePolicy(policy string) core.Option {
+	return WithProperty("on_upstream_failure", policy)
+}
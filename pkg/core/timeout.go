@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// Timeout returns the execution timeout of the task (info.timeout).
+//
+// The timeout is either a duration string ("1m30s") or a number of seconds.
+func (task Task) Timeout() (time.Duration, bool, error) {
+	raw, ok := task.Info["timeout"]
+	if !ok || raw == nil {
+		return 0, false, nil
+	}
+
+	timeout, err := ParseTimeout(raw)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return timeout, true, nil
+}
+
+// Deadline returns the execution deadline of the task (info.deadline, unix seconds)
+func (task Task) Deadline() (time.Time, bool, error) {
+	raw, ok := task.Info["deadline"]
+	if !ok || raw == nil {
+		return time.Time{}, false, nil
+	}
+
+	seconds, ok := number(raw)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("unexpected deadline type: %T", raw)
+	}
+
+	return time.Unix(int64(seconds), 0), true, nil
+}
+
+// ExecutionDeadline returns the earliest of the task's deadline and start + timeout
+func (task Task) ExecutionDeadline(start time.Time) (time.Time, bool, error) {
+	deadline, hasDeadline, err := task.Deadline()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get the deadline: %w", err)
+	}
+
+	timeout, hasTimeout, err := task.Timeout()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get the timeout: %w", err)
+	}
+
+	if hasTimeout && (!hasDeadline || start.Add(timeout).Before(deadline)) {
+		deadline, hasDeadline = start.Add(timeout), true
+	}
+
+	return deadline, hasDeadline, nil
+}
+
+// ParseTimeout parses a duration string ("1m30s") or a number of seconds
+func ParseTimeout(raw any) (time.Duration, error) {
+	if str, ok := raw.(string); ok {
+		timeout, err := time.ParseDuration(str)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse the timeout: %w", err)
+		}
+		return timeout, nil
+	}
+
+	seconds, ok := number(raw)
+	if !ok {
+		return 0, fmt.Errorf("unexpected timeout type: %T", raw)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// number converts numbers decoded from different storages (json, bson, etc.) to float64
+func number(raw any) (float64, bool) {
+	switch value := raw.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	default:
+		return 0, false
+	}
+}
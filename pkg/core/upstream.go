@@ -0,0 +1,50 @@
+package core
+
+// UpstreamFailurePolicies tell what happens to a task if one of its dependencies fails (info.on_upstream_failure)
+var UpstreamFailurePolicies = struct {
+	// Fail finishes the task with the "failed" sub status and the "upstream_failed" reason (the default one)
+	Fail string
+	// Run executes the task anyway (inputs of failed tasks are not initialized)
+	Run string
+}{
+	Fail: "fail",
+	Run:  "run",
+}
+
+// TaskFailureReasons are set to info.failure_reason of tasks failed by kantoku services
+var TaskFailureReasons = struct {
+	UpstreamFailed string
+}{
+	UpstreamFailed: "upstream_failed",
+}
+
+func (task Task) UpstreamFailurePolicy() string {
+	policy, _ := task.Info["on_upstream_failure"].(string)
+	if policy == "" {
+		return UpstreamFailurePolicies.Fail
+	}
+
+	return policy
+}
+
+func (task Task) FailureReason() string {
+	reason, _ := task.Info["failure_reason"].(string)
+
+	return reason
+}
+
+// WillRetry reports whether the task finished with the sub status and the error code
+// is going to be restarted by its retry policy.
+// Tasks failed because of their dependencies are never retried.
+func (task Task) WillRetry(subStatus, errorCode string) bool {
+	if task.FailureReason() == TaskFailureReasons.UpstreamFailed {
+		return false
+	}
+
+	policy, ok, err := task.RetryPolicy()
+	if err != nil || !ok {
+		return false
+	}
+
+	return policy.Retryable(subStatus, errorCode, task.RetryAttempt())
+}
@@ -6,28 +6,44 @@ import (
 	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
 	"github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
 	capi "github.com/hashicorp/consul/api"
+	"github.com/ischenkx/kantoku/pkg/common/data/blob"
 	"github.com/ischenkx/kantoku/pkg/common/data/codec"
 	"github.com/ischenkx/kantoku/pkg/common/data/storage"
 	"github.com/ischenkx/kantoku/pkg/common/data/uid"
 	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	dependencyinmem "github.com/ischenkx/kantoku/pkg/common/dependency/inmem"
 	batched2 "github.com/ischenkx/kantoku/pkg/common/dependency/postgres/batched"
+	dependencyredis "github.com/ischenkx/kantoku/pkg/common/dependency/redis"
 	"github.com/ischenkx/kantoku/pkg/common/logging/prefixed"
 	"github.com/ischenkx/kantoku/pkg/common/service"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
 	"github.com/ischenkx/kantoku/pkg/common/transport/broker/watermill"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/core/database/event_broker"
 	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	resourcedbpostgres "github.com/ischenkx/kantoku/pkg/core/database/resource_db/postgres"
 	"github.com/ischenkx/kantoku/pkg/core/database/task_db"
+	taskdbinmem "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	taskdbpostgres "github.com/ischenkx/kantoku/pkg/core/database/task_db/postgres"
 	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	"github.com/ischenkx/kantoku/pkg/core/services/outbox"
 	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies"
 	manager2 "github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager"
 	resourceResolver2 "github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/resource_resolver"
+	resourceResolverV2 "github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/resource_resolver_v2"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/signal_resolver"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/task_resolver"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/timer_resolver"
 	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/task2group"
 	"github.com/ischenkx/kantoku/pkg/core/services/status"
 	"github.com/ischenkx/kantoku/pkg/lib/builder/errx"
 	"github.com/ischenkx/kantoku/pkg/lib/discovery"
 	"github.com/ischenkx/kantoku/pkg/lib/discovery/consul"
 	"github.com/ischenkx/kantoku/pkg/lib/resources"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/cron"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/gc"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/retrier"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lmittmann/tint"
@@ -64,6 +80,23 @@ func BuildSystem(ctx context.Context, logger *slog.Logger, config SystemConfig)
 	), nil
 }
 
+// BuildCore builds the system and the specifications of task types,
+// new tasks get defaults from the specifications of their types (see specification.Defaults)
+func BuildCore(ctx context.Context, logger *slog.Logger, config CoreConfig) (*core.System, *specification.Manager, error) {
+	sys, err := BuildSystem(ctx, logger, config.System)
+	if err != nil {
+		return nil, nil, errx.FailedToBuild("system", err)
+	}
+
+	specifications, err := BuildSpecifications(ctx, config.Specifications)
+	if err != nil {
+		return nil, nil, errx.FailedToBuild("specifications", err)
+	}
+	sys.SetDefaults(specification.Defaults{Specifications: specifications})
+
+	return sys, specifications, nil
+}
+
 func BuildTasks(ctx context.Context, logger *slog.Logger, config TasksConfig) (core.TaskDB, error) {
 	storage, err := BuildTasksStorage(ctx, logger, config.Storage)
 	if err != nil {
@@ -102,17 +135,48 @@ func BuildTasksStorage(ctx context.Context, logger *slog.Logger, config TasksSto
 		}
 
 		return taskStorage, nil
+	case "memory":
+		return taskdbinmem.New(), nil
+	case "postgres":
+		pool, err := buildPostgres(ctx, config.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		table, err := getOption[string](config.Options, "table")
+		if err != nil {
+			table = "tasks"
+		}
+
+		return &taskdbpostgres.DB{
+			Client: pool,
+			Table:  table,
+		}, nil
 	default:
 		return nil, errx.UnsupportedKind(config.Kind)
 	}
 }
 
 func BuildResources(ctx context.Context, broker core.Broker, logger *slog.Logger, config ResourcesConfig) (core.ResourceDB, error) {
-	storage, err := BuildResourcesStorage(ctx, config.Storage)
+	storage, err := BuildResourcesStorage(ctx, logger, config.Storage)
 	if err != nil {
 		return nil, errx.FailedToBuild("resources_storage", err)
 	}
 
+	if config.Blobs.Kind != "" {
+		blobs, err := BuildResourcesBlobs(ctx, config.Blobs)
+		if err != nil {
+			return nil, errx.FailedToBuild("resources_blobs", err)
+		}
+
+		threshold := config.Blobs.Threshold
+		if threshold <= 0 {
+			threshold = 1 << 20
+		}
+
+		storage = resourcedb.NewTieredDB(storage, blobs, threshold, logger)
+	}
+
 	for _, observerConfig := range config.Observers {
 		observer, err := BuildResourcesObserver(ctx, broker, logger, observerConfig)
 		if err != nil {
@@ -125,7 +189,7 @@ func BuildResources(ctx context.Context, broker core.Broker, logger *slog.Logger
 	return storage, nil
 }
 
-func BuildResourcesStorage(ctx context.Context, config ResourcesStorageConfig) (core.ResourceDB, error) {
+func BuildResourcesStorage(ctx context.Context, logger *slog.Logger, config ResourcesStorageConfig) (core.ResourceDB, error) {
 	switch config.Kind {
 	case "redis":
 		redisClient, err := buildRedis(ctx, config.URI)
@@ -139,6 +203,52 @@ func BuildResourcesStorage(ctx context.Context, config ResourcesStorageConfig) (
 		}
 
 		return resourcedb.NewRedisDB(redisClient, codec.JSON[core.Resource](), keyPrefix), nil
+	case "postgres":
+		pool, err := buildPostgres(ctx, config.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		table, err := getOption[string](config.Options, "table")
+		if err != nil {
+			table = "resources"
+		}
+
+		channel, _ := getOption[string](config.Options, "channel")
+
+		return &resourcedbpostgres.DB{
+			Client:  pool,
+			Table:   table,
+			Channel: channel,
+			Logger:  logger,
+		}, nil
+	case "memory":
+		return resourcedb.NewMockDB(), nil
+	default:
+		return nil, errx.UnsupportedKind(config.Kind)
+	}
+}
+
+func BuildResourcesBlobs(ctx context.Context, config ResourcesBlobsConfig) (blob.Store, error) {
+	switch config.Kind {
+	case "filesystem":
+		return blob.NewFileStore(config.URI)
+	case "s3":
+		store := &blob.S3Store{Endpoint: config.URI}
+		for key, value := range map[string]*string{
+			"bucket":     &store.Bucket,
+			"region":     &store.Region,
+			"access_key": &store.AccessKey,
+			"secret_key": &store.SecretKey,
+		} {
+			option, err := getOption[string](config.Options, key)
+			if err != nil {
+				return nil, errx.FailedToBuild("s3", err)
+			}
+			*value = option
+		}
+
+		return store, nil
 	default:
 		return nil, errx.UnsupportedKind(config.Kind)
 	}
@@ -167,10 +277,74 @@ func BuildResourcesObserver(ctx context.Context, broker core.Broker, logger *slo
 }
 
 func BuildEvents(ctx context.Context, logger *slog.Logger, config EventsConfig) (core.Broker, error) {
-	return BuildEventBroker(ctx, logger, config.Broker)
+	broker, err := BuildEventBroker(ctx, logger, config.Broker)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Deduplication.Disabled {
+		return broker, nil
+	}
+
+	window := config.Deduplication.Window
+	if window <= 0 {
+		window = 4096
+	}
+
+	return eventbroker.Deduplicate(broker, window), nil
 }
 
 func BuildEventBroker(ctx context.Context, logger *slog.Logger, cfg EventsBrokerConfig) (core.Broker, error) {
+	switch cfg.Kind {
+	case "nats", "kafka":
+		agent, err := buildBrokerAgent(ctx, logger, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		b := watermill.Broker[core.Event]{
+			Agent:     agent,
+			ItemCodec: codec.JSON[core.Event](),
+			Logger: logger.With(
+				slog.String("component", "broker"),
+			),
+			ConsumerChannelBufferSize: 1024,
+		}
+
+		return eventbroker.WrapCommonBroker(b), nil
+	case "memory":
+		return eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+// BuildRawEventBroker builds a broker that consumes messages published by third parties (raw payloads)
+func BuildRawEventBroker(ctx context.Context, logger *slog.Logger, cfg EventsBrokerConfig) (core.Broker, error) {
+	switch cfg.Kind {
+	case "nats", "kafka":
+		agent, err := buildBrokerAgent(ctx, logger, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		b := watermill.Broker[core.Event]{
+			Agent:          agent,
+			ItemCodec:      codec.JSON[core.Event](),
+			MessageDecoder: eventbroker.DecodeRawEvent,
+			Logger: logger.With(
+				slog.String("component", "raw_broker"),
+			),
+			ConsumerChannelBufferSize: 1024,
+		}
+
+		return eventbroker.WrapCommonBroker(b), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildBrokerAgent(ctx context.Context, logger *slog.Logger, cfg EventsBrokerConfig) (watermill.Agent, error) {
 	switch cfg.Kind {
 	case "nats":
 		natsOptions := []nc.Option{
@@ -225,20 +399,10 @@ func BuildEventBroker(ctx context.Context, logger *slog.Logger, cfg EventsBroker
 			//extractLogger(ctx, slog.Default()),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to nats: %w", err)
+			return watermill.Agent{}, fmt.Errorf("failed to connect to nats: %w", err)
 		}
 
-		b := watermill.Broker[core.Event]{
-			Agent:     agent,
-			ItemCodec: codec.JSON[core.Event](),
-			Logger: logger.With(
-				slog.String("component", "broker"),
-			),
-			//logger:                    extractLogger(ctx, slog.Default()),
-			ConsumerChannelBufferSize: 1024,
-		}
-
-		return eventbroker.WrapCommonBroker(b), nil
+		return agent, nil
 	case "kafka":
 		subscriberConfig := kafka.SubscriberConfig{
 			Unmarshaler: kafka.DefaultMarshaler{},
@@ -257,21 +421,12 @@ func BuildEventBroker(ctx context.Context, logger *slog.Logger, cfg EventsBroker
 			),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to kafka: %w", err)
-		}
-
-		b := watermill.Broker[core.Event]{
-			Agent:     agent,
-			ItemCodec: codec.JSON[core.Event](),
-			Logger: logger.With(
-				slog.String("component", "broker"),
-			),
-			ConsumerChannelBufferSize: 1024,
+			return watermill.Agent{}, fmt.Errorf("failed to connect to kafka: %w", err)
 		}
 
-		return eventbroker.WrapCommonBroker(b), nil
+		return agent, nil
 	default:
-		return nil, errx.UnsupportedKind(cfg.Kind)
+		return watermill.Agent{}, errx.UnsupportedKind(cfg.Kind)
 	}
 }
 
@@ -303,12 +458,41 @@ func BuildSpecifications(ctx context.Context, cfg SpecificationsConfig) (*specif
 				Table: typesTable,
 			},
 		), nil
+	case "memory":
+		return specification.NewManager(
+			specification.NewMemoryBinaryStorage(),
+			specification.NewMemoryBinaryStorage(),
+		), nil
 	default:
 		return nil, errx.UnsupportedKind(cfg.Storage.Kind)
 	}
 }
 
-func BuildHttpApiDeployment(ctx context.Context, sys *core.System, specificationManager *specification.Manager, logger *slog.Logger, cfg HttpApiServiceConfig) (Deployment[*HttpApiService], error) {
+func BuildEventMappings(ctx context.Context, cfg EventMappingsConfig) (*specification.JsonStorage[event.Mapping], error) {
+	switch cfg.Storage.Kind {
+	case "postgres":
+		pool, err := buildPostgres(ctx, cfg.Storage.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		table, err := getOption[string](cfg.Storage.Options, "table")
+		if err != nil {
+			return nil, err
+		}
+
+		return event.NewMappings(&specification.PostgresBinaryStorage{
+			DB:    pool,
+			Table: table,
+		}), nil
+	case "memory":
+		return event.NewMappings(specification.NewMemoryBinaryStorage()), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Storage.Kind)
+	}
+}
+
+func BuildHttpApiDeployment(ctx context.Context, sys *core.System, specificationManager *specification.Manager, eventMappings *specification.JsonStorage[event.Mapping], logger *slog.Logger, cfg HttpApiServiceConfig) (Deployment[*HttpApiService], error) {
 	core, err := BuildServiceCore(ctx, "http-api", logger, cfg.ServiceConfig)
 	if err != nil {
 		return Deployment[*HttpApiService]{}, errx.FailedToBuild("core", err)
@@ -317,6 +501,7 @@ func BuildHttpApiDeployment(ctx context.Context, sys *core.System, specification
 	srvc := &HttpApiService{
 		sys:            sys,
 		specifications: specificationManager,
+		eventMappings:  eventMappings,
 		port:           cfg.Port,
 		loggerEnabled:  cfg.LoggerEnabled,
 		Core:           core,
@@ -336,14 +521,9 @@ func BuildSchedulerDeployment(ctx context.Context, sys *core.System, logger *slo
 		return Deployment[*dependencies.Service]{}, errx.FailedToBuild("core", err)
 	}
 
-	dependencyManager, err := buildDependencyManager(ctx, logger, cfg.Dependencies)
-	if err != nil {
-		return Deployment[*dependencies.Service]{}, errx.FailedToBuild("dependency_manager", err)
-	}
-
-	taskToGroup, err := buildTaskToGroup(ctx, cfg.TaskToGroup)
+	dependencyManager, taskToGroup, err := BuildSchedulerStorages(ctx, logger, cfg)
 	if err != nil {
-		return Deployment[*dependencies.Service]{}, errx.FailedToBuild("task2group", err)
+		return Deployment[*dependencies.Service]{}, err
 	}
 
 	resolvers, err := buildResolvers(ctx, sys, logger, cfg.Resolvers)
@@ -356,6 +536,7 @@ func BuildSchedulerDeployment(ctx context.Context, sys *core.System, logger *slo
 		Dependencies: dependencyManager,
 		TaskToGroup:  taskToGroup,
 		Resolvers:    resolvers,
+		ResultCodec:  codec.JSON[executor.Result](),
 		Logger:       logger.With(slog.String("component", "dependency_manager")),
 		//logger:       extractLogger(ctx, slog.Default()),
 	}
@@ -372,6 +553,31 @@ func BuildSchedulerDeployment(ctx context.Context, sys *core.System, logger *slo
 	}, nil
 }
 
+// BuildSchedulerStorages builds the dependency manager and the task-group bindings used by the scheduler
+func BuildSchedulerStorages(ctx context.Context, logger *slog.Logger, cfg SchedulerServiceConfig) (dependency.Manager, manager2.TaskToGroup, error) {
+	dependencyManager, err := buildDependencyManager(ctx, logger, cfg.Dependencies)
+	if err != nil {
+		return nil, nil, errx.FailedToBuild("dependency_manager", err)
+	}
+
+	taskToGroup, err := buildTaskToGroup(ctx, cfg.TaskToGroup)
+	if err != nil {
+		return nil, nil, errx.FailedToBuild("task2group", err)
+	}
+
+	return dependencyManager, taskToGroup, nil
+}
+
+// BuildSchedulerResolvers builds the dependency resolvers of the scheduler (e.g. for the garbage collector)
+func BuildSchedulerResolvers(ctx context.Context, sys core.AbstractSystem, logger *slog.Logger, cfg SchedulerServiceConfig) (map[string]manager2.Resolver, error) {
+	resolvers, err := buildResolvers(ctx, sys, logger, cfg.Resolvers)
+	if err != nil {
+		return nil, errx.FailedToBuild("resolvers", err)
+	}
+
+	return resolvers, nil
+}
+
 func buildResolvers(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, configs []SchedulerResolverConfig) (map[string]manager2.Resolver, error) {
 	result := make(map[string]manager2.Resolver, len(configs))
 	for _, config := range configs {
@@ -388,6 +594,42 @@ func buildResolvers(ctx context.Context, system core.AbstractSystem, logger *slo
 			}
 
 			result["resource_db"] = resolver
+		case "timer":
+			var timerResolverConfig SchedulerTimerResolverConfig
+			if err := config.Data.Bind(&timerResolverConfig); err != nil {
+				return nil, errx.FailedToBind(err)
+			}
+
+			resolver, err := buildTimerResolver(ctx, logger, timerResolverConfig)
+			if err != nil {
+				return nil, errx.FailedToBuild("timer_resolver", err)
+			}
+
+			result["timer"] = resolver
+		case "task":
+			var taskResolverConfig SchedulerTaskResolverConfig
+			if err := config.Data.Bind(&taskResolverConfig); err != nil {
+				return nil, errx.FailedToBind(err)
+			}
+
+			resolver, err := buildTaskResolver(ctx, system, logger, taskResolverConfig)
+			if err != nil {
+				return nil, errx.FailedToBuild("task_resolver", err)
+			}
+
+			result["task"] = resolver
+		case "signal":
+			var signalResolverConfig SchedulerSignalResolverConfig
+			if err := config.Data.Bind(&signalResolverConfig); err != nil {
+				return nil, errx.FailedToBind(err)
+			}
+
+			resolver, err := buildSignalResolver(ctx, system, logger, signalResolverConfig)
+			if err != nil {
+				return nil, errx.FailedToBuild("signal_resolver", err)
+			}
+
+			result["signal"] = resolver
 		default:
 			return nil, errx.UnsupportedKind(config.Kind)
 		}
@@ -396,17 +638,27 @@ func buildResolvers(ctx context.Context, system core.AbstractSystem, logger *slo
 	return result, nil
 }
 
-func buildResourceResolver(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, cfg SchedulerResourceResolverConfig) (*resourceResolver2.Resolver, error) {
+func buildResourceResolver(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, cfg SchedulerResourceResolverConfig) (manager2.Resolver, error) {
+	if cfg.Topic != "" {
+		return buildPushResourceResolver(ctx, system, logger, cfg)
+	}
+
 	storage, err := buildResourceResolverStorage(ctx, cfg.Storage)
 	if err != nil {
 		return nil, errx.FailedToBuild("resource_resolver_storage", err)
 	}
 
+	notifications, err := buildResourceResolverNotifications(ctx, cfg.Notifications)
+	if err != nil {
+		return nil, errx.FailedToBuild("resource_resolver_notifications", err)
+	}
+
 	resolver := &resourceResolver2.Resolver{
-		System:       system,
-		Storage:      storage,
-		PollLimit:    cfg.Poller.Limit,
-		PollInterval: cfg.Poller.Interval,
+		System:        system,
+		Storage:       storage,
+		PollLimit:     cfg.Poller.Limit,
+		PollInterval:  cfg.Poller.Interval,
+		Notifications: notifications,
 		Logger: logger.With(
 			slog.String("component", "dependency_resolver"),
 			slog.String("component_type", "resource_db"),
@@ -417,10 +669,70 @@ func buildResourceResolver(ctx context.Context, system core.AbstractSystem, logg
 	return resolver, nil
 }
 
+func buildPushResourceResolver(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, cfg SchedulerResourceResolverConfig) (*resourceResolverV2.Resolver, error) {
+	var storage resourceResolverV2.Storage
+	switch cfg.Storage.Kind {
+	case "mongo":
+		mongoStorage, err := buildMongoResourceResolverStorage(ctx, cfg.Storage)
+		if err != nil {
+			return nil, errx.FailedToBuild("resource_resolver_storage", err)
+		}
+
+		storage = &resourceResolverV2.MongoStorage{
+			Collection:  mongoStorage.Collection,
+			PollTimeout: mongoStorage.PollTimeout,
+		}
+	case "memory":
+		storage = resourceResolverV2.NewMemoryStorage()
+	default:
+		return nil, errx.FailedToBuild("resource_resolver_storage", errx.UnsupportedKind(cfg.Storage.Kind))
+	}
+
+	resolver := &resourceResolverV2.Resolver{
+		System:              system,
+		Storage:             storage,
+		ReadyResourcesTopic: cfg.Topic,
+		PollLimit:           cfg.Poller.Limit,
+		PollInterval:        cfg.Poller.Interval,
+		Logger: logger.With(
+			slog.String("component", "dependency_resolver"),
+			slog.String("component_type", "resource_db"),
+		),
+	}
+
+	return resolver, nil
+}
+
 func buildResourceResolverStorage(ctx context.Context, cfg SchedulerResourceResolverStorageConfig) (resourceResolver2.Storage, error) {
 	switch cfg.Kind {
 	case "mongo":
 		return buildMongoResourceResolverStorage(ctx, cfg)
+	case "memory":
+		return resourceResolver2.NewMemoryStorage(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildResourceResolverNotifications(ctx context.Context, cfg SchedulerResourceResolverNotificationsConfig) (resourceResolver2.Notifications, error) {
+	switch cfg.Kind {
+	case "":
+		return nil, nil
+	case "postgres":
+		pool, err := buildPostgres(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		channel, err := getOption[string](cfg.Options, "channel")
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		return &resourcedbpostgres.DB{
+			Client:  pool,
+			Channel: channel,
+		}, nil
 	default:
 		return nil, errx.UnsupportedKind(cfg.Kind)
 	}
@@ -450,6 +762,160 @@ func buildMongoResourceResolverStorage(ctx context.Context, cfg SchedulerResourc
 	}, nil
 }
 
+func buildTimerResolver(ctx context.Context, logger *slog.Logger, cfg SchedulerTimerResolverConfig) (*timerResolver.Resolver, error) {
+	storage, err := buildTimerResolverStorage(ctx, cfg.Storage)
+	if err != nil {
+		return nil, errx.FailedToBuild("timer_resolver_storage", err)
+	}
+
+	resolver := &timerResolver.Resolver{
+		Storage:      storage,
+		PollLimit:    cfg.Poller.Limit,
+		PollInterval: cfg.Poller.Interval,
+		Logger: logger.With(
+			slog.String("component", "dependency_resolver"),
+			slog.String("component_type", "timer"),
+		),
+	}
+
+	return resolver, nil
+}
+
+func buildTimerResolverStorage(ctx context.Context, cfg SchedulerTimerResolverStorageConfig) (timerResolver.Storage, error) {
+	switch cfg.Kind {
+	case "mongo":
+		conn, err := buildMongo(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("mongo", err)
+		}
+
+		var mongoTimerResolverStorageConfig struct {
+			DB         string `yaml:"db,omitempty" json:"db,omitempty"`
+			Collection string `yaml:"collection,omitempty" json:"collection,omitempty"`
+		}
+		dc := DynamicConfig(cfg.Options)
+		if err := dc.Bind(&mongoTimerResolverStorageConfig); err != nil {
+			return nil, errx.FailedToBind(err)
+		}
+
+		return &timerResolver.MongoStorage{
+			Collection: conn.
+				Database(mongoTimerResolverStorageConfig.DB).
+				Collection(mongoTimerResolverStorageConfig.Collection),
+		}, nil
+	case "memory":
+		return timerResolver.NewMemoryStorage(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildTaskResolver(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, cfg SchedulerTaskResolverConfig) (*taskResolver.Resolver, error) {
+	storage, err := buildTaskResolverStorage(ctx, cfg.Storage)
+	if err != nil {
+		return nil, errx.FailedToBuild("task_resolver_storage", err)
+	}
+
+	resolver := &taskResolver.Resolver{
+		System:       system,
+		Storage:      storage,
+		ResultCodec:  codec.JSON[executor.Result](),
+		PollLimit:    cfg.Poller.Limit,
+		PollInterval: cfg.Poller.Interval,
+		Logger: logger.With(
+			slog.String("component", "dependency_resolver"),
+			slog.String("component_type", "task"),
+		),
+	}
+
+	return resolver, nil
+}
+
+func buildTaskResolverStorage(ctx context.Context, cfg SchedulerTaskResolverStorageConfig) (taskResolver.Storage, error) {
+	switch cfg.Kind {
+	case "mongo":
+		conn, err := buildMongo(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("mongo", err)
+		}
+
+		var mongoTaskResolverStorageConfig struct {
+			DB          string        `yaml:"db,omitempty" json:"db,omitempty"`
+			Collection  string        `yaml:"collection,omitempty" json:"collection,omitempty"`
+			PollTimeout time.Duration `yaml:"poll_timeout,omitempty" json:"poll_timeout,omitempty"`
+		}
+		dc := DynamicConfig(cfg.Options)
+		if err := dc.Bind(&mongoTaskResolverStorageConfig); err != nil {
+			return nil, errx.FailedToBind(err)
+		}
+
+		return &taskResolver.MongoStorage{
+			Collection: conn.
+				Database(mongoTaskResolverStorageConfig.DB).
+				Collection(mongoTaskResolverStorageConfig.Collection),
+			PollTimeout: mongoTaskResolverStorageConfig.PollTimeout,
+		}, nil
+	case "memory":
+		return taskResolver.NewMemoryStorage(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildSignalResolver(ctx context.Context, system core.AbstractSystem, logger *slog.Logger, cfg SchedulerSignalResolverConfig) (*signalResolver.Resolver, error) {
+	storage, err := buildSignalResolverStorage(ctx, cfg.Storage)
+	if err != nil {
+		return nil, errx.FailedToBuild("signal_resolver_storage", err)
+	}
+
+	resolver := &signalResolver.Resolver{
+		System:       system,
+		Storage:      storage,
+		PollLimit:    cfg.Poller.Limit,
+		PollInterval: cfg.Poller.Interval,
+		Logger: logger.With(
+			slog.String("component", "dependency_resolver"),
+			slog.String("component_type", "signal"),
+		),
+	}
+
+	return resolver, nil
+}
+
+func buildSignalResolverStorage(ctx context.Context, cfg SchedulerSignalResolverStorageConfig) (signalResolver.Storage, error) {
+	switch cfg.Kind {
+	case "mongo":
+		conn, err := buildMongo(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("mongo", err)
+		}
+
+		var mongoSignalResolverStorageConfig struct {
+			DB                string `yaml:"db,omitempty" json:"db,omitempty"`
+			Collection        string `yaml:"collection,omitempty" json:"collection,omitempty"`
+			SignalsCollection string `yaml:"signals_collection,omitempty" json:"signals_collection,omitempty"`
+		}
+		dc := DynamicConfig(cfg.Options)
+		if err := dc.Bind(&mongoSignalResolverStorageConfig); err != nil {
+			return nil, errx.FailedToBind(err)
+		}
+		if mongoSignalResolverStorageConfig.SignalsCollection == "" {
+			mongoSignalResolverStorageConfig.SignalsCollection = mongoSignalResolverStorageConfig.Collection + "_signals"
+		}
+
+		db := conn.Database(mongoSignalResolverStorageConfig.DB)
+
+		return &signalResolver.MongoStorage{
+			Collection:       db.Collection(mongoSignalResolverStorageConfig.Collection),
+			SignalCollection: db.Collection(mongoSignalResolverStorageConfig.SignalsCollection),
+		}, nil
+	case "memory":
+		return signalResolver.NewMemoryStorage(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
 func buildTaskToGroup(ctx context.Context, cfg SchedulerTaskToGroupConfig) (manager2.TaskToGroup, error) {
 	switch cfg.Kind {
 	case "redis":
@@ -458,13 +924,53 @@ func buildTaskToGroup(ctx context.Context, cfg SchedulerTaskToGroupConfig) (mana
 			return nil, errx.FailedToBuild("redis", err)
 		}
 		return &task2group.RedisStorage{Client: client}, nil
+	case "memory":
+		return task2group.NewMemoryStorage(), nil
 	default:
 		return nil, errx.UnsupportedKind(cfg.Kind)
 	}
 }
 
 func buildDependencyManager(ctx context.Context, logger *slog.Logger, cfg SchedulerDependenciesConfig) (dependency.Manager, error) {
-	return buildBatchedPostgresDependencies(ctx, logger, cfg)
+	switch cfg.Kind {
+	case "", "postgres:batched":
+		return buildBatchedPostgresDependencies(ctx, logger, cfg)
+	case "redis":
+		return buildRedisDependencies(ctx, logger, cfg)
+	case "memory":
+		return dependencyinmem.New(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildRedisDependencies(ctx context.Context, logger *slog.Logger, cfg SchedulerDependenciesConfig) (*dependencyredis.Manager, error) {
+	client, err := buildRedis(ctx, cfg.Redis.URI)
+	if err != nil {
+		return nil, errx.FailedToBuild("redis", err)
+	}
+
+	var options struct {
+		Prefix string        `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+		Lease  time.Duration `yaml:"lease,omitempty" json:"lease,omitempty"`
+	}
+	dc := DynamicConfig(cfg.Redis.Options)
+	if err := dc.Bind(&options); err != nil {
+		return nil, errx.FailedToBind(err)
+	}
+
+	mng := &dependencyredis.Manager{
+		Client:       client,
+		Prefix:       options.Prefix,
+		BlockTimeout: cfg.Poller.Interval,
+		Lease:        options.Lease,
+		Logger: logger.With(
+			slog.String("component", "dependencies"),
+			slog.String("component_type", "redis"),
+		),
+	}
+
+	return mng, nil
 }
 
 func buildBatchedPostgresDependencies(ctx context.Context, logger *slog.Logger, cfg SchedulerDependenciesConfig) (*batched2.Manager, error) {
@@ -515,21 +1021,275 @@ func BuildProcessorDeployment(ctx context.Context, sys *core.System, exe executo
 		return Deployment[*executor.Service]{}, errx.FailedToBuild("core", err)
 	}
 
-	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
-
 	srvc := &executor.Service{
 		System:      sys,
 		ResultCodec: codec.JSON[executor.Result](),
 		Executor:    exe,
+		Concurrency: cfg.Concurrency,
+		Pools:       cfg.Pools,
+		Backlog:     cfg.Backlog,
+		GracePeriod: cfg.GracePeriod,
 		Core:        core,
 	}
 
+	// operators can see the capacity of processors through the discovery
+	middlewares := buildMiddlewaresWithInfo(sys, cfg.ServiceConfig, srvc.Info)
+
 	return Deployment[*executor.Service]{
 		Service:     srvc,
 		Middlewares: middlewares,
 	}, nil
 }
 
+func BuildOutboxRelayDeployment(ctx context.Context, sys *core.System, logger *slog.Logger, cfg OutboxRelayServiceConfig) (Deployment[*outbox.Service], error) {
+	core, err := BuildServiceCore(ctx, "outbox-relay", logger, cfg.ServiceConfig)
+	if err != nil {
+		return Deployment[*outbox.Service]{}, errx.FailedToBuild("core", err)
+	}
+
+	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	delay := cfg.Delay
+	if delay <= 0 {
+		delay = 10 * time.Second
+	}
+
+	srvc := &outbox.Service{
+		System:   sys,
+		Interval: interval,
+		Delay:    delay,
+		PageSize: cfg.PageSize,
+		Core:     core,
+	}
+
+	return Deployment[*outbox.Service]{
+		Service:     srvc,
+		Middlewares: middlewares,
+	}, nil
+}
+
+func BuildRetrierDeployment(ctx context.Context, sys *core.System, logger *slog.Logger, cfg RetrierServiceConfig) (Deployment[*retrier.Service], error) {
+	core, err := BuildServiceCore(ctx, "retrier", logger, cfg.ServiceConfig)
+	if err != nil {
+		return Deployment[*retrier.Service]{}, errx.FailedToBuild("core", err)
+	}
+
+	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	srvc := &retrier.Service{
+		System:      sys,
+		ResultCodec: codec.JSON[executor.Result](),
+		Interval:    interval,
+		Core:        core,
+	}
+
+	return Deployment[*retrier.Service]{
+		Service:     srvc,
+		Middlewares: middlewares,
+	}, nil
+}
+
+func BuildCronDeployment(ctx context.Context, sys *core.System, specifications *specification.Manager, logger *slog.Logger, cfg CronServiceConfig) (Deployment[*cron.Service], error) {
+	core, err := BuildServiceCore(ctx, "cron", logger, cfg.ServiceConfig)
+	if err != nil {
+		return Deployment[*cron.Service]{}, errx.FailedToBuild("core", err)
+	}
+
+	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
+
+	definitions, err := buildCronDefinitions(ctx, cfg.Definitions)
+	if err != nil {
+		return Deployment[*cron.Service]{}, errx.FailedToBuild("definitions", err)
+	}
+
+	lock, ticks, err := buildCronCoordination(ctx, cfg.Coordination)
+	if err != nil {
+		return Deployment[*cron.Service]{}, errx.FailedToBuild("coordination", err)
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	srvc := &cron.Service{
+		System:         sys,
+		Specifications: specifications,
+		Definitions:    definitions,
+		Ticks:          ticks,
+		Lock:           lock,
+		Interval:       interval,
+		Grace:          cfg.Grace,
+		CatchUp:        cfg.CatchUp,
+		Core:           core,
+	}
+
+	return Deployment[*cron.Service]{
+		Service:     srvc,
+		Middlewares: middlewares,
+	}, nil
+}
+
+func buildCronDefinitions(ctx context.Context, cfg CronStorageConfig) (*specification.JsonStorage[cron.Definition], error) {
+	switch cfg.Kind {
+	case "postgres":
+		pool, err := buildPostgres(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("postgres", err)
+		}
+
+		table, err := getOption[string](cfg.Options, "table")
+		if err != nil {
+			return nil, err
+		}
+
+		return cron.NewDefinitions(&specification.PostgresBinaryStorage{
+			DB:    pool,
+			Table: table,
+		}), nil
+	case "memory":
+		return cron.NewDefinitions(specification.NewMemoryBinaryStorage()), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildCronCoordination(ctx context.Context, cfg CronStorageConfig) (cron.Lock, cron.TickStorage, error) {
+	switch cfg.Kind {
+	case "redis":
+		client, err := buildRedis(ctx, cfg.URI)
+		if err != nil {
+			return nil, nil, errx.FailedToBuild("redis", err)
+		}
+
+		keyPrefix := "cron"
+		if prefix, err := getOption[string](cfg.Options, "key_prefix"); err == nil {
+			keyPrefix = prefix
+		}
+
+		return &cron.RedisLock{Client: client, Key: keyPrefix + ":lock"},
+			&cron.RedisTickStorage{Client: client, Key: keyPrefix + ":ticks"},
+			nil
+	case "memory":
+		return &cron.MemoryLock{}, cron.NewMemoryTickStorage(), nil
+	default:
+		return nil, nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func buildEventSpawnerClaims(ctx context.Context, cfg EventSpawnerClaimsConfig) (event.Claims, error) {
+	switch cfg.Kind {
+	case "redis":
+		client, err := buildRedis(ctx, cfg.URI)
+		if err != nil {
+			return nil, errx.FailedToBuild("redis", err)
+		}
+
+		keyPrefix := "event_spawner"
+		if prefix, err := getOption[string](cfg.Options, "key_prefix"); err == nil {
+			keyPrefix = prefix
+		}
+
+		return &event.RedisClaims{Client: client, Prefix: keyPrefix + ":claims"}, nil
+	case "memory":
+		return event.NewMemoryClaims(), nil
+	default:
+		return nil, errx.UnsupportedKind(cfg.Kind)
+	}
+}
+
+func BuildEventSpawnerDeployment(ctx context.Context, sys *core.System, specifications *specification.Manager, mappings *specification.JsonStorage[event.Mapping], logger *slog.Logger, cfg EventSpawnerServiceConfig) (Deployment[*event.Service], error) {
+	core, err := BuildServiceCore(ctx, "event-spawner", logger, cfg.ServiceConfig)
+	if err != nil {
+		return Deployment[*event.Service]{}, errx.FailedToBuild("core", err)
+	}
+
+	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	claims, err := buildEventSpawnerClaims(ctx, cfg.Claims)
+	if err != nil {
+		return Deployment[*event.Service]{}, errx.FailedToBuild("claims", err)
+	}
+
+	srvc := &event.Service{
+		System:         sys,
+		Specifications: specifications,
+		Mappings:       mappings,
+		Claims:         claims,
+		ClaimTTL:       cfg.ClaimTTL,
+		Interval:       interval,
+		Core:           core,
+	}
+
+	if cfg.Broker.Kind != "" {
+		srvc.Events, err = BuildRawEventBroker(ctx, logger, cfg.Broker)
+		if err != nil {
+			return Deployment[*event.Service]{}, errx.FailedToBuild("broker", err)
+		}
+	}
+
+	return Deployment[*event.Service]{
+		Service:     srvc,
+		Middlewares: middlewares,
+	}, nil
+}
+
+func BuildGCDeployment(ctx context.Context, sys *core.System, dependencyManager dependency.Manager, taskToGroup manager2.TaskToGroup, resolvers map[string]manager2.Resolver, logger *slog.Logger, cfg GCServiceConfig) (Deployment[*gc.Service], error) {
+	core, err := BuildServiceCore(ctx, "gc", logger, cfg.ServiceConfig)
+	if err != nil {
+		return Deployment[*gc.Service]{}, errx.FailedToBuild("core", err)
+	}
+
+	middlewares := buildMiddlewares(sys, cfg.ServiceConfig)
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	policies := make([]gc.Policy, 0, len(cfg.Policies))
+	for _, policy := range cfg.Policies {
+		policies = append(policies, gc.Policy{
+			Type:      policy.Type,
+			Retention: policy.Retention,
+		})
+	}
+
+	srvc := &gc.Service{
+		System:                sys,
+		Dependencies:          dependencyManager,
+		TaskToGroup:           taskToGroup,
+		Resolvers:             resolvers,
+		Retention:             cfg.Retention,
+		Policies:              policies,
+		Interval:              interval,
+		DryRun:                cfg.DryRun,
+		PageSize:              cfg.PageSize,
+		UninitializedGroupAge: cfg.UninitializedGroupAge,
+		Core:                  core,
+	}
+
+	return Deployment[*gc.Service]{
+		Service:     srvc,
+		Middlewares: middlewares,
+	}, nil
+}
+
 func BuildDiscoveryDeployment(ctx context.Context, sys *core.System, logger *slog.Logger, cfg DiscoveryServiceConfig) (Deployment[*discovery.Poller], error) {
 	core, err := BuildServiceCore(ctx, "discovery", logger, cfg.ServiceConfig)
 	if err != nil {
@@ -631,15 +1391,34 @@ func BuildPrettySlogHandler(w io.Writer, level slog.Level) slog.Handler {
 }
 
 func buildMiddlewares(sys *core.System, cfg ServiceConfig) []service.Middleware {
+	return buildMiddlewaresWithInfo(sys, cfg, nil)
+}
+
+// buildMiddlewaresWithInfo adds the info reported by the service to the static discovery info
+func buildMiddlewaresWithInfo(sys *core.System, cfg ServiceConfig, info func() map[string]any) []service.Middleware {
 	var middlewares []service.Middleware
 
 	if cfg.Discovery.Enabled {
-		middlewares = append(middlewares, discovery.WithStaticInfo[service.Service](
-			cfg.Discovery.Info,
-			sys.Events(),
-			codec.JSON[discovery.Request](),
-			codec.JSON[discovery.Response](),
-		))
+		middlewares = append(middlewares, discovery.Middleware[service.Service]{
+			InfoProvider: func(ctx context.Context, srvc service.Service) (map[string]any, error) {
+				if info == nil {
+					return cfg.Discovery.Info, nil
+				}
+
+				result := map[string]any{}
+				for key, value := range cfg.Discovery.Info {
+					result[key] = value
+				}
+				for key, value := range info() {
+					result[key] = value
+				}
+
+				return result, nil
+			},
+			Events:        sys.Events(),
+			RequestCodec:  codec.JSON[discovery.Request](),
+			ResponseCodec: codec.JSON[discovery.Response](),
+		})
 	}
 
 	middlewares = append(middlewares, loggingMiddleware{})
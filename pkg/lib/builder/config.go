@@ -28,9 +28,20 @@ type TasksStorageConfig struct {
 
 type ResourcesConfig struct {
 	Storage   ResourcesStorageConfig    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Blobs     ResourcesBlobsConfig      `yaml:"blobs,omitempty" json:"blobs,omitempty"`
 	Observers []ResourcesObserverConfig `yaml:"observers,omitempty" json:"observers,omitempty"`
 }
 
+// ResourcesBlobsConfig configures the store of large payloads (they are kept in the storage if Kind is empty)
+type ResourcesBlobsConfig struct {
+	// Kind is "filesystem" (URI is a directory) or "s3" (URI is an endpoint)
+	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI  string `yaml:"uri,omitempty" json:"uri,omitempty"`
+	// Threshold is the size (in bytes) of payloads spilled to the blob store (1MiB by default)
+	Threshold int            `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	Options   map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
 type ResourcesStorageConfig struct {
 	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
 	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
@@ -43,7 +54,13 @@ type ResourcesObserverConfig struct {
 }
 
 type EventsConfig struct {
-	Broker EventsBrokerConfig `yaml:"broker,omitempty" json:"broker,omitempty"`
+	Broker        EventsBrokerConfig        `yaml:"broker,omitempty" json:"broker,omitempty"`
+	Deduplication EventsDeduplicationConfig `yaml:"deduplication,omitempty" json:"deduplication,omitempty"`
+}
+
+type EventsDeduplicationConfig struct {
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Window   int  `yaml:"window,omitempty" json:"window,omitempty"`
 }
 
 type EventsBrokerConfig struct {
@@ -62,6 +79,16 @@ type SpecificationsStorageConfig struct {
 	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
 }
 
+type EventMappingsConfig struct {
+	Storage EventMappingsStorageConfig `yaml:"storage,omitempty" json:"storage,omitempty"`
+}
+
+type EventMappingsStorageConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
 type HttpApiServiceConfig struct {
 	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
 	Port          int           `yaml:"port,omitempty" json:"port,omitempty"`
@@ -82,6 +109,8 @@ type SchedulerTaskToGroupConfig struct {
 }
 
 type SchedulerDependenciesConfig struct {
+	// Kind is "postgres:batched" (default), "redis" or "memory"
+	Kind   string `yaml:"kind,omitempty" json:"kind,omitempty"`
 	Poller struct {
 		Interval  time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
 		BatchSize int           `yaml:"batch_size,omitempty" json:"batch_size,omitempty"`
@@ -90,6 +119,10 @@ type SchedulerDependenciesConfig struct {
 		URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
 		Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
 	} `yaml:"postgres,omitempty" json:"postgres,omitempty"`
+	Redis struct {
+		URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+		Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+	} `yaml:"redis,omitempty" json:"redis,omitempty"`
 }
 
 type SchedulerResolverConfig struct {
@@ -99,11 +132,15 @@ type SchedulerResolverConfig struct {
 
 type SchedulerResourceResolverConfig struct {
 	Storage SchedulerResourceResolverStorageConfig `yaml:"storage,omitempty" json:"storage,omitempty"`
-	Poller  struct {
+	// Notifications about ready resources make the resolver poll without waiting for the interval
+	Notifications SchedulerResourceResolverNotificationsConfig `yaml:"notifications,omitempty" json:"notifications,omitempty"`
+	Poller        struct {
 		Limit    int           `yaml:"limit,omitempty" json:"limit,omitempty"`
 		Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
 	} `yaml:"poller,omitempty" json:"poller,omitempty"`
-	//Topic string `yaml:"topic,omitempty" json:"topic,omitempty"`
+	// Topic of initialized resources (see the "notifier" resources observer).
+	// If set, dependencies are resolved on events and polling is only a fallback for missed ones.
+	Topic string `yaml:"topic,omitempty" json:"topic,omitempty"`
 }
 
 type SchedulerResourceResolverStorageConfig struct {
@@ -112,13 +149,126 @@ type SchedulerResourceResolverStorageConfig struct {
 	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
 }
 
+type SchedulerResourceResolverNotificationsConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type SchedulerTimerResolverConfig struct {
+	Storage SchedulerTimerResolverStorageConfig `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Poller  struct {
+		Limit    int           `yaml:"limit,omitempty" json:"limit,omitempty"`
+		Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	} `yaml:"poller,omitempty" json:"poller,omitempty"`
+}
+
+type SchedulerTimerResolverStorageConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type SchedulerTaskResolverConfig struct {
+	Storage SchedulerTaskResolverStorageConfig `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Poller  struct {
+		Limit    int           `yaml:"limit,omitempty" json:"limit,omitempty"`
+		Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	} `yaml:"poller,omitempty" json:"poller,omitempty"`
+}
+
+type SchedulerTaskResolverStorageConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type SchedulerSignalResolverConfig struct {
+	Storage SchedulerSignalResolverStorageConfig `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Poller  struct {
+		Limit    int           `yaml:"limit,omitempty" json:"limit,omitempty"`
+		Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	} `yaml:"poller,omitempty" json:"poller,omitempty"`
+}
+
+type SchedulerSignalResolverStorageConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
 type StatusServiceConfig struct {
 	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
 }
 
 type ProcessorServiceConfig struct {
+	ServiceConfig ServiceConfig  `yaml:"$,omitempty" json:"$,omitempty"`
+	Kind          string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Concurrency   int            `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	Pools         map[string]int `yaml:"pools,omitempty" json:"pools,omitempty"`
+	Backlog       int            `yaml:"backlog,omitempty" json:"backlog,omitempty"`
+	GracePeriod   time.Duration  `yaml:"grace_period,omitempty" json:"grace_period,omitempty"`
+}
+
+type OutboxRelayServiceConfig struct {
+	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
+	Interval      time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Delay         time.Duration `yaml:"delay,omitempty" json:"delay,omitempty"`
+	PageSize      int           `yaml:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+type RetrierServiceConfig struct {
+	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
+	Interval      time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+}
+
+type CronServiceConfig struct {
+	ServiceConfig ServiceConfig     `yaml:"$,omitempty" json:"$,omitempty"`
+	Interval      time.Duration     `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Grace         time.Duration     `yaml:"grace,omitempty" json:"grace,omitempty"`
+	CatchUp       string            `yaml:"catch_up,omitempty" json:"catch_up,omitempty"`
+	Definitions   CronStorageConfig `yaml:"definitions,omitempty" json:"definitions,omitempty"`
+	Coordination  CronStorageConfig `yaml:"coordination,omitempty" json:"coordination,omitempty"`
+}
+
+type CronStorageConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type EventSpawnerServiceConfig struct {
 	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
-	Kind          string        `yaml:"kind,omitempty" json:"kind,omitempty"`
+	// Interval between reloads of mappings
+	Interval time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	// Broker of external events (raw payloads), events of the system are consumed if not set
+	Broker   EventsBrokerConfig       `yaml:"broker,omitempty" json:"broker,omitempty"`
+	Claims   EventSpawnerClaimsConfig `yaml:"claims,omitempty" json:"claims,omitempty"`
+	ClaimTTL time.Duration            `yaml:"claim_ttl,omitempty" json:"claim_ttl,omitempty"`
+}
+
+type EventSpawnerClaimsConfig struct {
+	Kind    string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	URI     string         `yaml:"uri,omitempty" json:"uri,omitempty"`
+	Options map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type GCServiceConfig struct {
+	ServiceConfig ServiceConfig `yaml:"$,omitempty" json:"$,omitempty"`
+	Interval      time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	// Retention of tasks that don't match any policy (such tasks are kept forever if not set)
+	Retention time.Duration    `yaml:"retention,omitempty" json:"retention,omitempty"`
+	Policies  []GCPolicyConfig `yaml:"policies,omitempty" json:"policies,omitempty"`
+	DryRun    bool             `yaml:"dry_run,omitempty" json:"dry_run,omitempty"`
+	// PageSize is the maximal amount of finished tasks looked at by a collection
+	PageSize int `yaml:"page_size,omitempty" json:"page_size,omitempty"`
+	// UninitializedGroupAge is the age of dependency groups that are deleted if they are still not initialized
+	UninitializedGroupAge time.Duration `yaml:"uninitialized_group_age,omitempty" json:"uninitialized_group_age,omitempty"`
+}
+
+type GCPolicyConfig struct {
+	Type      string        `yaml:"type,omitempty" json:"type,omitempty"`
+	Retention time.Duration `yaml:"retention,omitempty" json:"retention,omitempty"`
 }
 
 type DiscoveryServiceConfig struct {
@@ -151,14 +301,20 @@ type Config struct {
 type CoreConfig struct {
 	System         SystemConfig         `yaml:"system,omitempty" json:"system,omitempty"`
 	Specifications SpecificationsConfig `yaml:"specifications,omitempty" json:"specifications,omitempty"`
+	EventMappings  EventMappingsConfig  `yaml:"event_mappings,omitempty" json:"event_mappings,omitempty"`
 }
 
 type ServicesConfig struct {
-	HttpApi   HttpApiServiceConfig   `yaml:"http_api,omitempty" json:"http_api,omitempty"`
-	Scheduler SchedulerServiceConfig `yaml:"scheduler,omitempty" json:"scheduler,omitempty"`
-	Status    StatusServiceConfig    `yaml:"status,omitempty" json:"status,omitempty"`
-	Processor ProcessorServiceConfig `yaml:"processor,omitempty" json:"processor,omitempty"`
-	Discovery DiscoveryServiceConfig `yaml:"discovery,omitempty" json:"discovery,omitempty"`
+	HttpApi      HttpApiServiceConfig      `yaml:"http_api,omitempty" json:"http_api,omitempty"`
+	Scheduler    SchedulerServiceConfig    `yaml:"scheduler,omitempty" json:"scheduler,omitempty"`
+	Status       StatusServiceConfig       `yaml:"status,omitempty" json:"status,omitempty"`
+	Processor    ProcessorServiceConfig    `yaml:"processor,omitempty" json:"processor,omitempty"`
+	Discovery    DiscoveryServiceConfig    `yaml:"discovery,omitempty" json:"discovery,omitempty"`
+	OutboxRelay  OutboxRelayServiceConfig  `yaml:"outbox_relay,omitempty" json:"outbox_relay,omitempty"`
+	Retrier      RetrierServiceConfig      `yaml:"retrier,omitempty" json:"retrier,omitempty"`
+	Cron         CronServiceConfig         `yaml:"cron,omitempty" json:"cron,omitempty"`
+	EventSpawner EventSpawnerServiceConfig `yaml:"event_spawner,omitempty" json:"event_spawner,omitempty"`
+	GC           GCServiceConfig           `yaml:"gc,omitempty" json:"gc,omitempty"`
 }
 
 func FromFile(path string) (config Config, err error) {
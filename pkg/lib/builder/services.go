@@ -2,16 +2,19 @@ package builder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/common/service"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp"
 	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
+	"net/http"
 )
 
 type Deployment[S service.Service] struct {
@@ -22,6 +25,7 @@ type Deployment[S service.Service] struct {
 type HttpApiService struct {
 	sys            core.AbstractSystem
 	specifications *specification.Manager
+	eventMappings  *specification.JsonStorage[event.Mapping]
 	port           int
 	loggerEnabled  bool
 	service.Core
@@ -31,6 +35,7 @@ func (srvc HttpApiService) Run(ctx context.Context) error {
 	srv := kantokuhttp.NewServer(
 		srvc.sys,
 		srvc.specifications,
+		srvc.eventMappings,
 	)
 
 	e := echo.New()
@@ -42,7 +47,15 @@ func (srvc HttpApiService) Run(ctx context.Context) error {
 
 	oas.RegisterHandlers(e, oas.NewStrictHandler(srv, nil))
 
-	if err := e.Start(fmt.Sprintf(":%d", srvc.port)); err != nil {
+	go func() {
+		<-ctx.Done()
+		if err := e.Shutdown(context.Background()); err != nil {
+			srvc.Logger().Error("failed to shutdown the server",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	if err := e.Start(fmt.Sprintf(":%d", srvc.port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 
@@ -0,0 +1,193 @@
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"github.com/ischenkx/kantoku/pkg/lib/builder/errx"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/cron"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/gc"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"log/slog"
+	"time"
+)
+
+// DefaultConfig returns a config in which every component lives in the current process.
+//
+// Nothing is persisted, so it is meant for local development and tests.
+func DefaultConfig() builder.Config {
+	var cfg builder.Config
+
+	cfg.Core.System.Tasks.Storage.Kind = "memory"
+	cfg.Core.System.Resources.Storage.Kind = "memory"
+	cfg.Core.System.Events.Broker.Kind = "memory"
+	cfg.Core.Specifications.Storage.Kind = "memory"
+	cfg.Core.EventMappings.Storage.Kind = "memory"
+
+	cfg.Services.Scheduler.TaskToGroup.Kind = "memory"
+	cfg.Services.Scheduler.Dependencies.Kind = "memory"
+	cfg.Services.Scheduler.Resolvers = []builder.SchedulerResolverConfig{
+		{
+			Kind: "resource_db",
+			Data: builder.DynamicConfig{
+				"storage": map[string]any{
+					"kind": "memory",
+				},
+				"poller": map[string]any{
+					"interval": "500ms",
+				},
+			},
+		},
+		{
+			Kind: "timer",
+			Data: builder.DynamicConfig{
+				"storage": map[string]any{
+					"kind": "memory",
+				},
+				"poller": map[string]any{
+					"interval": "100ms",
+				},
+			},
+		},
+		{
+			Kind: "task",
+			Data: builder.DynamicConfig{
+				"storage": map[string]any{
+					"kind": "memory",
+				},
+				"poller": map[string]any{
+					"interval": "5s",
+				},
+			},
+		},
+		{
+			Kind: "signal",
+			Data: builder.DynamicConfig{
+				"storage": map[string]any{
+					"kind": "memory",
+				},
+				"poller": map[string]any{
+					"interval": "1s",
+				},
+			},
+		},
+	}
+
+	cfg.Services.HttpApi.Port = 8585
+	cfg.Services.OutboxRelay.Interval = time.Second
+	cfg.Services.Retrier.Interval = 500 * time.Millisecond
+	cfg.Services.Cron.Definitions.Kind = "memory"
+	cfg.Services.Cron.Coordination.Kind = "memory"
+	cfg.Services.EventSpawner.Interval = 500 * time.Millisecond
+	cfg.Services.EventSpawner.Claims.Kind = "memory"
+	cfg.Services.GC.Interval = time.Minute
+
+	return cfg
+}
+
+// Instance is a kantoku deployment running in a single process:
+// the scheduler, the status service, the processor, the outbox relay, the retrier, the cron and event spawners,
+// the garbage collector and (optionally) the http api.
+type Instance struct {
+	System         *core.System
+	Specifications *specification.Manager
+	Cron           *cron.Service
+	EventSpawner   *event.Service
+	GC             *gc.Service
+
+	deployer *service.Deployer
+}
+
+// New builds an instance from the config (see DefaultConfig).
+// The http api is not deployed if its port is not set.
+func New(ctx context.Context, logger *slog.Logger, cfg builder.Config, exe executor.Executor) (*Instance, error) {
+	sys, specifications, err := builder.BuildCore(ctx, logger, cfg.Core)
+	if err != nil {
+		return nil, errx.FailedToBuild("core", err)
+	}
+
+	eventMappings, err := builder.BuildEventMappings(ctx, cfg.Core.EventMappings)
+	if err != nil {
+		return nil, errx.FailedToBuild("event_mappings", err)
+	}
+
+	deployer := service.NewDeployer()
+
+	scheduler, err := builder.BuildSchedulerDeployment(ctx, sys, logger, cfg.Services.Scheduler)
+	if err != nil {
+		return nil, errx.FailedToBuild("scheduler", err)
+	}
+	deployer.Add(scheduler.Service, scheduler.Middlewares...)
+
+	status, err := builder.BuildStatusDeployment(ctx, sys, logger, cfg.Services.Status)
+	if err != nil {
+		return nil, errx.FailedToBuild("status", err)
+	}
+	deployer.Add(status.Service, status.Middlewares...)
+
+	processor, err := builder.BuildProcessorDeployment(ctx, sys, exe, logger, cfg.Services.Processor)
+	if err != nil {
+		return nil, errx.FailedToBuild("processor", err)
+	}
+	deployer.Add(processor.Service, processor.Middlewares...)
+
+	relay, err := builder.BuildOutboxRelayDeployment(ctx, sys, logger, cfg.Services.OutboxRelay)
+	if err != nil {
+		return nil, errx.FailedToBuild("outbox_relay", err)
+	}
+	deployer.Add(relay.Service, relay.Middlewares...)
+
+	retrier, err := builder.BuildRetrierDeployment(ctx, sys, logger, cfg.Services.Retrier)
+	if err != nil {
+		return nil, errx.FailedToBuild("retrier", err)
+	}
+	deployer.Add(retrier.Service, retrier.Middlewares...)
+
+	cronSpawner, err := builder.BuildCronDeployment(ctx, sys, specifications, logger, cfg.Services.Cron)
+	if err != nil {
+		return nil, errx.FailedToBuild("cron", err)
+	}
+	deployer.Add(cronSpawner.Service, cronSpawner.Middlewares...)
+
+	eventSpawner, err := builder.BuildEventSpawnerDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.EventSpawner)
+	if err != nil {
+		return nil, errx.FailedToBuild("event_spawner", err)
+	}
+	deployer.Add(eventSpawner.Service, eventSpawner.Middlewares...)
+
+	collector, err := builder.BuildGCDeployment(ctx, sys, scheduler.Service.Manager.Dependencies, scheduler.Service.Manager.TaskToGroup, scheduler.Service.Manager.Resolvers, logger, cfg.Services.GC)
+	if err != nil {
+		return nil, errx.FailedToBuild("gc", err)
+	}
+	deployer.Add(collector.Service, collector.Middlewares...)
+
+	if cfg.Services.HttpApi.Port > 0 {
+		api, err := builder.BuildHttpApiDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.HttpApi)
+		if err != nil {
+			return nil, errx.FailedToBuild("http_api", err)
+		}
+		deployer.Add(api.Service, api.Middlewares...)
+	}
+
+	return &Instance{
+		System:         sys,
+		Specifications: specifications,
+		Cron:           cronSpawner.Service,
+		EventSpawner:   eventSpawner.Service,
+		GC:             collector.Service,
+		deployer:       deployer,
+	}, nil
+}
+
+// Run blocks until the context is cancelled or one of the services fails
+func (instance *Instance) Run(ctx context.Context) error {
+	if err := instance.deployer.Deploy(ctx); err != nil {
+		return fmt.Errorf("failed to deploy: %w", err)
+	}
+
+	return nil
+}
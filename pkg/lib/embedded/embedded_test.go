@@ -0,0 +1,445 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/exe"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type noopExecutor struct{}
+
+func (noopExecutor) Execute(ctx context.Context, sys core.AbstractSystem, task core.Task) error {
+	return nil
+}
+
+type blockingExecutor struct {
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func (exe blockingExecutor) Execute(ctx context.Context, sys core.AbstractSystem, task core.Task) error {
+	close(exe.started)
+	<-ctx.Done()
+	close(exe.cancelled)
+	return ctx.Err()
+}
+
+// hungExecutor ignores the context
+type hungExecutor struct {
+	release chan struct{}
+}
+
+func (exe hungExecutor) Execute(ctx context.Context, sys core.AbstractSystem, task core.Task) error {
+	<-exe.release
+	return nil
+}
+
+// flakyExecutor fails the first failures executions
+type flakyExecutor struct {
+	failures  int32
+	execution *atomic.Int32
+}
+
+func (exe flakyExecutor) Execute(ctx context.Context, sys core.AbstractSystem, task core.Task) error {
+	if exe.execution.Add(1) <= exe.failures {
+		return errors.New("flaky failure")
+	}
+	return nil
+}
+
+func TestTaskIsExecuted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	router := exe.NewRouter()
+	router.AddExecutor(noopExecutor{}, "noop")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	task, err := instance.System.Spawn(ctx, core.New(func(task *core.Task) {
+		task.Info["type"] = "noop"
+	}))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	for {
+		stored, err := instance.System.Task(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("failed to load the task: %s", err)
+		}
+
+		if stored.Info["status"] == core.TaskStatuses.Finished {
+			if stored.Info["sub_status"] != core.TaskSubStatuses.OK {
+				t.Fatalf("unexpected sub status: %v", stored.Info["sub_status"])
+			}
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("the task has not been executed (status=%v)", stored.Info["status"])
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestRunningTaskIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	executor := blockingExecutor{
+		started:   make(chan struct{}),
+		cancelled: make(chan struct{}),
+	}
+
+	router := exe.NewRouter()
+	router.AddExecutor(executor, "blocking")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	task, err := instance.System.Spawn(ctx, core.New(func(task *core.Task) {
+		task.Info["type"] = "blocking"
+	}))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	select {
+	case <-executor.started:
+	case <-ctx.Done():
+		t.Fatalf("the task has not been started")
+	}
+
+	if err := instance.System.Cancel(ctx, task.ID, "test"); err != nil {
+		t.Fatalf("failed to cancel: %s", err)
+	}
+
+	select {
+	case <-executor.cancelled:
+	case <-ctx.Done():
+		t.Fatalf("the running task has not been cancelled")
+	}
+
+	stored, err := instance.System.Task(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("failed to load the task: %s", err)
+	}
+	if stored.Status() != core.TaskStatuses.Cancelled {
+		t.Fatalf("expected the task to be cancelled, got '%s'", stored.Status())
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestHungTaskTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	executor := hungExecutor{release: make(chan struct{})}
+
+	router := exe.NewRouter()
+	router.AddExecutor(executor, "hung")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+	cfg.Services.Processor.GracePeriod = 100 * time.Millisecond
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	task, err := instance.System.Spawn(ctx, core.New(
+		taskopts.WithType("hung"),
+		taskopts.WithTimeout(100*time.Millisecond),
+	))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	for {
+		stored, err := instance.System.Task(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("failed to load the task: %s", err)
+		}
+
+		if stored.Status() == core.TaskStatuses.Finished {
+			if stored.SubStatus() != core.TaskSubStatuses.TimedOut {
+				t.Fatalf("expected the task to time out, got '%s'", stored.SubStatus())
+			}
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("the task has not timed out (status=%s)", stored.Status())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// the task is reported after the grace period, but the worker is kept until the executor returns
+	close(executor.release)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestSlowTasksDoNotStarveOthers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	slow := hungExecutor{release: make(chan struct{})}
+
+	router := exe.NewRouter()
+	router.AddExecutor(slow, "slow")
+	router.AddExecutor(noopExecutor{}, "noop")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+	cfg.Services.Processor.Concurrency = 1
+	cfg.Services.Processor.Pools = map[string]int{"slow": 1}
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	// the slow pool is exhausted, the second slow task waits in its backlog
+	for i := 0; i < 2; i++ {
+		if _, err := instance.System.Spawn(ctx, core.New(taskopts.WithType("slow"))); err != nil {
+			t.Fatalf("failed to spawn: %s", err)
+		}
+	}
+
+	task, err := instance.System.Spawn(ctx, core.New(taskopts.WithType("noop")))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	for {
+		stored, err := instance.System.Task(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("failed to load the task: %s", err)
+		}
+
+		if stored.Status() == core.TaskStatuses.Finished {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("the task has not been executed (status=%s)", stored.Status())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// workers are not released until their executors return
+	close(slow.release)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestFailedTaskIsRetried(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	router := exe.NewRouter()
+	router.AddExecutor(flakyExecutor{failures: 2, execution: &atomic.Int32{}}, "flaky")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+	cfg.Services.Retrier.Interval = 50 * time.Millisecond
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	task, err := instance.System.Spawn(ctx, core.New(
+		taskopts.WithType("flaky"),
+		taskopts.WithRetryPolicy(core.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 10 * time.Millisecond,
+			Jitter:         0.5,
+		}),
+	))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	for {
+		// the last attempt is the only one that is not restarted
+		retries, err := instance.System.Tasks().GetWithProperties(ctx, map[string][]any{
+			"info.restart_root": {task.ID},
+			"info.status":       {core.TaskStatuses.Finished},
+			"info.restarted_by": {nil},
+		})
+		if err != nil {
+			t.Fatalf("failed to load retries: %s", err)
+		}
+
+		if len(retries) == 1 && retries[0].RetryAttempt() == 3 {
+			if retries[0].SubStatus() != core.TaskSubStatuses.OK {
+				t.Fatalf("expected the last attempt to succeed, got '%s'", retries[0].SubStatus())
+			}
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("the task has not been retried")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestDelayedTaskIsNotExecutedEarly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	router := exe.NewRouter()
+	router.AddExecutor(noopExecutor{}, "noop")
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, router)
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- instance.Run(ctx) }()
+
+	notBefore := time.Now().Add(2 * time.Second)
+
+	task, err := instance.System.Spawn(ctx, core.New(
+		taskopts.WithType("noop"),
+		taskopts.WithNotBefore(notBefore),
+	))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	for {
+		stored, err := instance.System.Task(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("failed to load the task: %s", err)
+		}
+
+		if stored.Status() == core.TaskStatuses.Finished {
+			if time.Now().Before(notBefore) {
+				t.Fatalf("the task has been executed too early")
+			}
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("the task has not been executed (status=%s)", stored.Status())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %s", err)
+	}
+}
+
+func TestSpecificationDefaultsAreApplied(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := DefaultConfig()
+	cfg.Services.HttpApi.Port = 0
+
+	instance, err := New(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, exe.NewRouter())
+	if err != nil {
+		t.Fatalf("failed to build: %s", err)
+	}
+
+	err = instance.Specifications.Specifications().Add(ctx, specification.Specification{
+		ID:   "limited",
+		Meta: map[string]any{"timeout": "1m"},
+	})
+	if err != nil {
+		t.Fatalf("failed to add a specification: %s", err)
+	}
+
+	// the default is applied to tasks spawned directly, not only through specification.Spawn
+	task, err := instance.System.Spawn(ctx, core.New(taskopts.WithType("limited")))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	if timeout, ok, _ := task.Timeout(); !ok || timeout != time.Minute {
+		t.Fatalf("expected the default timeout, got %s (%t)", timeout, ok)
+	}
+
+	// an explicit timeout is kept
+	task, err = instance.System.Spawn(ctx, core.New(taskopts.WithType("limited"), taskopts.WithTimeout(time.Second)))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	if timeout, _, _ := task.Timeout(); timeout != time.Second {
+		t.Fatalf("expected the explicit timeout, got %s", timeout)
+	}
+
+	_, err = instance.System.Spawn(ctx, core.New(taskopts.WithProperty("timeout", "soon")))
+	if !errors.Is(err, core.ErrInvalidTimeout) {
+		t.Fatalf("expected ErrInvalidTimeout, got: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/samber/lo"
 	"net/http"
 )
 
@@ -28,6 +29,10 @@ func (client *Client) Types() *SpecificationStorage {
 	return &SpecificationStorage{client.httpClient}
 }
 
+func (client *Client) EventMappings() *EventMappingStorage {
+	return &EventMappingStorage{client.httpClient}
+}
+
 func (client *Client) Tasks() core.TaskDB {
 	return taskStorage{client.httpClient}
 }
@@ -62,6 +67,96 @@ func (client *Client) Spawn(ctx context.Context, t core.Task) (core.Task, error)
 	}
 }
 
+func (client *Client) SpawnBatch(ctx context.Context, tasks []core.Task) ([]core.Task, error) {
+	res, err := client.httpClient.PostTasksSpawnBatchWithResponse(ctx, lo.Map(tasks, func(t core.Task, _ int) oas.TaskParameters {
+		return oas.TaskParameters{
+			Info:    t.Info,
+			Inputs:  t.Inputs,
+			Outputs: t.Outputs,
+		}
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to make an http request: %w", err)
+	}
+
+	code := res.StatusCode()
+
+	switch code {
+	case http.StatusOK:
+		ids := lo.Map(*res.JSON200, func(r oas.TaskSpawnResponse, _ int) string {
+			return r.Id
+		})
+		loaded, err := client.Tasks().ByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load spawned tasks: %w", err)
+		}
+
+		// keeping the order of the input tasks
+		id2task := lo.KeyBy(loaded, func(t core.Task) string { return t.ID })
+		spawned := make([]core.Task, 0, len(ids))
+		for _, id := range ids {
+			t, ok := id2task[id]
+			if !ok {
+				return nil, fmt.Errorf("spawned task not found: %s", id)
+			}
+			spawned = append(spawned, t)
+		}
+
+		return spawned, nil
+	case http.StatusInternalServerError:
+		return nil, fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response code: %d", code)
+	}
+}
+
+func (client *Client) Cancel(ctx context.Context, id string, reason string) error {
+	res, err := client.httpClient.PostTasksCancelWithResponse(ctx, oas.PostTasksCancelJSONRequestBody{
+		Id:     id,
+		Reason: &reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to make an http request: %w", err)
+	}
+
+	code := res.StatusCode()
+
+	switch code {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", core.ErrTaskNotFound, res.JSON404.Message)
+	case http.StatusConflict:
+		return fmt.Errorf("%w: %s", core.ErrIllegalTransition, res.JSON409.Message)
+	case http.StatusInternalServerError:
+		return fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return fmt.Errorf("unexpected response code: %d", code)
+	}
+}
+
+// Signal sends a signal with the payload and returns the id of the resource storing it
+func (client *Client) Signal(ctx context.Context, name string, payload []byte) (string, error) {
+	payloadString := string(payload)
+	res, err := client.httpClient.PostSignalsNameWithResponse(ctx, name, oas.PostSignalsNameJSONRequestBody{
+		Payload: &payloadString,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to make an http request: %w", err)
+	}
+
+	code := res.StatusCode()
+
+	switch code {
+	case http.StatusOK:
+		return res.JSON200.Resource, nil
+	case http.StatusInternalServerError:
+		return "", fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return "", fmt.Errorf("unexpected response code: %d", code)
+	}
+}
+
 func (client *Client) Task(ctx context.Context, id string) (core.Task, error) {
 	ts, err := client.Tasks().ByIDs(ctx, []string{id})
 	if err != nil {
@@ -69,7 +164,7 @@ func (client *Client) Task(ctx context.Context, id string) (core.Task, error) {
 	}
 
 	if len(ts) == 0 {
-		return core.Task{}, fmt.Errorf("not found")
+		return core.Task{}, fmt.Errorf("%w: %s", core.ErrTaskNotFound, id)
 	}
 
 	return ts[0], nil
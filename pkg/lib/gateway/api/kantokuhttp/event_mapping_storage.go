@@ -0,0 +1,107 @@
+package kantokuhttp
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/samber/lo"
+	"net/http"
+)
+
+type EventMappingStorage struct {
+	httpClient oas.ClientWithResponsesInterface
+}
+
+func (storage *EventMappingStorage) Get(ctx context.Context, id string) (event.Mapping, error) {
+	res, err := storage.httpClient.PostSpawnersEventsMappingsGetWithResponse(
+		ctx,
+		oas.PostSpawnersEventsMappingsGetJSONRequestBody{Id: id},
+	)
+	if err != nil {
+		return event.Mapping{}, fmt.Errorf("request failed: %w", err)
+	}
+
+	switch res.StatusCode() {
+	case http.StatusOK:
+		return eventMappingFromModel(*res.JSON200), nil
+	case http.StatusInternalServerError:
+		return event.Mapping{}, fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return event.Mapping{}, fmt.Errorf("unexpected response code: %d", res.StatusCode())
+	}
+}
+
+func (storage *EventMappingStorage) GetAll(ctx context.Context) ([]event.Mapping, error) {
+	res, err := storage.httpClient.PostSpawnersEventsMappingsGetAllWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	switch res.StatusCode() {
+	case http.StatusOK:
+		return lo.Map(*res.JSON200, func(model oas.EventMapping, _ int) event.Mapping {
+			return eventMappingFromModel(model)
+		}), nil
+	case http.StatusInternalServerError:
+		return nil, fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response code: %d", res.StatusCode())
+	}
+}
+
+func (storage *EventMappingStorage) Add(ctx context.Context, mapping event.Mapping) error {
+	res, err := storage.httpClient.PostSpawnersEventsMappingsCreateWithResponse(ctx, eventMappingToModel(mapping))
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	switch res.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusInternalServerError:
+		return fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return fmt.Errorf("unexpected response code: %d", res.StatusCode())
+	}
+}
+
+func (storage *EventMappingStorage) Remove(ctx context.Context, id string) error {
+	res, err := storage.httpClient.PostSpawnersEventsMappingsRemoveWithResponse(ctx,
+		oas.PostSpawnersEventsMappingsRemoveJSONRequestBody{Id: id},
+	)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+
+	switch res.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusInternalServerError:
+		return fmt.Errorf("server failure: %s", res.JSON500.Message)
+	default:
+		return fmt.Errorf("unexpected response code: %d", res.StatusCode())
+	}
+}
+
+func eventMappingFromModel(model oas.EventMapping) event.Mapping {
+	mapping := event.Mapping{
+		ID:            model.Id,
+		Topic:         model.Topic,
+		Specification: model.Specification,
+		Inputs:        make([]event.Binding, 0, len(model.Inputs)),
+	}
+
+	if model.Info != nil {
+		mapping.Info = *model.Info
+	}
+
+	for _, input := range model.Inputs {
+		mapping.Inputs = append(mapping.Inputs, event.Binding{
+			Path:  lo.FromPtr(input.Path),
+			Value: input.Value,
+		})
+	}
+
+	return mapping
+}
@@ -25,6 +25,26 @@ import (
 // AnyValue defines model for AnyValue.
 type AnyValue = any
 
+// EventMapping defines model for EventMapping.
+type EventMapping struct {
+	Id   string                  `json:"id"`
+	Info *map[string]interface{} `json:"info,omitempty"`
+
+	// Inputs Bindings of the specification's inputs (in order)
+	Inputs        []EventMappingInput `json:"inputs"`
+	Specification string              `json:"specification"`
+	Topic         string              `json:"topic"`
+}
+
+// EventMappingInput defines model for EventMappingInput.
+type EventMappingInput struct {
+	// Path A dot separated path to a field of the JSON payload (the whole payload if empty)
+	Path *string `json:"path,omitempty"`
+
+	// Value A constant used instead of the payload
+	Value *string `json:"value,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Message A human readable error message
@@ -40,8 +60,11 @@ type Resource struct {
 
 // ResourceInitializer defines model for ResourceInitializer.
 type ResourceInitializer struct {
-	Id    string `json:"id"`
-	Value string `json:"value"`
+	Id string `json:"id"`
+
+	// Ttl Lifetime of the resource after initialization (e.g. "1h30m")
+	Ttl   *string `json:"ttl,omitempty"`
+	Value string  `json:"value"`
 }
 
 // Specification defines model for Specification.
@@ -146,6 +169,9 @@ type TypeWithID struct {
 // PostResourcesAllocateParams defines parameters for PostResourcesAllocate.
 type PostResourcesAllocateParams struct {
 	Amount int `form:"amount" json:"amount"`
+
+	// Ttl Lifetime of the resources (e.g. "1h30m"), they never expire if not set
+	Ttl *string `form:"ttl,omitempty" json:"ttl,omitempty"`
 }
 
 // PostResourcesDeallocateJSONBody defines parameters for PostResourcesDeallocate.
@@ -157,6 +183,29 @@ type PostResourcesInitializeJSONBody = []ResourceInitializer
 // PostResourcesLoadJSONBody defines parameters for PostResourcesLoad.
 type PostResourcesLoadJSONBody = []string
 
+// PostSignalsNameJSONBody defines parameters for PostSignalsName.
+type PostSignalsNameJSONBody struct {
+	Payload *string `json:"payload,omitempty"`
+}
+
+// PostSpawnersEventsMappingsGetJSONBody defines parameters for PostSpawnersEventsMappingsGet.
+type PostSpawnersEventsMappingsGetJSONBody struct {
+	Id string `json:"id"`
+}
+
+// PostSpawnersEventsMappingsRemoveJSONBody defines parameters for PostSpawnersEventsMappingsRemove.
+type PostSpawnersEventsMappingsRemoveJSONBody struct {
+	Id string `json:"id"`
+}
+
+// PostTasksCancelJSONBody defines parameters for PostTasksCancel.
+type PostTasksCancelJSONBody struct {
+	Id     string  `json:"id"`
+	Reason *string `json:"reason,omitempty"`
+	// Recursive Cancel every task from the same execution context and every descendant task
+	Recursive *bool `json:"recursive,omitempty"`
+}
+
 // PostTasksLoadJSONBody defines parameters for PostTasksLoad.
 type PostTasksLoadJSONBody = []string
 
@@ -165,6 +214,9 @@ type PostTasksRestartJSONBody struct {
 	Id string `json:"id"`
 }
 
+// PostTasksSpawnBatchJSONBody defines parameters for PostTasksSpawnBatch.
+type PostTasksSpawnBatchJSONBody = []TaskParameters
+
 // PostTasksSpecificationsGetJSONBody defines parameters for PostTasksSpecificationsGet.
 type PostTasksSpecificationsGetJSONBody struct {
 	Id string `json:"id"`
@@ -220,6 +272,21 @@ type PostResourcesInitializeJSONRequestBody = PostResourcesInitializeJSONBody
 // PostResourcesLoadJSONRequestBody defines body for PostResourcesLoad for application/json ContentType.
 type PostResourcesLoadJSONRequestBody = PostResourcesLoadJSONBody
 
+// PostSignalsNameJSONRequestBody defines body for PostSignalsName for application/json ContentType.
+type PostSignalsNameJSONRequestBody PostSignalsNameJSONBody
+
+// PostSpawnersEventsMappingsCreateJSONRequestBody defines body for PostSpawnersEventsMappingsCreate for application/json ContentType.
+type PostSpawnersEventsMappingsCreateJSONRequestBody = EventMapping
+
+// PostSpawnersEventsMappingsGetJSONRequestBody defines body for PostSpawnersEventsMappingsGet for application/json ContentType.
+type PostSpawnersEventsMappingsGetJSONRequestBody PostSpawnersEventsMappingsGetJSONBody
+
+// PostSpawnersEventsMappingsRemoveJSONRequestBody defines body for PostSpawnersEventsMappingsRemove for application/json ContentType.
+type PostSpawnersEventsMappingsRemoveJSONRequestBody PostSpawnersEventsMappingsRemoveJSONBody
+
+// PostTasksCancelJSONRequestBody defines body for PostTasksCancel for application/json ContentType.
+type PostTasksCancelJSONRequestBody PostTasksCancelJSONBody
+
 // PostTasksLoadJSONRequestBody defines body for PostTasksLoad for application/json ContentType.
 type PostTasksLoadJSONRequestBody = PostTasksLoadJSONBody
 
@@ -229,6 +296,9 @@ type PostTasksRestartJSONRequestBody PostTasksRestartJSONBody
 // PostTasksSpawnJSONRequestBody defines body for PostTasksSpawn for application/json ContentType.
 type PostTasksSpawnJSONRequestBody = TaskParameters
 
+// PostTasksSpawnBatchJSONRequestBody defines body for PostTasksSpawnBatch for application/json ContentType.
+type PostTasksSpawnBatchJSONRequestBody = PostTasksSpawnBatchJSONBody
+
 // PostTasksSpawnFromSpecJSONRequestBody defines body for PostTasksSpawnFromSpec for application/json ContentType.
 type PostTasksSpawnFromSpecJSONRequestBody = SpecificationBasedTaskParameters
 
@@ -430,6 +500,34 @@ type ClientInterface interface {
 
 	PostResourcesLoad(ctx context.Context, body PostResourcesLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSignalsNameWithBody request with any body
+	PostSignalsNameWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSignalsName(ctx context.Context, name string, body PostSignalsNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSpawnersEventsMappingsCreateWithBody request with any body
+	PostSpawnersEventsMappingsCreateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSpawnersEventsMappingsCreate(ctx context.Context, body PostSpawnersEventsMappingsCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSpawnersEventsMappingsGetWithBody request with any body
+	PostSpawnersEventsMappingsGetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSpawnersEventsMappingsGet(ctx context.Context, body PostSpawnersEventsMappingsGetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSpawnersEventsMappingsGetAll request
+	PostSpawnersEventsMappingsGetAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSpawnersEventsMappingsRemoveWithBody request with any body
+	PostSpawnersEventsMappingsRemoveWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSpawnersEventsMappingsRemove(ctx context.Context, body PostSpawnersEventsMappingsRemoveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTasksCancelWithBody request with any body
+	PostTasksCancelWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTasksCancel(ctx context.Context, body PostTasksCancelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostTasksLoadWithBody request with any body
 	PostTasksLoadWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -445,6 +543,11 @@ type ClientInterface interface {
 
 	PostTasksSpawn(ctx context.Context, body PostTasksSpawnJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostTasksSpawnBatchWithBody request with any body
+	PostTasksSpawnBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTasksSpawnBatch(ctx context.Context, body PostTasksSpawnBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostTasksSpawnFromSpecWithBody request with any body
 	PostTasksSpawnFromSpecWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -609,6 +712,138 @@ func (c *Client) PostResourcesLoad(ctx context.Context, body PostResourcesLoadJS
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostSignalsNameWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSignalsNameRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSignalsName(ctx context.Context, name string, body PostSignalsNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSignalsNameRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsCreateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsCreateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsCreate(ctx context.Context, body PostSpawnersEventsMappingsCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsCreateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsGetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsGetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsGet(ctx context.Context, body PostSpawnersEventsMappingsGetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsGetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsGetAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsGetAllRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsRemoveWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsRemoveRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSpawnersEventsMappingsRemove(ctx context.Context, body PostSpawnersEventsMappingsRemoveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSpawnersEventsMappingsRemoveRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTasksCancelWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksCancelRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTasksCancel(ctx context.Context, body PostTasksCancelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksCancelRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostTasksLoadWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostTasksLoadRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -681,6 +916,30 @@ func (c *Client) PostTasksSpawn(ctx context.Context, body PostTasksSpawnJSONRequ
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostTasksSpawnBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksSpawnBatchRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTasksSpawnBatch(ctx context.Context, body PostTasksSpawnBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksSpawnBatchRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostTasksSpawnFromSpecWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostTasksSpawnFromSpecRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -1087,6 +1346,22 @@ func NewPostResourcesAllocateRequest(server string, params *PostResourcesAllocat
 			}
 		}
 
+		if params.Ttl != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, *params.Ttl); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
@@ -1218,27 +1493,34 @@ func NewPostResourcesLoadRequestWithBody(server string, contentType string, body
 	return req, nil
 }
 
-// NewPostTasksLoadRequest calls the generic PostTasksLoad builder with application/json body
-func NewPostTasksLoadRequest(server string, body PostTasksLoadJSONRequestBody) (*http.Request, error) {
+// NewPostSignalsNameRequest calls the generic PostSignalsName builder with application/json body
+func NewPostSignalsNameRequest(server string, name string, body PostSignalsNameJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksLoadRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSignalsNameRequestWithBody(server, name, "application/json", bodyReader)
 }
 
-// NewPostTasksLoadRequestWithBody generates requests for PostTasksLoad with any type of body
-func NewPostTasksLoadRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSignalsNameRequestWithBody generates requests for PostSignalsName with any type of body
+func NewPostSignalsNameRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/load")
+	operationPath := fmt.Sprintf("/signals/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1258,19 +1540,19 @@ func NewPostTasksLoadRequestWithBody(server string, contentType string, body io.
 	return req, nil
 }
 
-// NewPostTasksRestartRequest calls the generic PostTasksRestart builder with application/json body
-func NewPostTasksRestartRequest(server string, body PostTasksRestartJSONRequestBody) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsCreateRequest calls the generic PostSpawnersEventsMappingsCreate builder with application/json body
+func NewPostSpawnersEventsMappingsCreateRequest(server string, body PostSpawnersEventsMappingsCreateJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksRestartRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSpawnersEventsMappingsCreateRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksRestartRequestWithBody generates requests for PostTasksRestart with any type of body
-func NewPostTasksRestartRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsCreateRequestWithBody generates requests for PostSpawnersEventsMappingsCreate with any type of body
+func NewPostSpawnersEventsMappingsCreateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1278,7 +1560,7 @@ func NewPostTasksRestartRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/restart")
+	operationPath := fmt.Sprintf("/spawners/events/mappings/create")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1298,19 +1580,19 @@ func NewPostTasksRestartRequestWithBody(server string, contentType string, body
 	return req, nil
 }
 
-// NewPostTasksSpawnRequest calls the generic PostTasksSpawn builder with application/json body
-func NewPostTasksSpawnRequest(server string, body PostTasksSpawnJSONRequestBody) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsGetRequest calls the generic PostSpawnersEventsMappingsGet builder with application/json body
+func NewPostSpawnersEventsMappingsGetRequest(server string, body PostSpawnersEventsMappingsGetJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpawnRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSpawnersEventsMappingsGetRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpawnRequestWithBody generates requests for PostTasksSpawn with any type of body
-func NewPostTasksSpawnRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsGetRequestWithBody generates requests for PostSpawnersEventsMappingsGet with any type of body
+func NewPostSpawnersEventsMappingsGetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1318,7 +1600,7 @@ func NewPostTasksSpawnRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/spawn")
+	operationPath := fmt.Sprintf("/spawners/events/mappings/get")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1338,19 +1620,8 @@ func NewPostTasksSpawnRequestWithBody(server string, contentType string, body io
 	return req, nil
 }
 
-// NewPostTasksSpawnFromSpecRequest calls the generic PostTasksSpawnFromSpec builder with application/json body
-func NewPostTasksSpawnFromSpecRequest(server string, body PostTasksSpawnFromSpecJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpawnFromSpecRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewPostTasksSpawnFromSpecRequestWithBody generates requests for PostTasksSpawnFromSpec with any type of body
-func NewPostTasksSpawnFromSpecRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsGetAllRequest generates requests for PostSpawnersEventsMappingsGetAll
+func NewPostSpawnersEventsMappingsGetAllRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1358,7 +1629,7 @@ func NewPostTasksSpawnFromSpecRequestWithBody(server string, contentType string,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/spawn_from_spec")
+	operationPath := fmt.Sprintf("/spawners/events/mappings/get_all")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1368,29 +1639,27 @@ func NewPostTasksSpawnFromSpecRequestWithBody(server string, contentType string,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewPostTasksSpecificationsCreateRequest calls the generic PostTasksSpecificationsCreate builder with application/json body
-func NewPostTasksSpecificationsCreateRequest(server string, body PostTasksSpecificationsCreateJSONRequestBody) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsRemoveRequest calls the generic PostSpawnersEventsMappingsRemove builder with application/json body
+func NewPostSpawnersEventsMappingsRemoveRequest(server string, body PostSpawnersEventsMappingsRemoveJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsCreateRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSpawnersEventsMappingsRemoveRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsCreateRequestWithBody generates requests for PostTasksSpecificationsCreate with any type of body
-func NewPostTasksSpecificationsCreateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSpawnersEventsMappingsRemoveRequestWithBody generates requests for PostSpawnersEventsMappingsRemove with any type of body
+func NewPostSpawnersEventsMappingsRemoveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1398,7 +1667,7 @@ func NewPostTasksSpecificationsCreateRequestWithBody(server string, contentType
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/create")
+	operationPath := fmt.Sprintf("/spawners/events/mappings/remove")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1418,19 +1687,19 @@ func NewPostTasksSpecificationsCreateRequestWithBody(server string, contentType
 	return req, nil
 }
 
-// NewPostTasksSpecificationsGetRequest calls the generic PostTasksSpecificationsGet builder with application/json body
-func NewPostTasksSpecificationsGetRequest(server string, body PostTasksSpecificationsGetJSONRequestBody) (*http.Request, error) {
+// NewPostTasksCancelRequest calls the generic PostTasksCancel builder with application/json body
+func NewPostTasksCancelRequest(server string, body PostTasksCancelJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsGetRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksCancelRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsGetRequestWithBody generates requests for PostTasksSpecificationsGet with any type of body
-func NewPostTasksSpecificationsGetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksCancelRequestWithBody generates requests for PostTasksCancel with any type of body
+func NewPostTasksCancelRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1438,7 +1707,7 @@ func NewPostTasksSpecificationsGetRequestWithBody(server string, contentType str
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/get")
+	operationPath := fmt.Sprintf("/tasks/cancel")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1458,8 +1727,19 @@ func NewPostTasksSpecificationsGetRequestWithBody(server string, contentType str
 	return req, nil
 }
 
-// NewPostTasksSpecificationsGetAllRequest generates requests for PostTasksSpecificationsGetAll
-func NewPostTasksSpecificationsGetAllRequest(server string) (*http.Request, error) {
+// NewPostTasksLoadRequest calls the generic PostTasksLoad builder with application/json body
+func NewPostTasksLoadRequest(server string, body PostTasksLoadJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksLoadRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksLoadRequestWithBody generates requests for PostTasksLoad with any type of body
+func NewPostTasksLoadRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1467,7 +1747,7 @@ func NewPostTasksSpecificationsGetAllRequest(server string) (*http.Request, erro
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/get_all")
+	operationPath := fmt.Sprintf("/tasks/load")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1477,27 +1757,29 @@ func NewPostTasksSpecificationsGetAllRequest(server string) (*http.Request, erro
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewPostTasksSpecificationsRemoveRequest calls the generic PostTasksSpecificationsRemove builder with application/json body
-func NewPostTasksSpecificationsRemoveRequest(server string, body PostTasksSpecificationsRemoveJSONRequestBody) (*http.Request, error) {
+// NewPostTasksRestartRequest calls the generic PostTasksRestart builder with application/json body
+func NewPostTasksRestartRequest(server string, body PostTasksRestartJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsRemoveRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksRestartRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsRemoveRequestWithBody generates requests for PostTasksSpecificationsRemove with any type of body
-func NewPostTasksSpecificationsRemoveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksRestartRequestWithBody generates requests for PostTasksRestart with any type of body
+func NewPostTasksRestartRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1505,7 +1787,7 @@ func NewPostTasksSpecificationsRemoveRequestWithBody(server string, contentType
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/remove")
+	operationPath := fmt.Sprintf("/tasks/restart")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1525,19 +1807,19 @@ func NewPostTasksSpecificationsRemoveRequestWithBody(server string, contentType
 	return req, nil
 }
 
-// NewPostTasksSpecificationsTypesCreateRequest calls the generic PostTasksSpecificationsTypesCreate builder with application/json body
-func NewPostTasksSpecificationsTypesCreateRequest(server string, body PostTasksSpecificationsTypesCreateJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpawnRequest calls the generic PostTasksSpawn builder with application/json body
+func NewPostTasksSpawnRequest(server string, body PostTasksSpawnJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsTypesCreateRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpawnRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsTypesCreateRequestWithBody generates requests for PostTasksSpecificationsTypesCreate with any type of body
-func NewPostTasksSpecificationsTypesCreateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpawnRequestWithBody generates requests for PostTasksSpawn with any type of body
+func NewPostTasksSpawnRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1545,7 +1827,7 @@ func NewPostTasksSpecificationsTypesCreateRequestWithBody(server string, content
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/types/create")
+	operationPath := fmt.Sprintf("/tasks/spawn")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1565,19 +1847,19 @@ func NewPostTasksSpecificationsTypesCreateRequestWithBody(server string, content
 	return req, nil
 }
 
-// NewPostTasksSpecificationsTypesGetRequest calls the generic PostTasksSpecificationsTypesGet builder with application/json body
-func NewPostTasksSpecificationsTypesGetRequest(server string, body PostTasksSpecificationsTypesGetJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpawnBatchRequest calls the generic PostTasksSpawnBatch builder with application/json body
+func NewPostTasksSpawnBatchRequest(server string, body PostTasksSpawnBatchJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsTypesGetRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpawnBatchRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsTypesGetRequestWithBody generates requests for PostTasksSpecificationsTypesGet with any type of body
-func NewPostTasksSpecificationsTypesGetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpawnBatchRequestWithBody generates requests for PostTasksSpawnBatch with any type of body
+func NewPostTasksSpawnBatchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1585,7 +1867,7 @@ func NewPostTasksSpecificationsTypesGetRequestWithBody(server string, contentTyp
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/types/get")
+	operationPath := fmt.Sprintf("/tasks/spawn_batch")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1605,8 +1887,19 @@ func NewPostTasksSpecificationsTypesGetRequestWithBody(server string, contentTyp
 	return req, nil
 }
 
-// NewPostTasksSpecificationsTypesGetAllRequest generates requests for PostTasksSpecificationsTypesGetAll
-func NewPostTasksSpecificationsTypesGetAllRequest(server string) (*http.Request, error) {
+// NewPostTasksSpawnFromSpecRequest calls the generic PostTasksSpawnFromSpec builder with application/json body
+func NewPostTasksSpawnFromSpecRequest(server string, body PostTasksSpawnFromSpecJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksSpawnFromSpecRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksSpawnFromSpecRequestWithBody generates requests for PostTasksSpawnFromSpec with any type of body
+func NewPostTasksSpawnFromSpecRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1614,7 +1907,7 @@ func NewPostTasksSpecificationsTypesGetAllRequest(server string) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/types/get_all")
+	operationPath := fmt.Sprintf("/tasks/spawn_from_spec")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1624,27 +1917,29 @@ func NewPostTasksSpecificationsTypesGetAllRequest(server string) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewPostTasksSpecificationsTypesRemoveRequest calls the generic PostTasksSpecificationsTypesRemove builder with application/json body
-func NewPostTasksSpecificationsTypesRemoveRequest(server string, body PostTasksSpecificationsTypesRemoveJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsCreateRequest calls the generic PostTasksSpecificationsCreate builder with application/json body
+func NewPostTasksSpecificationsCreateRequest(server string, body PostTasksSpecificationsCreateJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksSpecificationsTypesRemoveRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsCreateRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksSpecificationsTypesRemoveRequestWithBody generates requests for PostTasksSpecificationsTypesRemove with any type of body
-func NewPostTasksSpecificationsTypesRemoveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsCreateRequestWithBody generates requests for PostTasksSpecificationsCreate with any type of body
+func NewPostTasksSpecificationsCreateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1652,7 +1947,7 @@ func NewPostTasksSpecificationsTypesRemoveRequestWithBody(server string, content
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/specifications/types/remove")
+	operationPath := fmt.Sprintf("/tasks/specifications/create")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1672,19 +1967,19 @@ func NewPostTasksSpecificationsTypesRemoveRequestWithBody(server string, content
 	return req, nil
 }
 
-// NewPostTasksStorageDeleteRequest calls the generic PostTasksStorageDelete builder with application/json body
-func NewPostTasksStorageDeleteRequest(server string, body PostTasksStorageDeleteJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsGetRequest calls the generic PostTasksSpecificationsGet builder with application/json body
+func NewPostTasksSpecificationsGetRequest(server string, body PostTasksSpecificationsGetJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageDeleteRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsGetRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageDeleteRequestWithBody generates requests for PostTasksStorageDelete with any type of body
-func NewPostTasksStorageDeleteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsGetRequestWithBody generates requests for PostTasksSpecificationsGet with any type of body
+func NewPostTasksSpecificationsGetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1692,7 +1987,7 @@ func NewPostTasksStorageDeleteRequestWithBody(server string, contentType string,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/delete")
+	operationPath := fmt.Sprintf("/tasks/specifications/get")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1712,19 +2007,8 @@ func NewPostTasksStorageDeleteRequestWithBody(server string, contentType string,
 	return req, nil
 }
 
-// NewPostTasksStorageExecRequest calls the generic PostTasksStorageExec builder with application/json body
-func NewPostTasksStorageExecRequest(server string, body PostTasksStorageExecJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageExecRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewPostTasksStorageExecRequestWithBody generates requests for PostTasksStorageExec with any type of body
-func NewPostTasksStorageExecRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsGetAllRequest generates requests for PostTasksSpecificationsGetAll
+func NewPostTasksSpecificationsGetAllRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1732,7 +2016,7 @@ func NewPostTasksStorageExecRequestWithBody(server string, contentType string, b
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/exec")
+	operationPath := fmt.Sprintf("/tasks/specifications/get_all")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1742,29 +2026,27 @@ func NewPostTasksStorageExecRequestWithBody(server string, contentType string, b
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewPostTasksStorageGetByIdsRequest calls the generic PostTasksStorageGetByIds builder with application/json body
-func NewPostTasksStorageGetByIdsRequest(server string, body PostTasksStorageGetByIdsJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsRemoveRequest calls the generic PostTasksSpecificationsRemove builder with application/json body
+func NewPostTasksSpecificationsRemoveRequest(server string, body PostTasksSpecificationsRemoveJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageGetByIdsRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsRemoveRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageGetByIdsRequestWithBody generates requests for PostTasksStorageGetByIds with any type of body
-func NewPostTasksStorageGetByIdsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsRemoveRequestWithBody generates requests for PostTasksSpecificationsRemove with any type of body
+func NewPostTasksSpecificationsRemoveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1772,7 +2054,7 @@ func NewPostTasksStorageGetByIdsRequestWithBody(server string, contentType strin
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/get_by_ids")
+	operationPath := fmt.Sprintf("/tasks/specifications/remove")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1792,19 +2074,19 @@ func NewPostTasksStorageGetByIdsRequestWithBody(server string, contentType strin
 	return req, nil
 }
 
-// NewPostTasksStorageGetWithPropertiesRequest calls the generic PostTasksStorageGetWithProperties builder with application/json body
-func NewPostTasksStorageGetWithPropertiesRequest(server string, body PostTasksStorageGetWithPropertiesJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesCreateRequest calls the generic PostTasksSpecificationsTypesCreate builder with application/json body
+func NewPostTasksSpecificationsTypesCreateRequest(server string, body PostTasksSpecificationsTypesCreateJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageGetWithPropertiesRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsTypesCreateRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageGetWithPropertiesRequestWithBody generates requests for PostTasksStorageGetWithProperties with any type of body
-func NewPostTasksStorageGetWithPropertiesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesCreateRequestWithBody generates requests for PostTasksSpecificationsTypesCreate with any type of body
+func NewPostTasksSpecificationsTypesCreateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1812,7 +2094,7 @@ func NewPostTasksStorageGetWithPropertiesRequestWithBody(server string, contentT
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/get_with_properties")
+	operationPath := fmt.Sprintf("/tasks/specifications/types/create")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1832,19 +2114,19 @@ func NewPostTasksStorageGetWithPropertiesRequestWithBody(server string, contentT
 	return req, nil
 }
 
-// NewPostTasksStorageInsertRequest calls the generic PostTasksStorageInsert builder with application/json body
-func NewPostTasksStorageInsertRequest(server string, body PostTasksStorageInsertJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesGetRequest calls the generic PostTasksSpecificationsTypesGet builder with application/json body
+func NewPostTasksSpecificationsTypesGetRequest(server string, body PostTasksSpecificationsTypesGetJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageInsertRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsTypesGetRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageInsertRequestWithBody generates requests for PostTasksStorageInsert with any type of body
-func NewPostTasksStorageInsertRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesGetRequestWithBody generates requests for PostTasksSpecificationsTypesGet with any type of body
+func NewPostTasksSpecificationsTypesGetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1852,7 +2134,7 @@ func NewPostTasksStorageInsertRequestWithBody(server string, contentType string,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/insert")
+	operationPath := fmt.Sprintf("/tasks/specifications/types/get")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1872,8 +2154,8 @@ func NewPostTasksStorageInsertRequestWithBody(server string, contentType string,
 	return req, nil
 }
 
-// NewPostTasksStorageSettingsRequest generates requests for PostTasksStorageSettings
-func NewPostTasksStorageSettingsRequest(server string) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesGetAllRequest generates requests for PostTasksSpecificationsTypesGetAll
+func NewPostTasksSpecificationsTypesGetAllRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1881,7 +2163,7 @@ func NewPostTasksStorageSettingsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/settings")
+	operationPath := fmt.Sprintf("/tasks/specifications/types/get_all")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1899,19 +2181,19 @@ func NewPostTasksStorageSettingsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostTasksStorageUpdateByIdsRequest calls the generic PostTasksStorageUpdateByIds builder with application/json body
-func NewPostTasksStorageUpdateByIdsRequest(server string, body PostTasksStorageUpdateByIdsJSONRequestBody) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesRemoveRequest calls the generic PostTasksSpecificationsTypesRemove builder with application/json body
+func NewPostTasksSpecificationsTypesRemoveRequest(server string, body PostTasksSpecificationsTypesRemoveJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageUpdateByIdsRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksSpecificationsTypesRemoveRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageUpdateByIdsRequestWithBody generates requests for PostTasksStorageUpdateByIds with any type of body
-func NewPostTasksStorageUpdateByIdsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksSpecificationsTypesRemoveRequestWithBody generates requests for PostTasksSpecificationsTypesRemove with any type of body
+func NewPostTasksSpecificationsTypesRemoveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1919,7 +2201,7 @@ func NewPostTasksStorageUpdateByIdsRequestWithBody(server string, contentType st
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/update_by_ids")
+	operationPath := fmt.Sprintf("/tasks/specifications/types/remove")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1939,19 +2221,19 @@ func NewPostTasksStorageUpdateByIdsRequestWithBody(server string, contentType st
 	return req, nil
 }
 
-// NewPostTasksStorageUpdateWithPropertiesRequest calls the generic PostTasksStorageUpdateWithProperties builder with application/json body
-func NewPostTasksStorageUpdateWithPropertiesRequest(server string, body PostTasksStorageUpdateWithPropertiesJSONRequestBody) (*http.Request, error) {
+// NewPostTasksStorageDeleteRequest calls the generic PostTasksStorageDelete builder with application/json body
+func NewPostTasksStorageDeleteRequest(server string, body PostTasksStorageDeleteJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server, "application/json", bodyReader)
+	return NewPostTasksStorageDeleteRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostTasksStorageUpdateWithPropertiesRequestWithBody generates requests for PostTasksStorageUpdateWithProperties with any type of body
-func NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostTasksStorageDeleteRequestWithBody generates requests for PostTasksStorageDelete with any type of body
+func NewPostTasksStorageDeleteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1959,7 +2241,7 @@ func NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server string, conte
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tasks/storage/update_with_properties")
+	operationPath := fmt.Sprintf("/tasks/storage/delete")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1979,82 +2261,382 @@ func NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server string, conte
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostTasksStorageExecRequest calls the generic PostTasksStorageExec builder with application/json body
+func NewPostTasksStorageExecRequest(server string, body PostTasksStorageExecJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageExecRequestWithBody(server, "application/json", bodyReader)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewPostTasksStorageExecRequestWithBody generates requests for PostTasksStorageExec with any type of body
+func NewPostTasksStorageExecRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	operationPath := fmt.Sprintf("/tasks/storage/exec")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostResourcesAllocateWithResponse request
-	PostResourcesAllocateWithResponse(ctx context.Context, params *PostResourcesAllocateParams, reqEditors ...RequestEditorFn) (*PostResourcesAllocateResponse, error)
-
-	// PostResourcesDeallocateWithBodyWithResponse request with any body
-	PostResourcesDeallocateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	PostResourcesDeallocateWithResponse(ctx context.Context, body PostResourcesDeallocateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostResourcesInitializeWithBodyWithResponse request with any body
-	PostResourcesInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	PostResourcesInitializeWithResponse(ctx context.Context, body PostResourcesInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error)
+	return req, nil
+}
 
-	// PostResourcesLoadWithBodyWithResponse request with any body
-	PostResourcesLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error)
+// NewPostTasksStorageGetByIdsRequest calls the generic PostTasksStorageGetByIds builder with application/json body
+func NewPostTasksStorageGetByIdsRequest(server string, body PostTasksStorageGetByIdsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageGetByIdsRequestWithBody(server, "application/json", bodyReader)
+}
 
-	PostResourcesLoadWithResponse(ctx context.Context, body PostResourcesLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error)
+// NewPostTasksStorageGetByIdsRequestWithBody generates requests for PostTasksStorageGetByIds with any type of body
+func NewPostTasksStorageGetByIdsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// PostTasksLoadWithBodyWithResponse request with any body
-	PostTasksLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksLoadResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	PostTasksLoadWithResponse(ctx context.Context, body PostTasksLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksLoadResponse, error)
+	operationPath := fmt.Sprintf("/tasks/storage/get_by_ids")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// PostTasksRestartWithBodyWithResponse request with any body
-	PostTasksRestartWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksRestartResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	PostTasksRestartWithResponse(ctx context.Context, body PostTasksRestartJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksRestartResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTasksStorageGetWithPropertiesRequest calls the generic PostTasksStorageGetWithProperties builder with application/json body
+func NewPostTasksStorageGetWithPropertiesRequest(server string, body PostTasksStorageGetWithPropertiesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageGetWithPropertiesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksStorageGetWithPropertiesRequestWithBody generates requests for PostTasksStorageGetWithProperties with any type of body
+func NewPostTasksStorageGetWithPropertiesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/storage/get_with_properties")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTasksStorageInsertRequest calls the generic PostTasksStorageInsert builder with application/json body
+func NewPostTasksStorageInsertRequest(server string, body PostTasksStorageInsertJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageInsertRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksStorageInsertRequestWithBody generates requests for PostTasksStorageInsert with any type of body
+func NewPostTasksStorageInsertRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/storage/insert")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTasksStorageSettingsRequest generates requests for PostTasksStorageSettings
+func NewPostTasksStorageSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/storage/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTasksStorageUpdateByIdsRequest calls the generic PostTasksStorageUpdateByIds builder with application/json body
+func NewPostTasksStorageUpdateByIdsRequest(server string, body PostTasksStorageUpdateByIdsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageUpdateByIdsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksStorageUpdateByIdsRequestWithBody generates requests for PostTasksStorageUpdateByIds with any type of body
+func NewPostTasksStorageUpdateByIdsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/storage/update_by_ids")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTasksStorageUpdateWithPropertiesRequest calls the generic PostTasksStorageUpdateWithProperties builder with application/json body
+func NewPostTasksStorageUpdateWithPropertiesRequest(server string, body PostTasksStorageUpdateWithPropertiesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTasksStorageUpdateWithPropertiesRequestWithBody generates requests for PostTasksStorageUpdateWithProperties with any type of body
+func NewPostTasksStorageUpdateWithPropertiesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/storage/update_with_properties")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// PostResourcesAllocateWithResponse request
+	PostResourcesAllocateWithResponse(ctx context.Context, params *PostResourcesAllocateParams, reqEditors ...RequestEditorFn) (*PostResourcesAllocateResponse, error)
+
+	// PostResourcesDeallocateWithBodyWithResponse request with any body
+	PostResourcesDeallocateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error)
+
+	PostResourcesDeallocateWithResponse(ctx context.Context, body PostResourcesDeallocateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error)
+
+	// PostResourcesInitializeWithBodyWithResponse request with any body
+	PostResourcesInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error)
+
+	PostResourcesInitializeWithResponse(ctx context.Context, body PostResourcesInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error)
+
+	// PostResourcesLoadWithBodyWithResponse request with any body
+	PostResourcesLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error)
+
+	PostResourcesLoadWithResponse(ctx context.Context, body PostResourcesLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error)
+
+	// PostSignalsNameWithBodyWithResponse request with any body
+	PostSignalsNameWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSignalsNameResponse, error)
+
+	PostSignalsNameWithResponse(ctx context.Context, name string, body PostSignalsNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSignalsNameResponse, error)
+
+	// PostSpawnersEventsMappingsCreateWithBodyWithResponse request with any body
+	PostSpawnersEventsMappingsCreateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsCreateResponse, error)
+
+	PostSpawnersEventsMappingsCreateWithResponse(ctx context.Context, body PostSpawnersEventsMappingsCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsCreateResponse, error)
+
+	// PostSpawnersEventsMappingsGetWithBodyWithResponse request with any body
+	PostSpawnersEventsMappingsGetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetResponse, error)
+
+	PostSpawnersEventsMappingsGetWithResponse(ctx context.Context, body PostSpawnersEventsMappingsGetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetResponse, error)
+
+	// PostSpawnersEventsMappingsGetAllWithResponse request
+	PostSpawnersEventsMappingsGetAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetAllResponse, error)
+
+	// PostSpawnersEventsMappingsRemoveWithBodyWithResponse request with any body
+	PostSpawnersEventsMappingsRemoveWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsRemoveResponse, error)
+
+	PostSpawnersEventsMappingsRemoveWithResponse(ctx context.Context, body PostSpawnersEventsMappingsRemoveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsRemoveResponse, error)
+
+	// PostTasksCancelWithBodyWithResponse request with any body
+	PostTasksCancelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksCancelResponse, error)
+
+	PostTasksCancelWithResponse(ctx context.Context, body PostTasksCancelJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksCancelResponse, error)
+
+	// PostTasksLoadWithBodyWithResponse request with any body
+	PostTasksLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksLoadResponse, error)
+
+	PostTasksLoadWithResponse(ctx context.Context, body PostTasksLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksLoadResponse, error)
+
+	// PostTasksRestartWithBodyWithResponse request with any body
+	PostTasksRestartWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksRestartResponse, error)
+
+	PostTasksRestartWithResponse(ctx context.Context, body PostTasksRestartJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksRestartResponse, error)
 
 	// PostTasksSpawnWithBodyWithResponse request with any body
 	PostTasksSpawnWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksSpawnResponse, error)
 
 	PostTasksSpawnWithResponse(ctx context.Context, body PostTasksSpawnJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksSpawnResponse, error)
 
+	// PostTasksSpawnBatchWithBodyWithResponse request with any body
+	PostTasksSpawnBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksSpawnBatchResponse, error)
+
+	PostTasksSpawnBatchWithResponse(ctx context.Context, body PostTasksSpawnBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksSpawnBatchResponse, error)
+
 	// PostTasksSpawnFromSpecWithBodyWithResponse request with any body
 	PostTasksSpawnFromSpecWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksSpawnFromSpecResponse, error)
 
@@ -2227,6 +2809,149 @@ func (r PostResourcesLoadResponse) StatusCode() int {
 	return 0
 }
 
+type PostSignalsNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Resource string `json:"resource"`
+	}
+	JSON500 *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSignalsNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSignalsNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSpawnersEventsMappingsCreateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSpawnersEventsMappingsCreateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSpawnersEventsMappingsCreateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSpawnersEventsMappingsGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EventMapping
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSpawnersEventsMappingsGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSpawnersEventsMappingsGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSpawnersEventsMappingsGetAllResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]EventMapping
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSpawnersEventsMappingsGetAllResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSpawnersEventsMappingsGetAllResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSpawnersEventsMappingsRemoveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSpawnersEventsMappingsRemoveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSpawnersEventsMappingsRemoveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTasksCancelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Cancelled Identifiers of cancelled tasks
+		Cancelled []string `json:"cancelled"`
+	}
+	JSON404 *Error
+	JSON409 *Error
+	JSON500 *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTasksCancelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTasksCancelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostTasksLoadResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2256,6 +2981,8 @@ type PostTasksRestartResponse struct {
 	JSON200      *struct {
 		Id string `json:"id"`
 	}
+	JSON404 *Error
+	JSON409 *Error
 	JSON500 *Error
 }
 
@@ -2279,6 +3006,7 @@ type PostTasksSpawnResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *TaskSpawnResponse
+	JSON400      *Error
 	JSON500      *Error
 }
 
@@ -2298,10 +3026,35 @@ func (r PostTasksSpawnResponse) StatusCode() int {
 	return 0
 }
 
+type PostTasksSpawnBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]TaskSpawnResponse
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTasksSpawnBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTasksSpawnBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostTasksSpawnFromSpecResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *TaskSpawnResponse
+	JSON400      *Error
 	JSON500      *Error
 }
 
@@ -2673,75 +3426,169 @@ func (r PostTasksStorageUpdateWithPropertiesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTasksStorageUpdateWithPropertiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// PostResourcesAllocateWithResponse request returning *PostResourcesAllocateResponse
+func (c *ClientWithResponses) PostResourcesAllocateWithResponse(ctx context.Context, params *PostResourcesAllocateParams, reqEditors ...RequestEditorFn) (*PostResourcesAllocateResponse, error) {
+	rsp, err := c.PostResourcesAllocate(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesAllocateResponse(rsp)
+}
+
+// PostResourcesDeallocateWithBodyWithResponse request with arbitrary body returning *PostResourcesDeallocateResponse
+func (c *ClientWithResponses) PostResourcesDeallocateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error) {
+	rsp, err := c.PostResourcesDeallocateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesDeallocateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostResourcesDeallocateWithResponse(ctx context.Context, body PostResourcesDeallocateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error) {
+	rsp, err := c.PostResourcesDeallocate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesDeallocateResponse(rsp)
+}
+
+// PostResourcesInitializeWithBodyWithResponse request with arbitrary body returning *PostResourcesInitializeResponse
+func (c *ClientWithResponses) PostResourcesInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error) {
+	rsp, err := c.PostResourcesInitializeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesInitializeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostResourcesInitializeWithResponse(ctx context.Context, body PostResourcesInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error) {
+	rsp, err := c.PostResourcesInitialize(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesInitializeResponse(rsp)
+}
+
+// PostResourcesLoadWithBodyWithResponse request with arbitrary body returning *PostResourcesLoadResponse
+func (c *ClientWithResponses) PostResourcesLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error) {
+	rsp, err := c.PostResourcesLoadWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesLoadResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostResourcesLoadWithResponse(ctx context.Context, body PostResourcesLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error) {
+	rsp, err := c.PostResourcesLoad(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostResourcesLoadResponse(rsp)
+}
+
+// PostSignalsNameWithBodyWithResponse request with arbitrary body returning *PostSignalsNameResponse
+func (c *ClientWithResponses) PostSignalsNameWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSignalsNameResponse, error) {
+	rsp, err := c.PostSignalsNameWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSignalsNameResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSignalsNameWithResponse(ctx context.Context, name string, body PostSignalsNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSignalsNameResponse, error) {
+	rsp, err := c.PostSignalsName(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSignalsNameResponse(rsp)
+}
+
+// PostSpawnersEventsMappingsCreateWithBodyWithResponse request with arbitrary body returning *PostSpawnersEventsMappingsCreateResponse
+func (c *ClientWithResponses) PostSpawnersEventsMappingsCreateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsCreateResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsCreateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSpawnersEventsMappingsCreateResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostTasksStorageUpdateWithPropertiesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *ClientWithResponses) PostSpawnersEventsMappingsCreateWithResponse(ctx context.Context, body PostSpawnersEventsMappingsCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsCreateResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsCreate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParsePostSpawnersEventsMappingsCreateResponse(rsp)
 }
 
-// PostResourcesAllocateWithResponse request returning *PostResourcesAllocateResponse
-func (c *ClientWithResponses) PostResourcesAllocateWithResponse(ctx context.Context, params *PostResourcesAllocateParams, reqEditors ...RequestEditorFn) (*PostResourcesAllocateResponse, error) {
-	rsp, err := c.PostResourcesAllocate(ctx, params, reqEditors...)
+// PostSpawnersEventsMappingsGetWithBodyWithResponse request with arbitrary body returning *PostSpawnersEventsMappingsGetResponse
+func (c *ClientWithResponses) PostSpawnersEventsMappingsGetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsGetWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesAllocateResponse(rsp)
+	return ParsePostSpawnersEventsMappingsGetResponse(rsp)
 }
 
-// PostResourcesDeallocateWithBodyWithResponse request with arbitrary body returning *PostResourcesDeallocateResponse
-func (c *ClientWithResponses) PostResourcesDeallocateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error) {
-	rsp, err := c.PostResourcesDeallocateWithBody(ctx, contentType, body, reqEditors...)
+func (c *ClientWithResponses) PostSpawnersEventsMappingsGetWithResponse(ctx context.Context, body PostSpawnersEventsMappingsGetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsGet(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesDeallocateResponse(rsp)
+	return ParsePostSpawnersEventsMappingsGetResponse(rsp)
 }
 
-func (c *ClientWithResponses) PostResourcesDeallocateWithResponse(ctx context.Context, body PostResourcesDeallocateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesDeallocateResponse, error) {
-	rsp, err := c.PostResourcesDeallocate(ctx, body, reqEditors...)
+// PostSpawnersEventsMappingsGetAllWithResponse request returning *PostSpawnersEventsMappingsGetAllResponse
+func (c *ClientWithResponses) PostSpawnersEventsMappingsGetAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsGetAllResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsGetAll(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesDeallocateResponse(rsp)
+	return ParsePostSpawnersEventsMappingsGetAllResponse(rsp)
 }
 
-// PostResourcesInitializeWithBodyWithResponse request with arbitrary body returning *PostResourcesInitializeResponse
-func (c *ClientWithResponses) PostResourcesInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error) {
-	rsp, err := c.PostResourcesInitializeWithBody(ctx, contentType, body, reqEditors...)
+// PostSpawnersEventsMappingsRemoveWithBodyWithResponse request with arbitrary body returning *PostSpawnersEventsMappingsRemoveResponse
+func (c *ClientWithResponses) PostSpawnersEventsMappingsRemoveWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsRemoveResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsRemoveWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesInitializeResponse(rsp)
+	return ParsePostSpawnersEventsMappingsRemoveResponse(rsp)
 }
 
-func (c *ClientWithResponses) PostResourcesInitializeWithResponse(ctx context.Context, body PostResourcesInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesInitializeResponse, error) {
-	rsp, err := c.PostResourcesInitialize(ctx, body, reqEditors...)
+func (c *ClientWithResponses) PostSpawnersEventsMappingsRemoveWithResponse(ctx context.Context, body PostSpawnersEventsMappingsRemoveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSpawnersEventsMappingsRemoveResponse, error) {
+	rsp, err := c.PostSpawnersEventsMappingsRemove(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesInitializeResponse(rsp)
+	return ParsePostSpawnersEventsMappingsRemoveResponse(rsp)
 }
 
-// PostResourcesLoadWithBodyWithResponse request with arbitrary body returning *PostResourcesLoadResponse
-func (c *ClientWithResponses) PostResourcesLoadWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error) {
-	rsp, err := c.PostResourcesLoadWithBody(ctx, contentType, body, reqEditors...)
+// PostTasksCancelWithBodyWithResponse request with arbitrary body returning *PostTasksCancelResponse
+func (c *ClientWithResponses) PostTasksCancelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksCancelResponse, error) {
+	rsp, err := c.PostTasksCancelWithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesLoadResponse(rsp)
+	return ParsePostTasksCancelResponse(rsp)
 }
 
-func (c *ClientWithResponses) PostResourcesLoadWithResponse(ctx context.Context, body PostResourcesLoadJSONRequestBody, reqEditors ...RequestEditorFn) (*PostResourcesLoadResponse, error) {
-	rsp, err := c.PostResourcesLoad(ctx, body, reqEditors...)
+func (c *ClientWithResponses) PostTasksCancelWithResponse(ctx context.Context, body PostTasksCancelJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksCancelResponse, error) {
+	rsp, err := c.PostTasksCancel(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostResourcesLoadResponse(rsp)
+	return ParsePostTasksCancelResponse(rsp)
 }
 
 // PostTasksLoadWithBodyWithResponse request with arbitrary body returning *PostTasksLoadResponse
@@ -2795,6 +3642,23 @@ func (c *ClientWithResponses) PostTasksSpawnWithResponse(ctx context.Context, bo
 	return ParsePostTasksSpawnResponse(rsp)
 }
 
+// PostTasksSpawnBatchWithBodyWithResponse request with arbitrary body returning *PostTasksSpawnBatchResponse
+func (c *ClientWithResponses) PostTasksSpawnBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksSpawnBatchResponse, error) {
+	rsp, err := c.PostTasksSpawnBatchWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTasksSpawnBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTasksSpawnBatchWithResponse(ctx context.Context, body PostTasksSpawnBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksSpawnBatchResponse, error) {
+	rsp, err := c.PostTasksSpawnBatch(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTasksSpawnBatchResponse(rsp)
+}
+
 // PostTasksSpawnFromSpecWithBodyWithResponse request with arbitrary body returning *PostTasksSpawnFromSpecResponse
 func (c *ClientWithResponses) PostTasksSpawnFromSpecWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksSpawnFromSpecResponse, error) {
 	rsp, err := c.PostTasksSpawnFromSpecWithBody(ctx, contentType, body, reqEditors...)
@@ -3093,27 +3957,246 @@ func ParsePostResourcesAllocateResponse(rsp *http.Response) (*PostResourcesAlloc
 	return response, nil
 }
 
-// ParsePostResourcesDeallocateResponse parses an HTTP response from a PostResourcesDeallocateWithResponse call
-func ParsePostResourcesDeallocateResponse(rsp *http.Response) (*PostResourcesDeallocateResponse, error) {
+// ParsePostResourcesDeallocateResponse parses an HTTP response from a PostResourcesDeallocateWithResponse call
+func ParsePostResourcesDeallocateResponse(rsp *http.Response) (*PostResourcesDeallocateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostResourcesDeallocateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostResourcesInitializeResponse parses an HTTP response from a PostResourcesInitializeWithResponse call
+func ParsePostResourcesInitializeResponse(rsp *http.Response) (*PostResourcesInitializeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostResourcesInitializeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostResourcesLoadResponse parses an HTTP response from a PostResourcesLoadWithResponse call
+func ParsePostResourcesLoadResponse(rsp *http.Response) (*PostResourcesLoadResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostResourcesLoadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Resource
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSignalsNameResponse parses an HTTP response from a PostSignalsNameWithResponse call
+func ParsePostSignalsNameResponse(rsp *http.Response) (*PostSignalsNameResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSignalsNameResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Resource string `json:"resource"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSpawnersEventsMappingsCreateResponse parses an HTTP response from a PostSpawnersEventsMappingsCreateWithResponse call
+func ParsePostSpawnersEventsMappingsCreateResponse(rsp *http.Response) (*PostSpawnersEventsMappingsCreateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSpawnersEventsMappingsCreateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSpawnersEventsMappingsGetResponse parses an HTTP response from a PostSpawnersEventsMappingsGetWithResponse call
+func ParsePostSpawnersEventsMappingsGetResponse(rsp *http.Response) (*PostSpawnersEventsMappingsGetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSpawnersEventsMappingsGetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EventMapping
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSpawnersEventsMappingsGetAllResponse parses an HTTP response from a PostSpawnersEventsMappingsGetAllWithResponse call
+func ParsePostSpawnersEventsMappingsGetAllResponse(rsp *http.Response) (*PostSpawnersEventsMappingsGetAllResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSpawnersEventsMappingsGetAllResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []EventMapping
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSpawnersEventsMappingsRemoveResponse parses an HTTP response from a PostSpawnersEventsMappingsRemoveWithResponse call
+func ParsePostSpawnersEventsMappingsRemoveResponse(rsp *http.Response) (*PostSpawnersEventsMappingsRemoveResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostResourcesDeallocateResponse{
+	response := &PostSpawnersEventsMappingsRemoveResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3126,27 +4209,44 @@ func ParsePostResourcesDeallocateResponse(rsp *http.Response) (*PostResourcesDea
 	return response, nil
 }
 
-// ParsePostResourcesInitializeResponse parses an HTTP response from a PostResourcesInitializeWithResponse call
-func ParsePostResourcesInitializeResponse(rsp *http.Response) (*PostResourcesInitializeResponse, error) {
+// ParsePostTasksCancelResponse parses an HTTP response from a PostTasksCancelWithResponse call
+func ParsePostTasksCancelResponse(rsp *http.Response) (*PostTasksCancelResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostResourcesInitializeResponse{
+	response := &PostTasksCancelResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest struct {
+			// Cancelled Identifiers of cancelled tasks
+			Cancelled []string `json:"cancelled"`
+		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3159,22 +4259,22 @@ func ParsePostResourcesInitializeResponse(rsp *http.Response) (*PostResourcesIni
 	return response, nil
 }
 
-// ParsePostResourcesLoadResponse parses an HTTP response from a PostResourcesLoadWithResponse call
-func ParsePostResourcesLoadResponse(rsp *http.Response) (*PostResourcesLoadResponse, error) {
+// ParsePostTasksLoadResponse parses an HTTP response from a PostTasksLoadWithResponse call
+func ParsePostTasksLoadResponse(rsp *http.Response) (*PostTasksLoadResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostResourcesLoadResponse{
+	response := &PostTasksLoadResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Resource
+		var dest []Task
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3192,27 +4292,43 @@ func ParsePostResourcesLoadResponse(rsp *http.Response) (*PostResourcesLoadRespo
 	return response, nil
 }
 
-// ParsePostTasksLoadResponse parses an HTTP response from a PostTasksLoadWithResponse call
-func ParsePostTasksLoadResponse(rsp *http.Response) (*PostTasksLoadResponse, error) {
+// ParsePostTasksRestartResponse parses an HTTP response from a PostTasksRestartWithResponse call
+func ParsePostTasksRestartResponse(rsp *http.Response) (*PostTasksRestartResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostTasksLoadResponse{
+	response := &PostTasksRestartResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Task
+		var dest struct {
+			Id string `json:"id"`
+		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3225,29 +4341,34 @@ func ParsePostTasksLoadResponse(rsp *http.Response) (*PostTasksLoadResponse, err
 	return response, nil
 }
 
-// ParsePostTasksRestartResponse parses an HTTP response from a PostTasksRestartWithResponse call
-func ParsePostTasksRestartResponse(rsp *http.Response) (*PostTasksRestartResponse, error) {
+// ParsePostTasksSpawnResponse parses an HTTP response from a PostTasksSpawnWithResponse call
+func ParsePostTasksSpawnResponse(rsp *http.Response) (*PostTasksSpawnResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostTasksRestartResponse{
+	response := &PostTasksSpawnResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			Id string `json:"id"`
-		}
+		var dest TaskSpawnResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3260,27 +4381,34 @@ func ParsePostTasksRestartResponse(rsp *http.Response) (*PostTasksRestartRespons
 	return response, nil
 }
 
-// ParsePostTasksSpawnResponse parses an HTTP response from a PostTasksSpawnWithResponse call
-func ParsePostTasksSpawnResponse(rsp *http.Response) (*PostTasksSpawnResponse, error) {
+// ParsePostTasksSpawnBatchResponse parses an HTTP response from a PostTasksSpawnBatchWithResponse call
+func ParsePostTasksSpawnBatchResponse(rsp *http.Response) (*PostTasksSpawnBatchResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostTasksSpawnResponse{
+	response := &PostTasksSpawnBatchResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest TaskSpawnResponse
+		var dest []TaskSpawnResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3314,6 +4442,13 @@ func ParsePostTasksSpawnFromSpecResponse(rsp *http.Response) (*PostTasksSpawnFro
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3821,6 +4956,24 @@ type ServerInterface interface {
 	// Load resources
 	// (POST /resources/load)
 	PostResourcesLoad(ctx echo.Context) error
+	// Send a signal
+	// (POST /signals/{name})
+	PostSignalsName(ctx echo.Context, name string) error
+	// Create an event mapping (or replace the one with the same id)
+	// (POST /spawners/events/mappings/create)
+	PostSpawnersEventsMappingsCreate(ctx echo.Context) error
+	// Get an event mapping by id
+	// (POST /spawners/events/mappings/get)
+	PostSpawnersEventsMappingsGet(ctx echo.Context) error
+	// Get all event mappings
+	// (POST /spawners/events/mappings/get_all)
+	PostSpawnersEventsMappingsGetAll(ctx echo.Context) error
+	// Remove an event mapping
+	// (POST /spawners/events/mappings/remove)
+	PostSpawnersEventsMappingsRemove(ctx echo.Context) error
+	// Cancel a task
+	// (POST /tasks/cancel)
+	PostTasksCancel(ctx echo.Context) error
 	// Load a set of tasks
 	// (POST /tasks/load)
 	PostTasksLoad(ctx echo.Context) error
@@ -3830,6 +4983,9 @@ type ServerInterface interface {
 	// Spawn a new task
 	// (POST /tasks/spawn)
 	PostTasksSpawn(ctx echo.Context) error
+	// Spawn several tasks at once (either all of them are spawned or none)
+	// (POST /tasks/spawn_batch)
+	PostTasksSpawnBatch(ctx echo.Context) error
 	// Spawn a new task from specification
 	// (POST /tasks/spawn_from_spec)
 	PostTasksSpawnFromSpec(ctx echo.Context) error
@@ -3901,6 +5057,13 @@ func (w *ServerInterfaceWrapper) PostResourcesAllocate(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter amount: %s", err))
 	}
 
+	// ------------- Optional query parameter "ttl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "ttl", ctx.QueryParams(), &params.Ttl)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ttl: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostResourcesAllocate(ctx, params)
 	return err
@@ -3933,6 +5096,67 @@ func (w *ServerInterfaceWrapper) PostResourcesLoad(ctx echo.Context) error {
 	return err
 }
 
+// PostSignalsName converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSignalsName(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSignalsName(ctx, name)
+	return err
+}
+
+// PostSpawnersEventsMappingsCreate converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSpawnersEventsMappingsCreate(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSpawnersEventsMappingsCreate(ctx)
+	return err
+}
+
+// PostSpawnersEventsMappingsGet converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSpawnersEventsMappingsGet(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSpawnersEventsMappingsGet(ctx)
+	return err
+}
+
+// PostSpawnersEventsMappingsGetAll converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSpawnersEventsMappingsGetAll(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSpawnersEventsMappingsGetAll(ctx)
+	return err
+}
+
+// PostSpawnersEventsMappingsRemove converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSpawnersEventsMappingsRemove(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSpawnersEventsMappingsRemove(ctx)
+	return err
+}
+
+// PostTasksCancel converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTasksCancel(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTasksCancel(ctx)
+	return err
+}
+
 // PostTasksLoad converts echo context to params.
 func (w *ServerInterfaceWrapper) PostTasksLoad(ctx echo.Context) error {
 	var err error
@@ -3960,6 +5184,15 @@ func (w *ServerInterfaceWrapper) PostTasksSpawn(ctx echo.Context) error {
 	return err
 }
 
+// PostTasksSpawnBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTasksSpawnBatch(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTasksSpawnBatch(ctx)
+	return err
+}
+
 // PostTasksSpawnFromSpec converts echo context to params.
 func (w *ServerInterfaceWrapper) PostTasksSpawnFromSpec(ctx echo.Context) error {
 	var err error
@@ -4145,9 +5378,16 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/resources/deallocate", wrapper.PostResourcesDeallocate)
 	router.POST(baseURL+"/resources/initialize", wrapper.PostResourcesInitialize)
 	router.POST(baseURL+"/resources/load", wrapper.PostResourcesLoad)
+	router.POST(baseURL+"/signals/:name", wrapper.PostSignalsName)
+	router.POST(baseURL+"/spawners/events/mappings/create", wrapper.PostSpawnersEventsMappingsCreate)
+	router.POST(baseURL+"/spawners/events/mappings/get", wrapper.PostSpawnersEventsMappingsGet)
+	router.POST(baseURL+"/spawners/events/mappings/get_all", wrapper.PostSpawnersEventsMappingsGetAll)
+	router.POST(baseURL+"/spawners/events/mappings/remove", wrapper.PostSpawnersEventsMappingsRemove)
+	router.POST(baseURL+"/tasks/cancel", wrapper.PostTasksCancel)
 	router.POST(baseURL+"/tasks/load", wrapper.PostTasksLoad)
 	router.POST(baseURL+"/tasks/restart", wrapper.PostTasksRestart)
 	router.POST(baseURL+"/tasks/spawn", wrapper.PostTasksSpawn)
+	router.POST(baseURL+"/tasks/spawn_batch", wrapper.PostTasksSpawnBatch)
 	router.POST(baseURL+"/tasks/spawn_from_spec", wrapper.PostTasksSpawnFromSpec)
 	router.POST(baseURL+"/tasks/specifications/create", wrapper.PostTasksSpecificationsCreate)
 	router.POST(baseURL+"/tasks/specifications/get", wrapper.PostTasksSpecificationsGet)
@@ -4168,104 +5408,281 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 }
 
-type PostResourcesAllocateRequestObject struct {
-	Params PostResourcesAllocateParams
+type PostResourcesAllocateRequestObject struct {
+	Params PostResourcesAllocateParams
+}
+
+type PostResourcesAllocateResponseObject interface {
+	VisitPostResourcesAllocateResponse(w http.ResponseWriter) error
+}
+
+type PostResourcesAllocate200JSONResponse []string
+
+func (response PostResourcesAllocate200JSONResponse) VisitPostResourcesAllocateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesAllocate500JSONResponse Error
+
+func (response PostResourcesAllocate500JSONResponse) VisitPostResourcesAllocateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesDeallocateRequestObject struct {
+	Body *PostResourcesDeallocateJSONRequestBody
+}
+
+type PostResourcesDeallocateResponseObject interface {
+	VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error
+}
+
+type PostResourcesDeallocate200JSONResponse map[string]interface{}
+
+func (response PostResourcesDeallocate200JSONResponse) VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesDeallocate500JSONResponse Error
+
+func (response PostResourcesDeallocate500JSONResponse) VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesInitializeRequestObject struct {
+	Body *PostResourcesInitializeJSONRequestBody
+}
+
+type PostResourcesInitializeResponseObject interface {
+	VisitPostResourcesInitializeResponse(w http.ResponseWriter) error
+}
+
+type PostResourcesInitialize200JSONResponse map[string]interface{}
+
+func (response PostResourcesInitialize200JSONResponse) VisitPostResourcesInitializeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesInitialize500JSONResponse Error
+
+func (response PostResourcesInitialize500JSONResponse) VisitPostResourcesInitializeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesLoadRequestObject struct {
+	Body *PostResourcesLoadJSONRequestBody
+}
+
+type PostResourcesLoadResponseObject interface {
+	VisitPostResourcesLoadResponse(w http.ResponseWriter) error
+}
+
+type PostResourcesLoad200JSONResponse []Resource
+
+func (response PostResourcesLoad200JSONResponse) VisitPostResourcesLoadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostResourcesLoad500JSONResponse Error
+
+func (response PostResourcesLoad500JSONResponse) VisitPostResourcesLoadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSignalsNameRequestObject struct {
+	Name string `json:"name"`
+	Body *PostSignalsNameJSONRequestBody
+}
+
+type PostSignalsNameResponseObject interface {
+	VisitPostSignalsNameResponse(w http.ResponseWriter) error
+}
+
+type PostSignalsName200JSONResponse struct {
+	Resource string `json:"resource"`
+}
+
+func (response PostSignalsName200JSONResponse) VisitPostSignalsNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSignalsName500JSONResponse Error
+
+func (response PostSignalsName500JSONResponse) VisitPostSignalsNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSpawnersEventsMappingsCreateRequestObject struct {
+	Body *PostSpawnersEventsMappingsCreateJSONRequestBody
+}
+
+type PostSpawnersEventsMappingsCreateResponseObject interface {
+	VisitPostSpawnersEventsMappingsCreateResponse(w http.ResponseWriter) error
 }
 
-type PostResourcesAllocateResponseObject interface {
-	VisitPostResourcesAllocateResponse(w http.ResponseWriter) error
+type PostSpawnersEventsMappingsCreate200Response struct {
 }
 
-type PostResourcesAllocate200JSONResponse []string
+func (response PostSpawnersEventsMappingsCreate200Response) VisitPostSpawnersEventsMappingsCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
 
-func (response PostResourcesAllocate200JSONResponse) VisitPostResourcesAllocateResponse(w http.ResponseWriter) error {
+type PostSpawnersEventsMappingsCreate500JSONResponse Error
+
+func (response PostSpawnersEventsMappingsCreate500JSONResponse) VisitPostSpawnersEventsMappingsCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSpawnersEventsMappingsGetRequestObject struct {
+	Body *PostSpawnersEventsMappingsGetJSONRequestBody
+}
+
+type PostSpawnersEventsMappingsGetResponseObject interface {
+	VisitPostSpawnersEventsMappingsGetResponse(w http.ResponseWriter) error
+}
+
+type PostSpawnersEventsMappingsGet200JSONResponse EventMapping
+
+func (response PostSpawnersEventsMappingsGet200JSONResponse) VisitPostSpawnersEventsMappingsGetResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesAllocate500JSONResponse Error
+type PostSpawnersEventsMappingsGet500JSONResponse Error
 
-func (response PostResourcesAllocate500JSONResponse) VisitPostResourcesAllocateResponse(w http.ResponseWriter) error {
+func (response PostSpawnersEventsMappingsGet500JSONResponse) VisitPostSpawnersEventsMappingsGetResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesDeallocateRequestObject struct {
-	Body *PostResourcesDeallocateJSONRequestBody
+type PostSpawnersEventsMappingsGetAllRequestObject struct {
 }
 
-type PostResourcesDeallocateResponseObject interface {
-	VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error
+type PostSpawnersEventsMappingsGetAllResponseObject interface {
+	VisitPostSpawnersEventsMappingsGetAllResponse(w http.ResponseWriter) error
 }
 
-type PostResourcesDeallocate200JSONResponse map[string]interface{}
+type PostSpawnersEventsMappingsGetAll200JSONResponse []EventMapping
 
-func (response PostResourcesDeallocate200JSONResponse) VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error {
+func (response PostSpawnersEventsMappingsGetAll200JSONResponse) VisitPostSpawnersEventsMappingsGetAllResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesDeallocate500JSONResponse Error
+type PostSpawnersEventsMappingsGetAll500JSONResponse Error
 
-func (response PostResourcesDeallocate500JSONResponse) VisitPostResourcesDeallocateResponse(w http.ResponseWriter) error {
+func (response PostSpawnersEventsMappingsGetAll500JSONResponse) VisitPostSpawnersEventsMappingsGetAllResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesInitializeRequestObject struct {
-	Body *PostResourcesInitializeJSONRequestBody
+type PostSpawnersEventsMappingsRemoveRequestObject struct {
+	Body *PostSpawnersEventsMappingsRemoveJSONRequestBody
 }
 
-type PostResourcesInitializeResponseObject interface {
-	VisitPostResourcesInitializeResponse(w http.ResponseWriter) error
+type PostSpawnersEventsMappingsRemoveResponseObject interface {
+	VisitPostSpawnersEventsMappingsRemoveResponse(w http.ResponseWriter) error
 }
 
-type PostResourcesInitialize200JSONResponse map[string]interface{}
+type PostSpawnersEventsMappingsRemove200Response struct {
+}
 
-func (response PostResourcesInitialize200JSONResponse) VisitPostResourcesInitializeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response PostSpawnersEventsMappingsRemove200Response) VisitPostSpawnersEventsMappingsRemoveResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+	return nil
 }
 
-type PostResourcesInitialize500JSONResponse Error
+type PostSpawnersEventsMappingsRemove500JSONResponse Error
 
-func (response PostResourcesInitialize500JSONResponse) VisitPostResourcesInitializeResponse(w http.ResponseWriter) error {
+func (response PostSpawnersEventsMappingsRemove500JSONResponse) VisitPostSpawnersEventsMappingsRemoveResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesLoadRequestObject struct {
-	Body *PostResourcesLoadJSONRequestBody
+type PostTasksCancelRequestObject struct {
+	Body *PostTasksCancelJSONRequestBody
 }
 
-type PostResourcesLoadResponseObject interface {
-	VisitPostResourcesLoadResponse(w http.ResponseWriter) error
+type PostTasksCancelResponseObject interface {
+	VisitPostTasksCancelResponse(w http.ResponseWriter) error
 }
 
-type PostResourcesLoad200JSONResponse []Resource
+type PostTasksCancel200JSONResponse struct {
+	// Cancelled Identifiers of cancelled tasks
+	Cancelled []string `json:"cancelled"`
+}
 
-func (response PostResourcesLoad200JSONResponse) VisitPostResourcesLoadResponse(w http.ResponseWriter) error {
+func (response PostTasksCancel200JSONResponse) VisitPostTasksCancelResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostResourcesLoad500JSONResponse Error
+type PostTasksCancel404JSONResponse Error
 
-func (response PostResourcesLoad500JSONResponse) VisitPostResourcesLoadResponse(w http.ResponseWriter) error {
+func (response PostTasksCancel404JSONResponse) VisitPostTasksCancelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksCancel409JSONResponse Error
+
+func (response PostTasksCancel409JSONResponse) VisitPostTasksCancelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksCancel500JSONResponse Error
+
+func (response PostTasksCancel500JSONResponse) VisitPostTasksCancelResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -4317,6 +5734,24 @@ func (response PostTasksRestart200JSONResponse) VisitPostTasksRestartResponse(w
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTasksRestart404JSONResponse Error
+
+func (response PostTasksRestart404JSONResponse) VisitPostTasksRestartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksRestart409JSONResponse Error
+
+func (response PostTasksRestart409JSONResponse) VisitPostTasksRestartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostTasksRestart500JSONResponse Error
 
 func (response PostTasksRestart500JSONResponse) VisitPostTasksRestartResponse(w http.ResponseWriter) error {
@@ -4343,6 +5778,15 @@ func (response PostTasksSpawn200JSONResponse) VisitPostTasksSpawnResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTasksSpawn400JSONResponse Error
+
+func (response PostTasksSpawn400JSONResponse) VisitPostTasksSpawnResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostTasksSpawn500JSONResponse Error
 
 func (response PostTasksSpawn500JSONResponse) VisitPostTasksSpawnResponse(w http.ResponseWriter) error {
@@ -4352,6 +5796,41 @@ func (response PostTasksSpawn500JSONResponse) VisitPostTasksSpawnResponse(w http
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTasksSpawnBatchRequestObject struct {
+	Body *PostTasksSpawnBatchJSONRequestBody
+}
+
+type PostTasksSpawnBatchResponseObject interface {
+	VisitPostTasksSpawnBatchResponse(w http.ResponseWriter) error
+}
+
+type PostTasksSpawnBatch200JSONResponse []TaskSpawnResponse
+
+func (response PostTasksSpawnBatch200JSONResponse) VisitPostTasksSpawnBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksSpawnBatch400JSONResponse Error
+
+func (response PostTasksSpawnBatch400JSONResponse) VisitPostTasksSpawnBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksSpawnBatch500JSONResponse Error
+
+func (response PostTasksSpawnBatch500JSONResponse) VisitPostTasksSpawnBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostTasksSpawnFromSpecRequestObject struct {
 	Body *PostTasksSpawnFromSpecJSONRequestBody
 }
@@ -4369,6 +5848,15 @@ func (response PostTasksSpawnFromSpec200JSONResponse) VisitPostTasksSpawnFromSpe
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTasksSpawnFromSpec400JSONResponse Error
+
+func (response PostTasksSpawnFromSpec400JSONResponse) VisitPostTasksSpawnFromSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostTasksSpawnFromSpec500JSONResponse Error
 
 func (response PostTasksSpawnFromSpec500JSONResponse) VisitPostTasksSpawnFromSpecResponse(w http.ResponseWriter) error {
@@ -4800,6 +6288,24 @@ type StrictServerInterface interface {
 	// Load resources
 	// (POST /resources/load)
 	PostResourcesLoad(ctx context.Context, request PostResourcesLoadRequestObject) (PostResourcesLoadResponseObject, error)
+	// Send a signal
+	// (POST /signals/{name})
+	PostSignalsName(ctx context.Context, request PostSignalsNameRequestObject) (PostSignalsNameResponseObject, error)
+	// Create an event mapping (or replace the one with the same id)
+	// (POST /spawners/events/mappings/create)
+	PostSpawnersEventsMappingsCreate(ctx context.Context, request PostSpawnersEventsMappingsCreateRequestObject) (PostSpawnersEventsMappingsCreateResponseObject, error)
+	// Get an event mapping by id
+	// (POST /spawners/events/mappings/get)
+	PostSpawnersEventsMappingsGet(ctx context.Context, request PostSpawnersEventsMappingsGetRequestObject) (PostSpawnersEventsMappingsGetResponseObject, error)
+	// Get all event mappings
+	// (POST /spawners/events/mappings/get_all)
+	PostSpawnersEventsMappingsGetAll(ctx context.Context, request PostSpawnersEventsMappingsGetAllRequestObject) (PostSpawnersEventsMappingsGetAllResponseObject, error)
+	// Remove an event mapping
+	// (POST /spawners/events/mappings/remove)
+	PostSpawnersEventsMappingsRemove(ctx context.Context, request PostSpawnersEventsMappingsRemoveRequestObject) (PostSpawnersEventsMappingsRemoveResponseObject, error)
+	// Cancel a task
+	// (POST /tasks/cancel)
+	PostTasksCancel(ctx context.Context, request PostTasksCancelRequestObject) (PostTasksCancelResponseObject, error)
 	// Load a set of tasks
 	// (POST /tasks/load)
 	PostTasksLoad(ctx context.Context, request PostTasksLoadRequestObject) (PostTasksLoadResponseObject, error)
@@ -4809,6 +6315,9 @@ type StrictServerInterface interface {
 	// Spawn a new task
 	// (POST /tasks/spawn)
 	PostTasksSpawn(ctx context.Context, request PostTasksSpawnRequestObject) (PostTasksSpawnResponseObject, error)
+	// Spawn several tasks at once (either all of them are spawned or none)
+	// (POST /tasks/spawn_batch)
+	PostTasksSpawnBatch(ctx context.Context, request PostTasksSpawnBatchRequestObject) (PostTasksSpawnBatchResponseObject, error)
 	// Spawn a new task from specification
 	// (POST /tasks/spawn_from_spec)
 	PostTasksSpawnFromSpec(ctx context.Context, request PostTasksSpawnFromSpecRequestObject) (PostTasksSpawnFromSpecResponseObject, error)
@@ -4986,6 +6495,175 @@ func (sh *strictHandler) PostResourcesLoad(ctx echo.Context) error {
 	return nil
 }
 
+// PostSignalsName operation middleware
+func (sh *strictHandler) PostSignalsName(ctx echo.Context, name string) error {
+	var request PostSignalsNameRequestObject
+	request.Name = name
+
+	var body PostSignalsNameJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSignalsName(ctx.Request().Context(), request.(PostSignalsNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSignalsName")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSignalsNameResponseObject); ok {
+		return validResponse.VisitPostSignalsNameResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSpawnersEventsMappingsCreate operation middleware
+func (sh *strictHandler) PostSpawnersEventsMappingsCreate(ctx echo.Context) error {
+	var request PostSpawnersEventsMappingsCreateRequestObject
+
+	var body PostSpawnersEventsMappingsCreateJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSpawnersEventsMappingsCreate(ctx.Request().Context(), request.(PostSpawnersEventsMappingsCreateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSpawnersEventsMappingsCreate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSpawnersEventsMappingsCreateResponseObject); ok {
+		return validResponse.VisitPostSpawnersEventsMappingsCreateResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSpawnersEventsMappingsGet operation middleware
+func (sh *strictHandler) PostSpawnersEventsMappingsGet(ctx echo.Context) error {
+	var request PostSpawnersEventsMappingsGetRequestObject
+
+	var body PostSpawnersEventsMappingsGetJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSpawnersEventsMappingsGet(ctx.Request().Context(), request.(PostSpawnersEventsMappingsGetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSpawnersEventsMappingsGet")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSpawnersEventsMappingsGetResponseObject); ok {
+		return validResponse.VisitPostSpawnersEventsMappingsGetResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSpawnersEventsMappingsGetAll operation middleware
+func (sh *strictHandler) PostSpawnersEventsMappingsGetAll(ctx echo.Context) error {
+	var request PostSpawnersEventsMappingsGetAllRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSpawnersEventsMappingsGetAll(ctx.Request().Context(), request.(PostSpawnersEventsMappingsGetAllRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSpawnersEventsMappingsGetAll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSpawnersEventsMappingsGetAllResponseObject); ok {
+		return validResponse.VisitPostSpawnersEventsMappingsGetAllResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSpawnersEventsMappingsRemove operation middleware
+func (sh *strictHandler) PostSpawnersEventsMappingsRemove(ctx echo.Context) error {
+	var request PostSpawnersEventsMappingsRemoveRequestObject
+
+	var body PostSpawnersEventsMappingsRemoveJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSpawnersEventsMappingsRemove(ctx.Request().Context(), request.(PostSpawnersEventsMappingsRemoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSpawnersEventsMappingsRemove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSpawnersEventsMappingsRemoveResponseObject); ok {
+		return validResponse.VisitPostSpawnersEventsMappingsRemoveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostTasksCancel operation middleware
+func (sh *strictHandler) PostTasksCancel(ctx echo.Context) error {
+	var request PostTasksCancelRequestObject
+
+	var body PostTasksCancelJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTasksCancel(ctx.Request().Context(), request.(PostTasksCancelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTasksCancel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTasksCancelResponseObject); ok {
+		return validResponse.VisitPostTasksCancelResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostTasksLoad operation middleware
 func (sh *strictHandler) PostTasksLoad(ctx echo.Context) error {
 	var request PostTasksLoadRequestObject
@@ -5073,6 +6751,35 @@ func (sh *strictHandler) PostTasksSpawn(ctx echo.Context) error {
 	return nil
 }
 
+// PostTasksSpawnBatch operation middleware
+func (sh *strictHandler) PostTasksSpawnBatch(ctx echo.Context) error {
+	var request PostTasksSpawnBatchRequestObject
+
+	var body PostTasksSpawnBatchJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostTasksSpawnBatch(ctx.Request().Context(), request.(PostTasksSpawnBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostTasksSpawnBatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostTasksSpawnBatchResponseObject); ok {
+		return validResponse.VisitPostTasksSpawnBatchResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostTasksSpawnFromSpec operation middleware
 func (sh *strictHandler) PostTasksSpawnFromSpec(ctx echo.Context) error {
 	var request PostTasksSpawnFromSpecRequestObject
@@ -5551,36 +7258,49 @@ func (sh *strictHandler) PostTasksStorageUpdateWithProperties(ctx echo.Context)
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbXW/bNhf+KwTf92ID1NhbsRvfpU1aGB2WoOm2i64waPPYZiORKknF8Qr/94GkZH2E",
-	"kmnHTmw0N20i8eOc8zzng0fMdzwRSSo4cK3w4DtWkzkkxP54zpd/kTgD8zPhy6spHnz+jvUyBTzASkvG",
-	"Z3gVrZ/wLBmDrD5hXMOs/mgsRAyE20dMQ2I2WkXFWyIlWVaHi/FXmGi8+hLh+1cz8aoYx80ofCmlkEa6",
-	"VIoUpGZg5U5AKTKzYlNQE8lSzQTHA3yO5llCOJJAKBnHgMAsgIrxUVO3VYQlfMuYBIoHn3Fj3JeoKWWE",
-	"P4ISmZzAQ6EYNf82jYeVJjpT3ld3he27pWIUr5cpJnWJNuRMMxKzf0EGS7mNKO0S3KQwYVM2IQ6O5t5w",
-	"D5NMG1zMb/+XMMUD/L9eSc9ezs1ebaHLctoqalOAia3WHF6ZSQloUlmtUMSnNBM4qiqQT95ohTdEAf1E",
-	"1O01kSQBDVJ5QOHTjeKbNYZm3CrCaW2twsseGqXhdFg18elGu7JNc27kZN6o/mUN8rrWlHitXyy5STr7",
-	"NnKrbJRjeOWzepq5kBhMm8LFbsBuITL9uCWaTHMSlQtv1Ku62gMFOUmM4aoUaVqAwn3F0mU4N3ND4oFd",
-	"IB/tE7bJQPO72k2ighWdTmLGtAnZGtPrQjZm50YsRPfNN64ZHGm39fWSpeF+XuFl6KRNTMwFt/G3zQbD",
-	"XDXvy33Gv+OySZs5blKyMA6aCq5C64WHqad1dS0kmcFbkSSEU1+N5KIroZSZSEHi68p7LTPwLGwGtCWH",
-	"PO3ULbgJsbqUlgMb7VwKsd6yI9u27eKLhtBd+3Qpsy6VPfEBOouiioA3oDXjM7UntLbKk+0GzFcJtJbK",
-	"xqN1FPeLGxKjo2YxAFOSxTo4wHeXbDkopaxtiv/N9Hx4ERy8d89BtD0BrSpZoX6ceSuBaECEU+RKT0CU",
-	"GWnGmQaKFkLeTmOxMP6hmTY1Fv5AuBa3GTq/HhpWglRuqV/O+mf93MU5SRke4Nf2kfExPbdK92ReTage",
-	"iWMxIdoRQyiLy9ovhxQP8LVQuqg+1HkxvF6bfjahHQ/wtwzksigTBpgkIuMaVy3kGO5M6Ev/5nwo81Bq",
-	"Zf213zf/TQTXwK14JE3jvCrqfVUuhJULBsf8VdQA4eqDGfXblvt1McSdaj1bvSMszqRjj8qShMilOdTm",
-	"xlXoD7RGyI6pAEZhS8guygkOCFD6jaDLJ7LqOYqZ0khM1yohRoFrNmXuvFHnxuqR8Htc7shQLvFoB5mt",
-	"T/aBIJetgH2A3KWtr/kQRAPKJjaDyCX6ab3IBXr1T9bvvwZkk+7PPyAfSkO28yEWhAYy4Xcz9Adw9K3I",
-	"ehLh3yDXpIAm6jYEflN7HgP0Rt7nh92e008GcoIUrI1Xw12C0kTqAOg/5iN3R39PZ1YfOXJS7J0LBxP5",
-	"2XmRw4kImhIWA7UmrDJDpWTBA3hhWxOPYMUmL6s0ezzKfZoDqrWTDcuJ44MWaGzekgWH/VNjY7ui1rI5",
-	"RgpYCRFBHBZ+9EdTKZKRMW8oD95JkdyY8Yfhw8bPIS8MOShDkCFE3Zp10lReqN7EthyCqFOd5zoVT8Eg",
-	"Z4gg0I8MmKKbE4zFDPTWQLyHI8n2T+eWHnocGfLvQddBV2i8NKVPJ/YjEse74H8ex/gpyumG3U+grjY4",
-	"kDhuYNGBgoRE3G0fDT+6aafjh0dX5xr7bREpbZN/19z1yUw+aAKrfGk4/eyli+8bXVDskrosDj9g/mqy",
-	"4xiDpkV9Y9JaY79T6irwf6r8VbX7CSUv90FzEwg7Zi6LwUv62lP6ehAq3TWAHoUYwhKVm3Dhxj9LD5cj",
-	"+67SxVXmCE6rIp0aQM6errGKFkzPkZ4DmrE74EY/H2RwH9ZQccMv7w/WTvHc/fF2Vyf5y4N12ne4qnLE",
-	"0fUyv19BCsMhxi0rLOlzFviIYbLdeDkytAmmx3vQb5ZDqp7doxl9+RQTUv1Uv2PZs7ueA5NtscJQwkSV",
-	"UT0PBnPDFCUVn9pXHi5/G2kxshfWOi9xBQFZ3ovzX/Ztv6PlFSfkM0zaNM0Ld9u5W8lxqRR3jAJFFQN6",
-	"2Mu4gqCPizlhh278ge+XhBq9Wa/YYoVVRTy1YsXZN1cmT0qUaDImypuQVPWSaRCC62uph/520tjuaFu1",
-	"Tk6kSkEfWDlLKdGwdeb/0057bPJvnq+2vApfn75FHffg0GaUqKwWEr2dBVD+1wSn6JC5BrYwLJV3zZEu",
-	"ruxcErgND1QVcFiMdiZEdBJFRdRU8pmrjMbVf0HZlAH1Xntu/H1qMfQkbqtU/KSzAjGTQN4VN8YzGeMB",
-	"nmudqkGvd+vus5+RlJ25UYPX/X4fr76s/gsAAP//fvwH/tk8AAA=",
+	"H4sIAAAAAAAC/+xcW3PbuBX+Kxi0M2vPMJa22T5Ub85lM+puk0yctg9JRgMRRxI2JMAFQNlqRv+9A4Ck",
+	"eBUhWbKlsV92HRKXc/nOBeeA+oFDESeCA9cKj35gFS4gJvbPa776D4lSMH8Tvvoww6MvP7BeJYBHWGnJ",
+	"+Byvg+IJT+MpyPITxjXMq4+mQkRAuH3ENMRmo3WQvyVSklV5uJj+AaHG628BvnsxFy/ycdyMwm+lFNJQ",
+	"l0iRgNQMLN0xKEXmlmwKKpQs0UxwPMLXaJHGhCMJhJJpBAjMAigfH9R5WwdYwp8pk0Dx6AuujfsW1KkM",
+	"8NslcP0vkiRmfoMwRs1/6wLEjM9E6cVmNcaTVKsmH68Yp4zPFRIzpBeAVAIhm7GQmPc/KeTmoQvGkZAU",
+	"5CUOCmHjv0qY4RH+y2Cj90Gm9EGZ/LFZBDd1gyu7tTKkRcLCljc1eTKK87H1VQve+4TsqGxIOiF60aZ/",
+	"KjRSkBBJNFBkRiEtEEEzBhHNxfnPmw/vUUJWkSAUXZgntwsRQfGIzRDEiV5dNhET4GVuMfWtQ8GVJlyj",
+	"VAFFjCsNpNgyW7oVgg3+P4ESqQzBG2BKE52q1lcFuR6qypbJJ33bQtqYM81IxP4H0ptKraOm3H5nM9As",
+	"hlxQMtsAkZkGiVi+j4UNuoCr+RX6in9evBzGX/F2BXlw3M3oTd0IqizCHYSpNj6mz+QqC73dTFsHXXJi",
+	"Yqc1xx/MpBg0afExbUwzgYMyA9nkXim8IgroZ6K+fySSxKBBqhbd81kv+WaNsRm3DnBSWatwYk3w7Oik",
+	"aoyXtmlzRTPRz/7bisqrXFPSKv18yT7q7NvArdJLx/hDm9TzQOINm9ySb8BuIVJ9vyXqSHMUbRbu5au8",
+	"WoNBTuIs4hYQqUuAwh0etaQmZq6PP7ALZKPbiK0j0Pxb7UdRjoqtRmLGdBHZmZ9UiazNzoSYk94235jm",
+	"znmNr61vUOpv5yVc+k7qQ2JGuPW/XTIYd6Vsh/Z/pyWTLnHcJOTWGGgiuPJNS5qhp3N1LSSZw2sRx4TT",
+	"tnzfeVdCKdNMcBJ9LL3XMoWWhc2A7gzWxoOqBPs0VqXSYqBXzhsiii23RNuuXdq8IWzP9rYxUxz7WvwD",
+	"bE2KSgTegNbmiHIgbe0UJ7sFmK3iKS2VTieFF28n18dHB/VkAGYkjbS3g9+esmVK2dDaxfh/mV6M3/hn",
+	"43vHINodgNalqFDN819LIBoQ4RS51BMQZYaaaWpOardCfp9F4tbYh2ba5Fj4N8K1+J6i649jg0qQyi31",
+	"89XwapiZOCcJwyP80j4K7LHQMj3IzxFqQKJIhEQ7YAhl9VLY5ZjiEf4olM6zD3WdD6/mpl9+YGY2/zMF",
+	"ucrThBEmsUi5xmUJOYQ7EbaF/3VQl03XGUg1zjuBeb9CHJYgEdwlTII5rXJ76tU4aKXRnLtaCCps7Juh",
+	"3rl2K7u/DYfmf6HgGrgVF0mSKMvSBn8o51I363nHoHVQY/zDb2bU33fcb2uBw1aMWrb6lbAolQ7NKo1j",
+	"Ilfm1J4pW6H3G6nbMSUAUdgRQm82ExwwQOlXgq4eSKrXKGJKGzDlPEzoFDEKXLMZc0egKlzX90RAixc4",
+	"MUVvVNKt56LW4KvnTRHkEHrexm1b2cULCZSF5m8iV+iiWOQNevE1HQ5fArJ5wOUTxMNGkN14sAU7PyT8",
+	"7mp7T8HWd8LrWQQBo7w6ChSbcxKpwQ8TQ9dlFFRX/LwolY0VUlpIoIgoRIoVA0QhAU6BhwwUuiXMJM5o",
+	"JqSr7dudEJEOidESDJKaWLtxFL0ncVdqYmviRdTnbmBvXlJNA/bDb702v8pNp7fYvV7XSbwvPqu0yFIp",
+	"vaq5cWEjjdKz0aJRUV/hvpwVFxu1J8UnBvkb4BSRDHsZ4s0RH6QawNKuHLv+ixqENnPf7ghvssm2c6Oy",
+	"1o1yOf89/KJvK2sHHJ2YIopjEbJiR5nU0YWQSEISkRAsDAUHdMv0wrkMEgNi9LJHcXPQ+2jtHWh8KFew",
+	"d2Ho8H5hNyydGEzegW5iZLpCjPZjYEKiaE8cXEcRfoh0oSr/M0gZrD6iqKoQ1aMLCbFY7uVIP7mZ52OV",
+	"J6YuJ7+GBTl9aaK+q0FIeAg9dmLKn+q1G3hUXZjFieooXUsIU6nYsiWncbQZJuUKGb7QTIp4EzNc5Y0J",
+	"jiy1d9rV4+xwsxJwSri2M3HQuNHji5D6CcUSwig+bqbnFBgB3Zbq2Rs1xUhLmSrfndmti7LZ0j/p+2X4",
+	"y/GtwJxKrNSpAMV/0gjumNJu+3884PZM2dIkCTWz5reKhYTHzricjRCH8pIH6D/uW/s/gaN+ZlCPfMb/",
+	"bOV3Lud7ghQUwlNlvUtQmkjtofpP2ciTiMOP42UPSPLT84c/KeSu2BV0mKL0rasVWWQ9dppkqTDXJQnL",
+	"42PZVGxq62EoNos90rm/dgGjQ9yVK17G7J2/N5dBp+atybIPbyu9Vwgq1yi22MQDQGDMlyRiTsdoU07M",
+	"Wo4mZdQsBpHqy8euVRmhIYI43LYDcjIlOlz4wvKVHXzkfk0dpP1RsnIVThWB6siA9b/8UkWub9R/RnIT",
+	"ycqct0iU6ZdoJHgI6AKYXoC0VQVXj45tTyDTPBISccHhsol+c8SbGH/nawG/ShEbuB3JQffeGX522U/N",
+	"ZbsyREXBVRyXXvg1HDI0l+cdtdtwU6P9zNsN3rro7SG0KOIJNhBa4HGCFeuqbsv9gy7d9/cO2vX/UI2D",
+	"mtzPqHNQlfUWLfh0DVqU8NwyOEzLwNtTGr73jl3mou1xA1jphvD5Ry+d30vepop9QpfVwxOMX3V0nKLT",
+	"tFrvDVqF7vcKXbn+Hyp+leV+RsHLCrlXCXtGLquD5/B1oPDVcJXu850BhQj8ApWb8MaNf5TGF0f2Xan1",
+	"ZetitEzSuSnIyTMrAhU3u+ZsCdzw16Yy0733V5j5XPmIJfjaN3utLakwe3m0quUen5idsHd9m30XRXLB",
+	"IcZdVceAPkNBGzBMtJuuJgY23vB4B/rVakzVo1s0o8/9a5/sp9z8t2d3vQAmu3yFgYTxKpPa1WxfbJik",
+	"pGRTB7saXvxrosXEfmi69eNLL0Vuvmdt/0i/+9vKVnJ8etdJXTTP2O3GbinGJVIsGTW/zLMRYAt6GVcg",
+	"tT9gx278AzT19slXbLLCyiSeW7Li5JsxkwUlSjSZEtUakFT543AvDRafkx+7nVPb7mRLtY5OpDaENqSc",
+	"JpRo2Dny/9tOu2/wr5+vdvwJi+r0HfK4xqFN4cpqPt7bSQBlvwJyjgaZceAagQXzrjiyDSt7pwRuwyNl",
+	"BRxuJ3sDIjiLpCKoM/nIWUZV3LGgbMaAtv9cQYW7YuhZfOdWspOtGYiZBHKZf06ZygiP8ELrRI0Gg+/u",
+	"dyiuSMKu3KjRy+FwiNff1v8fAF808MtdUwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -7,9 +7,11 @@ import (
 	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
 	"github.com/samber/lo"
 	"net/http"
+	"time"
 )
 
 var _ core.ResourceDB = (*resourceStorage)(nil)
+var _ core.ExpiringResourceDB = (*resourceStorage)(nil)
 
 type resourceStorage struct {
 	httpClient oas.ClientWithResponsesInterface
@@ -40,10 +42,18 @@ func (storage resourceStorage) Load(ctx context.Context, ids ...string) ([]core.
 }
 
 func (storage resourceStorage) Alloc(ctx context.Context, amount int) ([]string, error) {
-	res, err := storage.httpClient.PostResourcesAllocateWithResponse(ctx,
-		&oas.PostResourcesAllocateParams{
-			Amount: amount,
-		})
+	return storage.AllocWithTTL(ctx, amount, 0)
+}
+
+func (storage resourceStorage) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
+	params := &oas.PostResourcesAllocateParams{
+		Amount: amount,
+	}
+	if ttl > 0 {
+		params.Ttl = lo.ToPtr(ttl.String())
+	}
+
+	res, err := storage.httpClient.PostResourcesAllocateWithResponse(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make an http request: %w", err)
 	}
@@ -63,10 +73,14 @@ func (storage resourceStorage) Alloc(ctx context.Context, amount int) ([]string,
 func (storage resourceStorage) Init(ctx context.Context, resources []core.Resource) error {
 	res, err := storage.httpClient.PostResourcesInitializeWithResponse(ctx,
 		lo.Map(resources, func(res core.Resource, _ int) oas.ResourceInitializer {
-			return oas.ResourceInitializer{
+			initializer := oas.ResourceInitializer{
 				Id:    res.ID,
 				Value: string(res.Data),
 			}
+			if res.TTL > 0 {
+				initializer.Ttl = lo.ToPtr(res.TTL.String())
+			}
+			return initializer
 		}))
 	if err != nil {
 		return nil
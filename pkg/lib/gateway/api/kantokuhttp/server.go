@@ -2,16 +2,19 @@ package kantokuhttp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/common/data/storage"
 	"github.com/ischenkx/kantoku/pkg/core"
-	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/signal_resolver"
 	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/canceller"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/restarter"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification/typing"
 	"github.com/samber/lo"
-	"log/slog"
+	"time"
 )
 
 var _ oas.StrictServerInterface = (*Server)(nil)
@@ -19,12 +22,14 @@ var _ oas.StrictServerInterface = (*Server)(nil)
 type Server struct {
 	system         core.AbstractSystem
 	specifications *specification.Manager
+	eventMappings  *specification.JsonStorage[event.Mapping]
 }
 
-func NewServer(system core.AbstractSystem, specifications *specification.Manager) *Server {
+func NewServer(system core.AbstractSystem, specifications *specification.Manager, eventMappings *specification.JsonStorage[event.Mapping]) *Server {
 	return &Server{
 		system:         system,
 		specifications: specifications,
+		eventMappings:  eventMappings,
 	}
 }
 
@@ -146,7 +151,16 @@ func (server *Server) PostResourcesAllocate(ctx context.Context, request oas.Pos
 		return oas.PostResourcesAllocate200JSONResponse{}, nil
 	}
 
-	ids, err := server.system.Resources().Alloc(ctx, n)
+	var ttl time.Duration
+	if request.Params.Ttl != nil {
+		var err error
+		ttl, err = time.ParseDuration(*request.Params.Ttl)
+		if err != nil {
+			return oas.PostResourcesAllocate500JSONResponse{Message: fmt.Sprintf("invalid ttl: %s", err)}, nil
+		}
+	}
+
+	ids, err := core.AllocWithTTL(ctx, server.system.Resources(), n, ttl)
 	if err != nil {
 		return oas.PostResourcesAllocate500JSONResponse{Message: fmt.Sprintf("failed to allocate resources: %s", err)}, nil
 	}
@@ -167,9 +181,21 @@ func (server *Server) PostResourcesInitialize(ctx context.Context, request oas.P
 	var resources []core.Resource
 
 	for _, initializer := range *request.Body {
+		var ttl time.Duration
+		if initializer.Ttl != nil {
+			var err error
+			ttl, err = time.ParseDuration(*initializer.Ttl)
+			if err != nil {
+				return oas.PostResourcesInitialize500JSONResponse{
+					Message: fmt.Sprintf("invalid ttl (id='%s'): %s", initializer.Id, err),
+				}, nil
+			}
+		}
+
 		resources = append(resources, core.Resource{
 			Data: []byte(initializer.Value),
 			ID:   initializer.Id,
+			TTL:  ttl,
 		})
 	}
 
@@ -224,6 +250,11 @@ func (server *Server) PostTasksSpawn(ctx context.Context, request oas.PostTasksS
 		Outputs: request.Body.Outputs,
 		Info:    request.Body.Info,
 	})
+	if errors.Is(err, core.ErrInvalidTimeout) {
+		return oas.PostTasksSpawn400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return oas.PostTasksSpawn500JSONResponse{
 			Message: fmt.Sprintf("failed to spawn a new task: %s", err),
@@ -233,13 +264,33 @@ func (server *Server) PostTasksSpawn(ctx context.Context, request oas.PostTasksS
 	return oas.PostTasksSpawn200JSONResponse{Id: spawnedTask.ID}, nil
 }
 
-func (server *Server) PostTasksSpawnFromSpec(ctx context.Context, request oas.PostTasksSpawnFromSpecRequestObject) (oas.PostTasksSpawnFromSpecResponseObject, error) {
-	type txParams struct {
-		Inputs  []string
-		Outputs []string
-		Id      string
+func (server *Server) PostTasksSpawnBatch(ctx context.Context, request oas.PostTasksSpawnBatchRequestObject) (oas.PostTasksSpawnBatchResponseObject, error) {
+	tasks := lo.Map(*request.Body, func(params oas.TaskParameters, _ int) core.Task {
+		return core.Task{
+			Inputs:  params.Inputs,
+			Outputs: params.Outputs,
+			Info:    params.Info,
+		}
+	})
+
+	spawnedTasks, err := server.system.SpawnBatch(ctx, tasks)
+	if errors.Is(err, core.ErrInvalidTimeout) {
+		return oas.PostTasksSpawnBatch400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return oas.PostTasksSpawnBatch500JSONResponse{
+			Message: fmt.Sprintf("failed to spawn tasks: %s", err),
+		}, nil
 	}
 
+	return oas.PostTasksSpawnBatch200JSONResponse(lo.Map(spawnedTasks, func(t core.Task, _ int) oas.TaskSpawnResponse {
+		return oas.TaskSpawnResponse{Id: t.ID}
+	})), nil
+}
+
+func (server *Server) PostTasksSpawnFromSpec(ctx context.Context, request oas.PostTasksSpawnFromSpecRequestObject) (oas.PostTasksSpawnFromSpecResponseObject, error) {
 	spec, err := server.specifications.Specifications().Get(ctx, request.Body.Specification)
 	if err != nil {
 		return oas.PostTasksSpawnFromSpec500JSONResponse{
@@ -247,94 +298,77 @@ func (server *Server) PostTasksSpawnFromSpec(ctx context.Context, request oas.Po
 		}, nil
 	}
 
-	if len(request.Body.Parameters) != len(spec.IO.Inputs.Types) {
-		return oas.PostTasksSpawnFromSpec500JSONResponse{
-			Message: fmt.Sprintf("incorrect amount of input parameters: %d (expected %d)", len(request.Body.Parameters), len(spec.IO.Inputs.Types)),
-		}, nil
-	}
-
-	tx := lo.NewTransaction[txParams]().
-		Then(
-			func(params txParams) (txParams, error) {
-				// resource_db allocation
-				inputAmounts := len(spec.IO.Inputs.Types)
-				outputAmounts := len(spec.IO.Outputs.Types)
-				totalResources := inputAmounts + outputAmounts
-				resources, err := server.system.Resources().Alloc(ctx, totalResources)
-				if err != nil {
-					return params, fmt.Errorf("failed to allocate resources: %s", err)
-				}
-				params.Inputs = resources[:inputAmounts]
-				params.Outputs = resources[inputAmounts:]
-
-				return params, nil
-			},
-			func(params txParams) txParams {
-				err := server.system.Resources().Dealloc(ctx, append(params.Inputs, params.Outputs...))
-				if err != nil {
-					slog.Error("failed to dealloc resources", slog.String("error", err.Error()))
-				}
-				return params
-			},
-		).
-		Then(
-			func(params txParams) (txParams, error) {
-				initializedResources := make([]core.Resource, 0, len(params.Inputs))
-				for index := 0; index < len(params.Inputs); index++ {
-					param := request.Body.Parameters[index]
-					resourceId := params.Inputs[index]
-					initializedResources = append(initializedResources, core.Resource{
-						Data: []byte(param),
-						ID:   resourceId,
-					})
-				}
-
-				err := server.system.Resources().Init(ctx, initializedResources)
-				if err != nil {
-					return params, fmt.Errorf("failed to initialize resources: %s", err)
-				}
-
-				return params, nil
-			},
-			func(params txParams) txParams {
-				return params
-			},
-		).
-		Then(
-			func(params txParams) (txParams, error) {
-				t, err := server.system.Spawn(ctx, core.New(
-					taskopts.WithInputs(params.Inputs...),
-					taskopts.WithOutputs(params.Outputs...),
-					taskopts.WithInfo(request.Body.Info),
-					taskopts.WithProperty("type", request.Body.Specification),
-					taskopts.DependOnInputs(),
-				))
-				if err != nil {
-					return params, fmt.Errorf("failed to spawn a new task: %s", err)
-				}
-
-				params.Id = t.ID
-
-				return params, nil
-			},
-			func(params txParams) txParams {
-				return params
-			},
-		)
-
-	result, err := tx.Process(txParams{})
-
+	t, err := specification.Spawn(ctx, server.system, spec, request.Body.Parameters, request.Body.Info)
+	if errors.Is(err, core.ErrInvalidTimeout) {
+		return oas.PostTasksSpawnFromSpec400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return oas.PostTasksSpawnFromSpec500JSONResponse{
 			Message: fmt.Sprintf("failed to spawn a new task: %s", err),
 		}, nil
 	}
 
-	return oas.PostTasksSpawnFromSpec200JSONResponse{Id: result.Id}, nil
+	return oas.PostTasksSpawnFromSpec200JSONResponse{Id: t.ID}, nil
+}
+
+func (server *Server) PostTasksCancel(ctx context.Context, request oas.PostTasksCancelRequestObject) (oas.PostTasksCancelResponseObject, error) {
+	reason := lo.FromPtr(request.Body.Reason)
+
+	if lo.FromPtr(request.Body.Recursive) {
+		cancelled, err := canceller.CancelTree(ctx, server.system, request.Body.Id, reason)
+		if errors.Is(err, core.ErrTaskNotFound) {
+			return oas.PostTasksCancel404JSONResponse{
+				Message: err.Error(),
+			}, nil
+		}
+		if err != nil {
+			return oas.PostTasksCancel500JSONResponse{
+				Message: err.Error(),
+			}, nil
+		}
+
+		// an empty list instead of null
+		if cancelled == nil {
+			cancelled = []string{}
+		}
+
+		return oas.PostTasksCancel200JSONResponse{Cancelled: cancelled}, nil
+	}
+
+	err := server.system.Cancel(ctx, request.Body.Id, reason)
+	if errors.Is(err, core.ErrTaskNotFound) {
+		return oas.PostTasksCancel404JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+	if errors.Is(err, core.ErrIllegalTransition) {
+		return oas.PostTasksCancel409JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return oas.PostTasksCancel500JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return oas.PostTasksCancel200JSONResponse{Cancelled: []string{request.Body.Id}}, nil
 }
 
 func (server *Server) PostTasksRestart(ctx context.Context, request oas.PostTasksRestartRequestObject) (oas.PostTasksRestartResponseObject, error) {
 	newTaskID, err := restarter.Restart(ctx, server.system, request.Body.Id)
+	if errors.Is(err, core.ErrTaskNotFound) {
+		return oas.PostTasksRestart404JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+	if errors.Is(err, core.ErrIllegalTransition) {
+		return oas.PostTasksRestart409JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return oas.PostTasksRestart500JSONResponse{
 			Message: err.Error(),
@@ -486,6 +520,81 @@ func (server *Server) PostTasksSpecificationsTypesRemove(ctx context.Context, re
 	return oas.PostTasksSpecificationsTypesRemove200Response{}, nil
 }
 
+func (server *Server) PostSpawnersEventsMappingsCreate(ctx context.Context, request oas.PostSpawnersEventsMappingsCreateRequestObject) (oas.PostSpawnersEventsMappingsCreateResponseObject, error) {
+	mapping := eventMappingFromModel(*request.Body)
+
+	spec, err := server.specifications.Specifications().Get(ctx, mapping.Specification)
+	if err != nil {
+		return oas.PostSpawnersEventsMappingsCreate500JSONResponse{
+			Message: fmt.Sprintf("failed to load the specification: %s", err),
+		}, nil
+	}
+
+	if len(mapping.Inputs) != len(spec.IO.Inputs.Types) {
+		return oas.PostSpawnersEventsMappingsCreate500JSONResponse{
+			Message: fmt.Sprintf("incorrect amount of inputs: %d (expected %d)", len(mapping.Inputs), len(spec.IO.Inputs.Types)),
+		}, nil
+	}
+
+	if err := server.eventMappings.Add(ctx, mapping); err != nil {
+		return oas.PostSpawnersEventsMappingsCreate500JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return oas.PostSpawnersEventsMappingsCreate200Response{}, nil
+}
+
+func (server *Server) PostSpawnersEventsMappingsGet(ctx context.Context, request oas.PostSpawnersEventsMappingsGetRequestObject) (oas.PostSpawnersEventsMappingsGetResponseObject, error) {
+	mapping, err := server.eventMappings.Get(ctx, request.Body.Id)
+	if err != nil {
+		return oas.PostSpawnersEventsMappingsGet500JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return oas.PostSpawnersEventsMappingsGet200JSONResponse(eventMappingToModel(mapping)), nil
+}
+
+func (server *Server) PostSpawnersEventsMappingsGetAll(ctx context.Context, request oas.PostSpawnersEventsMappingsGetAllRequestObject) (oas.PostSpawnersEventsMappingsGetAllResponseObject, error) {
+	mappings, err := server.eventMappings.GetAll(ctx)
+	if err != nil {
+		return oas.PostSpawnersEventsMappingsGetAll500JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return oas.PostSpawnersEventsMappingsGetAll200JSONResponse(lo.Map(mappings, func(mapping event.Mapping, _ int) oas.EventMapping {
+		return eventMappingToModel(mapping)
+	})), nil
+}
+
+func (server *Server) PostSpawnersEventsMappingsRemove(ctx context.Context, request oas.PostSpawnersEventsMappingsRemoveRequestObject) (oas.PostSpawnersEventsMappingsRemoveResponseObject, error) {
+	if err := server.eventMappings.Remove(ctx, request.Body.Id); err != nil {
+		return oas.PostSpawnersEventsMappingsRemove500JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return oas.PostSpawnersEventsMappingsRemove200Response{}, nil
+}
+
+func (server *Server) PostSignalsName(ctx context.Context, request oas.PostSignalsNameRequestObject) (oas.PostSignalsNameResponseObject, error) {
+	var payload []byte
+	if request.Body.Payload != nil {
+		payload = []byte(*request.Body.Payload)
+	}
+
+	resourceId, err := signalResolver.Send(ctx, server.system, request.Name, payload)
+	if err != nil {
+		return oas.PostSignalsName500JSONResponse{
+			Message: fmt.Sprintf("failed to send a signal: %s", err),
+		}, nil
+	}
+
+	return oas.PostSignalsName200JSONResponse{Resource: resourceId}, nil
+}
+
 func parseType(t oas.Type) typing.Type {
 	result := typing.Type{
 		Name:     t.Name,
@@ -558,3 +667,26 @@ func specificationToModel(spec specification.Specification) oas.Specification {
 		Meta: spec.Meta,
 	}
 }
+
+func eventMappingToModel(mapping event.Mapping) oas.EventMapping {
+	model := oas.EventMapping{
+		Id:            mapping.ID,
+		Inputs:        make([]oas.EventMappingInput, 0, len(mapping.Inputs)),
+		Specification: mapping.Specification,
+		Topic:         mapping.Topic,
+	}
+
+	if mapping.Info != nil {
+		model.Info = &mapping.Info
+	}
+
+	for _, binding := range mapping.Inputs {
+		input := oas.EventMappingInput{Value: binding.Value}
+		if binding.Path != "" {
+			input.Path = lo.ToPtr(binding.Path)
+		}
+		model.Inputs = append(model.Inputs, input)
+	}
+
+	return model
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/ischenkx/kantoku/pkg/common/logging/prefixed"
 	"github.com/ischenkx/kantoku/pkg/common/service"
 	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
 	"github.com/spf13/cobra"
 	"log/slog"
 	"os"
@@ -17,11 +19,21 @@ type deployFlags struct {
 	noStatus           bool
 	noApi              bool
 	noServiceDiscovery bool
+	noOutboxRelay      bool
+	noRetrier          bool
+	noCron             bool
+	noEventSpawner     bool
+	noGC               bool
 	scheduler          bool
 	processor          bool
 	status             bool
 	api                bool
 	serviceDiscovery   bool
+	outboxRelay        bool
+	retrier            bool
+	cron               bool
+	eventSpawner       bool
+	gc                 bool
 }
 
 func NewDeploy() *cobra.Command {
@@ -30,12 +42,17 @@ func NewDeploy() *cobra.Command {
 		Use:   "deploy",
 		Short: "Deploy the application",
 		Run: func(cmd *cobra.Command, args []string) {
-			if !flags.scheduler && !flags.processor && !flags.status && !flags.api && !flags.serviceDiscovery {
+			if !flags.scheduler && !flags.processor && !flags.status && !flags.api && !flags.serviceDiscovery && !flags.outboxRelay && !flags.retrier && !flags.cron && !flags.eventSpawner && !flags.gc {
 				flags.scheduler = true
 				flags.processor = true
 				flags.status = true
 				flags.api = true
 				flags.serviceDiscovery = true
+				flags.outboxRelay = true
+				flags.retrier = true
+				flags.cron = true
+				flags.eventSpawner = true
+				flags.gc = true
 			}
 			if flags.noScheduler {
 				flags.scheduler = false
@@ -52,6 +69,21 @@ func NewDeploy() *cobra.Command {
 			if flags.noServiceDiscovery {
 				flags.serviceDiscovery = false
 			}
+			if flags.noOutboxRelay {
+				flags.outboxRelay = false
+			}
+			if flags.noRetrier {
+				flags.retrier = false
+			}
+			if flags.noCron {
+				flags.cron = false
+			}
+			if flags.noEventSpawner {
+				flags.eventSpawner = false
+			}
+			if flags.noGC {
+				flags.gc = false
+			}
 
 			var cfg builder.Config
 
@@ -81,17 +113,20 @@ func NewDeploy() *cobra.Command {
 			defer cancel()
 
 			cmd.Println("building: system")
-			sys, err := builder.BuildSystem(ctx, logger, cfg.Core.System)
+			sys, specifications, err := builder.BuildCore(ctx, logger, cfg.Core)
 			if err != nil {
 				cmd.PrintErrln("failed to create a system instance from config:", err)
 				return
 			}
 
-			cmd.Println("building: system")
-			specifications, err := builder.BuildSpecifications(ctx, cfg.Core.Specifications)
-			if err != nil {
-				cmd.PrintErrln("failed to build a specifications manager:", err)
-				return
+			var eventMappings *specification.JsonStorage[event.Mapping]
+			if flags.api || flags.eventSpawner {
+				cmd.Println("building: event mappings")
+				eventMappings, err = builder.BuildEventMappings(ctx, cfg.Core.EventMappings)
+				if err != nil {
+					cmd.PrintErrln("failed to build event mappings:", err)
+					return
+				}
 			}
 
 			var deployer service.Deployer
@@ -134,7 +169,7 @@ func NewDeploy() *cobra.Command {
 			if flags.api {
 				cmd.Println("building: api")
 
-				deployment, err := builder.BuildHttpApiDeployment(ctx, sys, specifications, logger, cfg.Services.HttpApi)
+				deployment, err := builder.BuildHttpApiDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.HttpApi)
 				if err != nil {
 					cmd.PrintErrln(err)
 					return
@@ -155,6 +190,78 @@ func NewDeploy() *cobra.Command {
 				deployer.Add(deployemnt.Service, deployemnt.Middlewares...)
 			}
 
+			if flags.outboxRelay {
+				cmd.Println("building: outbox relay")
+
+				deployment, err := builder.BuildOutboxRelayDeployment(ctx, sys, logger, cfg.Services.OutboxRelay)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployer.Add(deployment.Service, deployment.Middlewares...)
+			}
+
+			if flags.retrier {
+				cmd.Println("building: retrier")
+
+				deployment, err := builder.BuildRetrierDeployment(ctx, sys, logger, cfg.Services.Retrier)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployer.Add(deployment.Service, deployment.Middlewares...)
+			}
+
+			if flags.cron {
+				cmd.Println("building: cron")
+
+				deployment, err := builder.BuildCronDeployment(ctx, sys, specifications, logger, cfg.Services.Cron)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployer.Add(deployment.Service, deployment.Middlewares...)
+			}
+
+			if flags.eventSpawner {
+				cmd.Println("building: event spawner")
+
+				deployment, err := builder.BuildEventSpawnerDeployment(ctx, sys, specifications, eventMappings, logger, cfg.Services.EventSpawner)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployer.Add(deployment.Service, deployment.Middlewares...)
+			}
+
+			if flags.gc {
+				cmd.Println("building: gc")
+
+				dependencies, taskToGroup, err := builder.BuildSchedulerStorages(ctx, logger, cfg.Services.Scheduler)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				resolvers, err := builder.BuildSchedulerResolvers(ctx, sys, logger, cfg.Services.Scheduler)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployment, err := builder.BuildGCDeployment(ctx, sys, dependencies, taskToGroup, resolvers, logger, cfg.Services.GC)
+				if err != nil {
+					cmd.PrintErrln(err)
+					return
+				}
+
+				deployer.Add(deployment.Service, deployment.Middlewares...)
+			}
+
 			cmd.Println("deploying...")
 			if err := deployer.Deploy(context.Background()); err != nil {
 				cmd.PrintErrln(err)
@@ -170,11 +277,21 @@ func NewDeploy() *cobra.Command {
 	cmd.Flags().BoolVar(&flags.noStatus, "no-status", false, "Disable status")
 	cmd.Flags().BoolVar(&flags.noApi, "no-api", false, "Enable API")
 	cmd.Flags().BoolVar(&flags.noServiceDiscovery, "no-service-discovery", false, "Enable API")
+	cmd.Flags().BoolVar(&flags.noOutboxRelay, "no-outbox-relay", false, "Disable outbox relay")
+	cmd.Flags().BoolVar(&flags.noRetrier, "no-retrier", false, "Disable retrier")
+	cmd.Flags().BoolVar(&flags.noCron, "no-cron", false, "Disable cron spawner")
+	cmd.Flags().BoolVar(&flags.noEventSpawner, "no-event-spawner", false, "Disable event spawner")
+	cmd.Flags().BoolVar(&flags.noGC, "no-gc", false, "Disable garbage collector")
 	cmd.Flags().BoolVar(&flags.scheduler, "scheduler", false, "Enable scheduler")
 	cmd.Flags().BoolVar(&flags.processor, "processor", false, "Enable processor")
 	cmd.Flags().BoolVar(&flags.status, "status", false, "Enable status")
 	cmd.Flags().BoolVar(&flags.api, "api", false, "Enable API")
 	cmd.Flags().BoolVar(&flags.serviceDiscovery, "service-discovery", false, "Enable API")
+	cmd.Flags().BoolVar(&flags.outboxRelay, "outbox-relay", false, "Enable outbox relay")
+	cmd.Flags().BoolVar(&flags.retrier, "retrier", false, "Enable retrier")
+	cmd.Flags().BoolVar(&flags.cron, "cron", false, "Enable cron spawner")
+	cmd.Flags().BoolVar(&flags.eventSpawner, "event-spawner", false, "Enable event spawner")
+	cmd.Flags().BoolVar(&flags.gc, "gc", false, "Enable garbage collector")
 
 	return cmd
 }
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/lib/builder"
+	"github.com/ischenkx/kantoku/pkg/lib/embedded"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/exe"
+	"github.com/spf13/cobra"
+	"log/slog"
+	"os"
+	"os/signal"
+)
+
+type devFlags struct {
+	port int
+}
+
+func NewDev() *cobra.Command {
+	flags := &devFlags{}
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Run all services in a single process with in-memory storages (only \"echo\" tasks are executed)",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := slog.New(builder.BuildPrettySlogHandler(os.Stdout, slog.LevelInfo))
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			cfg := embedded.DefaultConfig()
+			cfg.Services.HttpApi.Port = flags.port
+
+			router := exe.NewRouter()
+			router.AddExecutor(echoExecutor(), EchoTaskType)
+
+			cmd.Println("building: embedded instance")
+			instance, err := embedded.New(ctx, logger, cfg, router)
+			if err != nil {
+				cmd.PrintErrln(err)
+				return
+			}
+
+			cmd.Printf("http api: http://localhost:%d\n", flags.port)
+			cmd.Printf("executors: '%s' (copies inputs to outputs), tasks of other types fail\n", EchoTaskType)
+			if err := instance.Run(ctx); err != nil {
+				cmd.PrintErrln(err)
+				return
+			}
+			cmd.Println("DONE")
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.port, "port", 8585, "HTTP API port")
+
+	return cmd
+}
+
+// EchoTaskType is the type of tasks executed by "ktk dev"
+const EchoTaskType = "echo"
+
+// echoExecutor initializes each output of the task with the data of the input at the same position
+func echoExecutor() *exe.Executor {
+	return exe.New(func(ctx *exe.Context) error {
+		task := ctx.Task()
+		if len(task.Inputs) != len(task.Outputs) {
+			return fmt.Errorf("unexpected amount of outputs: %d (inputs: %d)", len(task.Outputs), len(task.Inputs))
+		}
+
+		inputs, err := ctx.System().Resources().Load(ctx, task.Inputs...)
+		if err != nil {
+			return fmt.Errorf("failed to load inputs: %w", err)
+		}
+
+		outputs := make([]core.Resource, 0, len(inputs))
+		for index, input := range inputs {
+			if input.Status != core.ResourceStatuses.Ready {
+				return fmt.Errorf("input is not ready: '%s' (%s)", input.ID, input.Status)
+			}
+
+			outputs = append(outputs, core.Resource{
+				ID:   task.Outputs[index],
+				Data: input.Data,
+			})
+		}
+
+		if err := ctx.System().Resources().Init(ctx, outputs); err != nil {
+			return fmt.Errorf("failed to initialize outputs: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -24,8 +24,12 @@ func New() *cobra.Command {
 	root.PersistentFlags().String("env", "", "Path to a file with environment variables")
 
 	root.AddCommand(NewDeploy())
+	root.AddCommand(NewDev())
 	root.AddCommand(NewEvents())
 	root.AddCommand(NewMonitor())
+	root.AddCommand(NewSignals())
+	root.AddCommand(NewSpawners())
+	root.AddCommand(NewTasks())
 
 	return root
 }
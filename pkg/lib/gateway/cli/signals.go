@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func NewSignals() *cobra.Command {
+	var addr string
+
+	var signalsCmd = &cobra.Command{
+		Use:   "signals",
+		Short: "Manage signals (external approvals of dependent tasks)",
+	}
+	signalsCmd.PersistentFlags().StringVar(&addr, "addr", "http://localhost:8585", "http api address")
+
+	var sendFlags struct {
+		payload string
+		file    string
+	}
+	var sendCmd = &cobra.Command{
+		Use:   "send [signal name]",
+		Short: "Send a signal (resolves every dependency waiting for it)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			payload := []byte(sendFlags.payload)
+			if sendFlags.file != "" {
+				data, err := os.ReadFile(sendFlags.file)
+				if err != nil {
+					cmd.PrintErrln("failed to read the file:", err)
+					return
+				}
+				payload = data
+			}
+
+			client, err := oas.NewClientWithResponses(addr)
+			if err != nil {
+				cmd.PrintErrln("failed to create a client:", err)
+				return
+			}
+
+			resourceId, err := kantokuhttp.NewClient(client).Signal(context.Background(), args[0], payload)
+			if err != nil {
+				cmd.PrintErrln("failed to send:", err)
+				return
+			}
+
+			cmd.Println("Sent:", args[0])
+			cmd.Println("Payload:", resourceId)
+		},
+	}
+	sendCmd.Flags().StringVar(&sendFlags.payload, "payload", "", "payload of the signal")
+	sendCmd.Flags().StringVar(&sendFlags.file, "file", "", "path to a file with the payload")
+
+	signalsCmd.AddCommand(sendCmd)
+
+	return signalsCmd
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/ischenkx/kantoku/pkg/lib/spawners/event"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+func NewSpawners() *cobra.Command {
+	var spawnersCmd = &cobra.Command{
+		Use:   "spawners",
+		Short: "Manage spawners",
+	}
+
+	spawnersCmd.AddCommand(newEventMappings())
+
+	return spawnersCmd
+}
+
+func newEventMappings() *cobra.Command {
+	var addr string
+
+	var eventsCmd = &cobra.Command{
+		Use:   "events",
+		Short: "Manage event mappings (tasks spawned from broker events)",
+	}
+	eventsCmd.PersistentFlags().StringVar(&addr, "addr", "http://localhost:8585", "http api address")
+
+	var listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List event mappings",
+		Run: func(cmd *cobra.Command, args []string) {
+			mappings, err := eventMappingsClient(addr)
+			if err != nil {
+				cmd.PrintErrln("failed to create a client:", err)
+				return
+			}
+
+			all, err := mappings.GetAll(context.Background())
+			if err != nil {
+				cmd.PrintErrln("failed to list:", err)
+				return
+			}
+
+			for _, mapping := range all {
+				inputs := make([]string, 0, len(mapping.Inputs))
+				for _, binding := range mapping.Inputs {
+					inputs = append(inputs, formatBinding(binding))
+				}
+
+				cmd.Printf("id='%s' topic='%s' specification='%s' inputs=[%s]\n",
+					mapping.ID, mapping.Topic, mapping.Specification, strings.Join(inputs, ", "))
+			}
+		},
+	}
+
+	var createFlags struct {
+		file          string
+		id            string
+		topic         string
+		specification string
+		inputs        []string
+	}
+	var createCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create an event mapping (from a JSON file or flags)",
+		Run: func(cmd *cobra.Command, args []string) {
+			var mapping event.Mapping
+
+			if createFlags.file != "" {
+				data, err := os.ReadFile(createFlags.file)
+				if err != nil {
+					cmd.PrintErrln("failed to read the file:", err)
+					return
+				}
+
+				if err := json.Unmarshal(data, &mapping); err != nil {
+					cmd.PrintErrln("failed to decode the mapping:", err)
+					return
+				}
+			} else {
+				mapping = event.Mapping{
+					ID:            createFlags.id,
+					Topic:         createFlags.topic,
+					Specification: createFlags.specification,
+				}
+
+				for _, input := range createFlags.inputs {
+					binding, err := parseBinding(input)
+					if err != nil {
+						cmd.PrintErrln(err)
+						return
+					}
+					mapping.Inputs = append(mapping.Inputs, binding)
+				}
+			}
+
+			if mapping.ID == "" || mapping.Topic == "" || mapping.Specification == "" {
+				cmd.PrintErrln("id, topic and specification are required")
+				return
+			}
+
+			mappings, err := eventMappingsClient(addr)
+			if err != nil {
+				cmd.PrintErrln("failed to create a client:", err)
+				return
+			}
+
+			if err := mappings.Add(context.Background(), mapping); err != nil {
+				cmd.PrintErrln("failed to create:", err)
+				return
+			}
+
+			cmd.Println("Created:", mapping.ID)
+		},
+	}
+	createCmd.Flags().StringVar(&createFlags.file, "file", "", "path to a JSON encoded mapping")
+	createCmd.Flags().StringVar(&createFlags.id, "id", "", "mapping id")
+	createCmd.Flags().StringVar(&createFlags.topic, "topic", "", "topic to consume")
+	createCmd.Flags().StringVar(&createFlags.specification, "spec", "", "specification id")
+	createCmd.Flags().StringArrayVar(&createFlags.inputs, "input", nil,
+		"input binding (in order): 'payload', 'path:<field.path>' or 'value:<constant>'")
+
+	var removeCmd = &cobra.Command{
+		Use:   "remove [mapping id]",
+		Short: "Remove an event mapping",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			mappings, err := eventMappingsClient(addr)
+			if err != nil {
+				cmd.PrintErrln("failed to create a client:", err)
+				return
+			}
+
+			if err := mappings.Remove(context.Background(), args[0]); err != nil {
+				cmd.PrintErrln("failed to remove:", err)
+				return
+			}
+
+			cmd.Println("Removed:", args[0])
+		},
+	}
+
+	eventsCmd.AddCommand(listCmd)
+	eventsCmd.AddCommand(createCmd)
+	eventsCmd.AddCommand(removeCmd)
+
+	return eventsCmd
+}
+
+func eventMappingsClient(addr string) (*kantokuhttp.EventMappingStorage, error) {
+	client, err := oas.NewClientWithResponses(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return kantokuhttp.NewClient(client).EventMappings(), nil
+}
+
+func parseBinding(raw string) (event.Binding, error) {
+	if raw == "payload" {
+		return event.Binding{}, nil
+	}
+
+	if path, ok := strings.CutPrefix(raw, "path:"); ok {
+		return event.Binding{Path: path}, nil
+	}
+
+	if value, ok := strings.CutPrefix(raw, "value:"); ok {
+		return event.Binding{Value: &value}, nil
+	}
+
+	return event.Binding{}, fmt.Errorf("invalid input binding: %s", raw)
+}
+
+func formatBinding(binding event.Binding) string {
+	switch {
+	case binding.Value != nil:
+		return "value:" + *binding.Value
+	case binding.Path != "":
+		return "path:" + binding.Path
+	default:
+		return "payload"
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/lib/gateway/api/kantokuhttp/oas"
+	"github.com/spf13/cobra"
+	"net/http"
+	"strings"
+)
+
+func NewTasks() *cobra.Command {
+	var tasksCmd = &cobra.Command{
+		Use:   "tasks",
+		Short: "Manage tasks",
+	}
+
+	var cancelFlags struct {
+		addr      string
+		reason    string
+		recursive bool
+	}
+	var cancelCmd = &cobra.Command{
+		Use:   "cancel [task id]",
+		Short: "Cancel a task",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := oas.NewClientWithResponses(cancelFlags.addr)
+			if err != nil {
+				cmd.PrintErrln("failed to create a client:", err)
+				return
+			}
+
+			res, err := client.PostTasksCancelWithResponse(context.Background(), oas.PostTasksCancelJSONRequestBody{
+				Id:        args[0],
+				Reason:    &cancelFlags.reason,
+				Recursive: &cancelFlags.recursive,
+			})
+			if err != nil {
+				cmd.PrintErrln("failed to cancel:", err)
+				return
+			}
+
+			switch res.StatusCode() {
+			case http.StatusOK:
+				cmd.Println("Cancelled:", strings.Join(res.JSON200.Cancelled, ", "))
+			case http.StatusNotFound:
+				cmd.PrintErrln("task not found:", res.JSON404.Message)
+			case http.StatusConflict:
+				cmd.PrintErrln("task can't be cancelled:", res.JSON409.Message)
+			case http.StatusInternalServerError:
+				cmd.PrintErrln("server failure:", res.JSON500.Message)
+			default:
+				cmd.PrintErrln("unexpected response code:", res.StatusCode())
+			}
+		},
+	}
+	cancelCmd.Flags().StringVar(&cancelFlags.addr, "addr", "http://localhost:8585", "http api address")
+	cancelCmd.Flags().StringVar(&cancelFlags.reason, "reason", "", "cancellation reason")
+	cancelCmd.Flags().BoolVar(&cancelFlags.recursive, "recursive", false, "cancel every task from the same execution context and every descendant task")
+
+	tasksCmd.AddCommand(cancelCmd)
+
+	return tasksCmd
+}
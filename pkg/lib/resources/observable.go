@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"io"
+	"time"
 )
 
 type Observer interface {
@@ -52,12 +54,33 @@ func (storage *Observable) Load(ctx context.Context, ids ...string) ([]core.Reso
 	return resources, nil
 }
 
+// LoadStatuses notifies the observer about loading resources (loaded resources are passed without data)
+func (storage *Observable) LoadStatuses(ctx context.Context, ids ...string) ([]core.Resource, error) {
+	if err := storage.observer.BeforeLoad(ctx, ids); err != nil {
+		return nil, fmt.Errorf("observer.beforeLoad failed: %w", err)
+	}
+
+	resources, err := core.LoadStatuses(ctx, storage.raw, ids...)
+	if err != nil {
+		storage.observer.OnLoadError(ctx, ids, err)
+		return nil, err
+	}
+
+	storage.observer.AfterLoad(ctx, resources)
+
+	return resources, nil
+}
+
 func (storage *Observable) Alloc(ctx context.Context, amount int) ([]string, error) {
+	return storage.AllocWithTTL(ctx, amount, 0)
+}
+
+func (storage *Observable) AllocWithTTL(ctx context.Context, amount int, ttl time.Duration) ([]string, error) {
 	if err := storage.observer.BeforeAlloc(ctx, amount); err != nil {
 		return nil, fmt.Errorf("observer.beforeAlloc failed: %w", err)
 	}
 
-	ids, err := storage.raw.Alloc(ctx, amount)
+	ids, err := core.AllocWithTTL(ctx, storage.raw, amount, ttl)
 	if err != nil {
 		storage.observer.OnAllocError(ctx, amount, err)
 		return nil, err
@@ -100,6 +123,63 @@ func (storage *Observable) Dealloc(ctx context.Context, ids []string) error {
 	return nil
 }
 
+// OpenReader notifies the observer about loading a resource (loaded resources are passed without data)
+func (storage *Observable) OpenReader(ctx context.Context, id string) (io.ReadCloser, error) {
+	if err := storage.observer.BeforeLoad(ctx, []string{id}); err != nil {
+		return nil, fmt.Errorf("observer.beforeLoad failed: %w", err)
+	}
+
+	reader, err := Streaming(storage.raw).OpenReader(ctx, id)
+	if err != nil {
+		storage.observer.OnLoadError(ctx, []string{id}, err)
+		return nil, err
+	}
+
+	storage.observer.AfterLoad(ctx, []core.Resource{{ID: id, Status: core.ResourceStatuses.Ready}})
+
+	return reader, nil
+}
+
+// OpenWriter notifies the observer about initializing a resource when the writer is closed
+// (resources are passed without data)
+func (storage *Observable) OpenWriter(ctx context.Context, id string) (io.WriteCloser, error) {
+	resources := []core.Resource{{ID: id}}
+	if err := storage.observer.BeforeInit(ctx, resources); err != nil {
+		return nil, fmt.Errorf("observer.beforeInit failed: %w", err)
+	}
+
+	writer, err := Streaming(storage.raw).OpenWriter(ctx, id)
+	if err != nil {
+		storage.observer.OnInitError(ctx, resources, err)
+		return nil, err
+	}
+
+	return &observedWriter{
+		WriteCloser: writer,
+		ctx:         ctx,
+		id:          id,
+		observer:    storage.observer,
+	}, nil
+}
+
+type observedWriter struct {
+	io.WriteCloser
+	ctx      context.Context
+	id       string
+	observer Observer
+}
+
+func (writer *observedWriter) Close() error {
+	if err := writer.WriteCloser.Close(); err != nil {
+		writer.observer.OnInitError(writer.ctx, []core.Resource{{ID: writer.id}}, err)
+		return err
+	}
+
+	writer.observer.AfterInit(writer.ctx, []core.Resource{{ID: writer.id, Status: core.ResourceStatuses.Ready}})
+
+	return nil
+}
+
 type FunctionalObserver struct {
 	BeforeLoadF     func(ctx context.Context, ids []string) error
 	AfterLoadF      func(ctx context.Context, resources []core.Resource)
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"io"
+)
+
+// Streaming returns the streaming interface of the database if it's supported,
+// otherwise payloads are buffered in memory and passed through the byte-slice API
+func Streaming(db core.ResourceDB) core.StreamingResourceDB {
+	if streaming, ok := db.(core.StreamingResourceDB); ok {
+		return streaming
+	}
+
+	return bufferedStreams{db: db}
+}
+
+type bufferedStreams struct {
+	db core.ResourceDB
+}
+
+func (streams bufferedStreams) OpenReader(ctx context.Context, id string) (io.ReadCloser, error) {
+	resources, err := streams.db.Load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the resource: %w", err)
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("unexpected amount of loaded resources: %d", len(resources))
+	}
+
+	res := resources[0]
+	switch res.Status {
+	case core.ResourceStatuses.Ready:
+		return io.NopCloser(bytes.NewReader(res.Data)), nil
+	case core.ResourceStatuses.Expired:
+		return nil, fmt.Errorf("failed to read '%s': %w", id, core.ErrResourceExpired)
+	default:
+		return nil, fmt.Errorf("resource '%s' is not ready (status='%s')", id, res.Status)
+	}
+}
+
+func (streams bufferedStreams) OpenWriter(ctx context.Context, id string) (io.WriteCloser, error) {
+	return &bufferedWriter{ctx: ctx, db: streams.db, id: id}, nil
+}
+
+type bufferedWriter struct {
+	ctx    context.Context
+	db     core.ResourceDB
+	id     string
+	buffer bytes.Buffer
+}
+
+func (writer *bufferedWriter) Write(data []byte) (int, error) {
+	return writer.buffer.Write(data)
+}
+
+func (writer *bufferedWriter) Close() error {
+	return writer.db.Init(writer.ctx, []core.Resource{{ID: writer.id, Data: writer.buffer.Bytes()}})
+}
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/core"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestStreamingFallback(t *testing.T) {
+	ctx := context.Background()
+
+	var initialized []string
+	db := Observe(resourcedb.NewMockDB(), FunctionalObserver{
+		AfterInitF: func(ctx context.Context, resources []core.Resource) {
+			for _, res := range resources {
+				initialized = append(initialized, res.ID)
+			}
+		},
+	})
+
+	ids, err := db.Alloc(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	writer, err := Streaming(db).OpenWriter(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("failed to open a writer: %s", err)
+	}
+	io.WriteString(writer, "hello, ")
+	io.WriteString(writer, "world")
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close the writer: %s", err)
+	}
+
+	if len(initialized) != 1 || initialized[0] != ids[0] {
+		t.Fatalf("the observer has not been notified: %v", initialized)
+	}
+
+	reader, err := Streaming(db).OpenReader(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("failed to open a reader: %s", err)
+	}
+	data, _ := io.ReadAll(reader)
+	if string(data) != "hello, world" {
+		t.Fatalf("unexpected data: %q", data)
+	}
+
+	expiring, err := db.AllocWithTTL(ctx, 1, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := Streaming(db).OpenReader(ctx, expiring[0]); !errors.Is(err, core.ErrResourceExpired) {
+		t.Fatalf("expected ErrResourceExpired, got: %v", err)
+	}
+}
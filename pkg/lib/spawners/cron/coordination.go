@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Lock elects a single spawner instance that evaluates schedules
+type Lock interface {
+	// Acquire acquires (or extends) the lock for the holder.
+	// It returns false if the lock is held by someone else.
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+}
+
+// TickStorage keeps the last processed tick of every definition
+type TickStorage interface {
+	LastTick(ctx context.Context, definitionID string) (time.Time, bool, error)
+	// Advance atomically moves the last tick from "from" to "to" (zero "from" means that there is no last tick).
+	// It returns false if the last tick is not "from", i.e. the tick has been processed by someone else.
+	Advance(ctx context.Context, definitionID string, from, to time.Time) (bool, error)
+	// Claim atomically claims the tick of the definition for the holder (or extends its claim).
+	// It returns false if the tick is claimed by someone else and the claim has not expired.
+	Claim(ctx context.Context, definitionID string, tick time.Time, holder string, ttl time.Duration) (bool, error)
+}
+
+type MemoryLock struct {
+	holder    string
+	expiresAt time.Time
+	mu        sync.Mutex
+}
+
+func (lock *MemoryLock) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	now := time.Now()
+	if lock.holder != holder && now.Before(lock.expiresAt) {
+		return false, nil
+	}
+
+	lock.holder = holder
+	lock.expiresAt = now.Add(ttl)
+
+	return true, nil
+}
+
+type MemoryTickStorage struct {
+	ticks  map[string]time.Time
+	claims map[tickKey]tickClaim
+	mu     sync.Mutex
+}
+
+type tickKey struct {
+	definitionID string
+	tick         int64
+}
+
+type tickClaim struct {
+	holder    string
+	expiresAt time.Time
+}
+
+func NewMemoryTickStorage() *MemoryTickStorage {
+	return &MemoryTickStorage{
+		ticks:  map[string]time.Time{},
+		claims: map[tickKey]tickClaim{},
+	}
+}
+
+func (storage *MemoryTickStorage) LastTick(ctx context.Context, definitionID string) (time.Time, bool, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	tick, ok := storage.ticks[definitionID]
+
+	return tick, ok, nil
+}
+
+func (storage *MemoryTickStorage) Advance(ctx context.Context, definitionID string, from, to time.Time) (bool, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	current, ok := storage.ticks[definitionID]
+	if from.IsZero() && ok {
+		return false, nil
+	}
+	if !from.IsZero() && (!ok || !current.Equal(from)) {
+		return false, nil
+	}
+
+	storage.ticks[definitionID] = to
+
+	// processed ticks are never claimed again
+	for key := range storage.claims {
+		if key.definitionID == definitionID && key.tick <= to.UnixNano() {
+			delete(storage.claims, key)
+		}
+	}
+
+	return true, nil
+}
+
+func (storage *MemoryTickStorage) Claim(ctx context.Context, definitionID string, tick time.Time, holder string, ttl time.Duration) (bool, error) {
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+
+	now := time.Now()
+	key := tickKey{definitionID: definitionID, tick: tick.UnixNano()}
+	if claim, ok := storage.claims[key]; ok && claim.holder != holder && now.Before(claim.expiresAt) {
+		return false, nil
+	}
+
+	storage.claims[key] = tickClaim{holder: holder, expiresAt: now.Add(ttl)}
+
+	return true, nil
+}
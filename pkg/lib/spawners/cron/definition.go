@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"time"
+)
+
+var OverlapPolicies = struct {
+	// Allow spawns a task on every tick
+	Allow string
+	// Forbid skips a tick if a task spawned by the definition is still running
+	Forbid string
+}{
+	Allow:  "allow",
+	Forbid: "forbid",
+}
+
+// CatchUpPolicies define what happens to ticks missed while the spawner was down
+var CatchUpPolicies = struct {
+	// Skip ignores missed ticks
+	Skip string
+	// Last fires only the latest missed tick
+	Last string
+	// All fires every missed tick
+	All string
+}{
+	Skip: "skip",
+	Last: "last",
+	All:  "all",
+}
+
+// Definition describes tasks spawned periodically from a specification
+// (the same way as by the "spawn from specification" http endpoint).
+type Definition struct {
+	ID            string         `json:"id"`
+	Specification string         `json:"specification"`
+	Parameters    []string       `json:"parameters,omitempty"`
+	Info          map[string]any `json:"info,omitempty"`
+	// Schedule is a cron expression (see Parse)
+	Schedule string `json:"schedule"`
+	// Timezone is an IANA time zone name the schedule is evaluated in (UTC if not set)
+	Timezone string `json:"timezone,omitempty"`
+	// Overlap is one of OverlapPolicies (Allow if not set)
+	Overlap string `json:"overlap,omitempty"`
+	// CatchUp is one of CatchUpPolicies (the spawner's default if not set)
+	CatchUp string `json:"catch_up,omitempty"`
+}
+
+func (definition Definition) Location() (*time.Location, error) {
+	if definition.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(definition.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the timezone: %w", err)
+	}
+
+	return location, nil
+}
+
+func NewDefinitions(raw specification.BinaryStorage) *specification.JsonStorage[Definition] {
+	return &specification.JsonStorage[Definition]{
+		Raw: raw,
+		IdFunc: func(definition Definition) string {
+			return definition.ID
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// acquireScript sets the holder if the lock (or the claim of a tick) is free or already held by it
+var acquireScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == false or holder == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// advanceScript is a compare-and-set of a hash field ("" means a missing field)
+var advanceScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], ARGV[1])
+if (current == false and ARGV[2] == '') or current == ARGV[2] then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+	return 1
+end
+return 0
+`)
+
+type RedisLock struct {
+	Client redis.UniversalClient
+	Key    string
+}
+
+func (lock *RedisLock) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	acquired, err := acquireScript.Run(ctx, lock.Client, []string{lock.Key}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire the lock: %w", err)
+	}
+
+	return acquired == 1, nil
+}
+
+// RedisTickStorage keeps ticks (unix nanoseconds) in a hash,
+// claims of ticks are expiring keys with the same prefix
+type RedisTickStorage struct {
+	Client redis.UniversalClient
+	Key    string
+}
+
+func (storage *RedisTickStorage) LastTick(ctx context.Context, definitionID string) (time.Time, bool, error) {
+	raw, err := storage.Client.HGet(ctx, storage.Key, definitionID).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get the last tick: %w", err)
+	}
+
+	nanos, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to parse the last tick: %w", err)
+	}
+
+	return time.Unix(0, nanos), true, nil
+}
+
+func (storage *RedisTickStorage) Advance(ctx context.Context, definitionID string, from, to time.Time) (bool, error) {
+	encodedFrom := ""
+	if !from.IsZero() {
+		encodedFrom = strconv.FormatInt(from.UnixNano(), 10)
+	}
+
+	advanced, err := advanceScript.Run(ctx, storage.Client,
+		[]string{storage.Key},
+		definitionID,
+		encodedFrom,
+		strconv.FormatInt(to.UnixNano(), 10),
+	).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to advance the tick: %w", err)
+	}
+
+	return advanced == 1, nil
+}
+
+func (storage *RedisTickStorage) Claim(ctx context.Context, definitionID string, tick time.Time, holder string, ttl time.Duration) (bool, error) {
+	key := storage.Key + ":claims:" + definitionID + ":" + strconv.FormatInt(tick.UnixNano(), 10)
+
+	claimed, err := acquireScript.Run(ctx, storage.Client, []string{key}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim the tick: %w", err)
+	}
+
+	return claimed == 1, nil
+}
@@ -0,0 +1,240 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes activation times of a cron expression
+type Schedule interface {
+	// Next returns the first activation time strictly after t (in t's location)
+	Next(t time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minutes    = field{name: "minute", min: 0, max: 59}
+	hours      = field{name: "hour", min: 0, max: 23}
+	daysOfMon  = field{name: "day of month", min: 1, max: 31}
+	months     = field{name: "month", min: 1, max: 12, names: monthNames}
+	daysOfWeek = field{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Parse parses a standard five-field cron expression ("minute hour day-of-month month day-of-week"),
+// a descriptor (@hourly, @daily, ...) or an interval ("@every 10s").
+//
+// Intervals are aligned to a fixed origin (time.Time{}), so every instance computes the same activation times.
+func Parse(expression string) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+
+	if rawInterval, ok := strings.CutPrefix(expression, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rawInterval))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the interval: %w", err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("interval must be at least one second: %s", interval)
+		}
+
+		return intervalSchedule{interval: interval}, nil
+	}
+
+	if descriptor, ok := descriptors[expression]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var (
+		schedule fieldSchedule
+		err      error
+	)
+
+	if schedule.minutes, err = parseField(fields[0], minutes); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseField(fields[1], hours); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfMonth, err = parseField(fields[2], daysOfMon); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseField(fields[3], months); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek, err = parseField(fields[4], daysOfWeek); err != nil {
+		return nil, err
+	}
+
+	// 7 is another name for sunday
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+
+	// like in vixie cron, "*/n" is unrestricted
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// parseField parses comma separated values, ranges (a-b) and steps (*/n, a-b/n) into a bitset
+func parseField(expression string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, rawStep, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(rawStep)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step: %s", f.name, rawStep)
+			}
+		}
+
+		var from, to int
+		switch {
+		case rangeExpression == "*":
+			from, to = f.min, f.max
+		case strings.Contains(rangeExpression, "-"):
+			rawFrom, rawTo, _ := strings.Cut(rangeExpression, "-")
+
+			var err error
+			if from, err = f.value(rawFrom); err != nil {
+				return 0, err
+			}
+			if to, err = f.value(rawTo); err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid %s range: %s", f.name, rangeExpression)
+			}
+		default:
+			value, err := f.value(rangeExpression)
+			if err != nil {
+				return 0, err
+			}
+
+			from, to = value, value
+			// "a/n" means "from a to the max with step n"
+			if hasStep {
+				to = f.max
+			}
+		}
+
+		for value := from; value <= to; value += step {
+			bits |= 1 << value
+		}
+	}
+
+	return bits, nil
+}
+
+func (f field) value(raw string) (int, error) {
+	if value, ok := f.names[strings.ToLower(raw)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", f.name, raw)
+	}
+
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s out of range [%d, %d]: %d", f.name, f.min, f.max, value)
+	}
+
+	return value, nil
+}
+
+type fieldSchedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	anyDayOfMonth, anyDayOfWeek                     bool
+}
+
+func (schedule fieldSchedule) Next(t time.Time) time.Time {
+	location := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// there is no matching time within 5 years (e.g. february 30th)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if schedule.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if !schedule.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if schedule.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+			continue
+		}
+
+		if schedule.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay follows the cron convention:
+// if both day fields are restricted, a day matching either of them matches.
+func (schedule fieldSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := schedule.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := schedule.daysOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if schedule.anyDayOfMonth || schedule.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (schedule intervalSchedule) Next(t time.Time) time.Time {
+	return t.Truncate(schedule.interval).Add(schedule.interval)
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	base := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC) // wednesday
+
+	cases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 31, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		{"5 10-12 * * *", time.Date(2024, time.January, 31, 11, 5, 0, 0, time.UTC)},
+		{"0 0 * * *", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2024, time.February, 5, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2024, time.February, 4, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// both day fields are restricted: either of them matches
+		{"0 0 15 * fri", time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		schedule, err := Parse(c.expression)
+		if err != nil {
+			t.Fatalf("failed to parse '%s': %s", c.expression, err)
+		}
+
+		if next := schedule.Next(base); !next.Equal(c.expected) {
+			t.Errorf("'%s': expected %s, got %s", c.expression, c.expected, next)
+		}
+	}
+}
+
+func TestScheduleTimezone(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Moscow") // UTC+3
+	if err != nil {
+		t.Skipf("no timezone data: %s", err)
+	}
+
+	schedule, err := Parse("0 9 * * *")
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+
+	next := schedule.Next(time.Date(2024, time.January, 31, 7, 0, 0, 0, time.UTC).In(location))
+	if expected := time.Date(2024, time.February, 1, 6, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, next.UTC())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* * * foo *", "5-1 * * * *", "*/0 * * * *", "@every 1ms"} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("expected '%s' to be invalid", expression)
+		}
+	}
+}
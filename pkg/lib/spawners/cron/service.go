@@ -0,0 +1,300 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"log/slog"
+	"time"
+)
+
+// maxCatchUp limits the number of missed ticks fired by CatchUpPolicies.All
+const maxCatchUp = 1024
+
+// Service spawns tasks according to cron definitions.
+//
+// Only the instance holding the Lock evaluates schedules. The lock expires, so two instances might evaluate
+// schedules at the same time while it changes hands, that's why every tick is claimed in the TickStorage before it's fired.
+// Spawned tasks are marked with info.cron_id and info.cron_tick,
+// which is the idempotency key of a tick: a task is spawned only if there is no task with the key,
+// and the tick is advanced in the TickStorage only after the task is spawned.
+// So a tick is retried if the spawner fails in the middle (once its claim expires), but it doesn't spawn duplicates.
+type Service struct {
+	System         core.AbstractSystem
+	Specifications *specification.Manager
+	Definitions    *specification.JsonStorage[Definition]
+	Ticks          TickStorage
+	Lock           Lock
+	// Interval between schedule evaluations
+	Interval time.Duration
+	// Grace is the maximal delay of a tick, older ticks are considered missed (2 * Interval if not set)
+	Grace time.Duration
+	// CatchUp is the default policy for missed ticks (CatchUpPolicies.Skip if not set)
+	CatchUp string
+
+	service.Core
+}
+
+func (srvc *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(srvc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// the lock outlives a few evaluations, so the leader doesn't change without a reason
+			leader, err := srvc.Lock.Acquire(ctx, srvc.ID(), srvc.lease())
+			if err != nil {
+				srvc.Logger().Error("failed to acquire the lock",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			if !leader {
+				continue
+			}
+
+			if err := srvc.Tick(ctx, time.Now()); err != nil {
+				srvc.Logger().Error("failed to process definitions",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// Tick fires ticks of all definitions that are due at the moment
+func (srvc *Service) Tick(ctx context.Context, now time.Time) error {
+	definitions, err := srvc.Definitions.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load definitions: %w", err)
+	}
+
+	for _, definition := range definitions {
+		if err := srvc.process(ctx, definition, now); err != nil {
+			srvc.Logger().Error("failed to process a definition",
+				slog.String("definition_id", definition.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+func (srvc *Service) process(ctx context.Context, definition Definition, now time.Time) error {
+	schedule, err := Parse(definition.Schedule)
+	if err != nil {
+		return fmt.Errorf("failed to parse the schedule: %w", err)
+	}
+
+	location, err := definition.Location()
+	if err != nil {
+		return err
+	}
+
+	last, ok, err := srvc.Ticks.LastTick(ctx, definition.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get the last tick: %w", err)
+	}
+
+	// a new definition starts from now, earlier ticks are not missed
+	if !ok {
+		if _, err := srvc.Ticks.Advance(ctx, definition.ID, time.Time{}, now); err != nil {
+			return fmt.Errorf("failed to initialize the last tick: %w", err)
+		}
+		return nil
+	}
+
+	due := dueTicks(schedule, last.In(location), now, srvc.grace(), srvc.catchUp(definition))
+
+	for _, tick := range due {
+		if srvc.shouldFire(definition, tick, due, now) {
+			claimed, err := srvc.Ticks.Claim(ctx, definition.ID, tick, srvc.ID(), srvc.lease())
+			if err != nil {
+				return fmt.Errorf("failed to claim a tick: %w", err)
+			}
+			if !claimed {
+				// the tick is being fired by another instance
+				return nil
+			}
+
+			if err := srvc.fire(ctx, definition, tick); err != nil {
+				// the tick is not advanced, so it's retried later
+				return fmt.Errorf("failed to fire a tick: %w", err)
+			}
+		}
+
+		advanced, err := srvc.Ticks.Advance(ctx, definition.ID, last, tick)
+		if err != nil {
+			return fmt.Errorf("failed to advance the tick: %w", err)
+		}
+		if !advanced {
+			// the tick has been processed by another instance
+			return nil
+		}
+
+		last = tick
+	}
+
+	return nil
+}
+
+// dueTicks returns ticks in (last, now] that must be processed.
+//
+// Missed ticks (older than the grace period) are collapsed into the latest one,
+// unless all of them are fired (CatchUpPolicies.All), then only maxCatchUp earliest ones are returned
+// and the rest are processed by the next evaluations.
+func dueTicks(schedule Schedule, last, now time.Time, grace time.Duration, policy string) []time.Time {
+	var due []time.Time
+
+	if policy == CatchUpPolicies.All {
+		for tick := schedule.Next(last); !tick.IsZero() && !tick.After(now) && len(due) < maxCatchUp; tick = schedule.Next(tick) {
+			due = append(due, tick)
+		}
+		return due
+	}
+
+	missedBefore := now.Add(-grace)
+	if missed, ok := latestTick(schedule, last, missedBefore); ok {
+		due = append(due, missed)
+		last = missed
+	}
+
+	// fresh ticks
+	if from := missedBefore.Add(-time.Nanosecond); from.After(last) {
+		last = from
+	}
+	for tick := schedule.Next(last); !tick.IsZero() && !tick.After(now) && len(due) < maxCatchUp; tick = schedule.Next(tick) {
+		due = append(due, tick)
+	}
+
+	return due
+}
+
+// latestTick finds the latest tick in (after, before) without iterating over all ticks since after:
+// the window preceding before is doubled until it contains a tick.
+func latestTick(schedule Schedule, after, before time.Time) (time.Time, bool) {
+	for window := time.Second; ; window *= 2 {
+		from := after
+		if window < before.Sub(after) {
+			from = before.Add(-window)
+		}
+
+		var latest time.Time
+		for tick := schedule.Next(from); !tick.IsZero() && tick.Before(before); tick = schedule.Next(tick) {
+			latest = tick
+		}
+
+		if !latest.IsZero() {
+			return latest, true
+		}
+		if from.Equal(after) {
+			return time.Time{}, false
+		}
+	}
+}
+
+func (srvc *Service) lease() time.Duration {
+	return 3 * srvc.Interval
+}
+
+func (srvc *Service) grace() time.Duration {
+	if srvc.Grace <= 0 {
+		return 2 * srvc.Interval
+	}
+
+	return srvc.Grace
+}
+
+func (srvc *Service) catchUp(definition Definition) string {
+	if definition.CatchUp == "" {
+		return srvc.CatchUp
+	}
+
+	return definition.CatchUp
+}
+
+func (srvc *Service) shouldFire(definition Definition, tick time.Time, due []time.Time, now time.Time) bool {
+	grace := srvc.grace()
+	if now.Sub(tick) <= grace {
+		return true
+	}
+
+	switch srvc.catchUp(definition) {
+	case CatchUpPolicies.All:
+		return true
+	case CatchUpPolicies.Last:
+		// the latest missed tick is the last one or the one followed by a fresh tick
+		for i, dueTick := range due {
+			if dueTick.Equal(tick) {
+				return i == len(due)-1 || now.Sub(due[i+1]) <= grace
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (srvc *Service) fire(ctx context.Context, definition Definition, tick time.Time) error {
+	// the tick might have been fired before the spawner failed to advance it
+	fired, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"info.cron_id":   {definition.ID},
+		"info.cron_tick": {tick.Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load tasks of the tick: %w", err)
+	}
+	if len(fired) > 0 {
+		return nil
+	}
+
+	if definition.Overlap == OverlapPolicies.Forbid {
+		running, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+			"info.cron_id": {definition.ID},
+			"info.status": {
+				nil,
+				core.TaskStatuses.Initialized,
+				core.TaskStatuses.Ready,
+				core.TaskStatuses.Received,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load running tasks: %w", err)
+		}
+
+		if len(running) > 0 {
+			srvc.Logger().Info("skipped a tick: the previous task is still running",
+				slog.String("definition_id", definition.ID),
+				slog.Time("tick", tick))
+			return nil
+		}
+	}
+
+	spec, err := srvc.Specifications.Specifications().Get(ctx, definition.Specification)
+	if err != nil {
+		return fmt.Errorf("failed to load the specification: %w", err)
+	}
+
+	info := make(map[string]any, len(definition.Info)+2)
+	for key, value := range definition.Info {
+		info[key] = value
+	}
+	info["cron_id"] = definition.ID
+	info["cron_tick"] = tick.Unix()
+
+	task, err := specification.Spawn(ctx, srvc.System, spec, definition.Parameters, info)
+	if err != nil {
+		return fmt.Errorf("failed to spawn a task: %w", err)
+	}
+
+	srvc.Logger().Info("spawned a task",
+		slog.String("definition_id", definition.ID),
+		slog.String("task_id", task.ID),
+		slog.Time("tick", tick))
+
+	return nil
+}
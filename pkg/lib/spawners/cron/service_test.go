@@ -0,0 +1,223 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestServices(t *testing.T, amount int, catchUp string) ([]*Service, *core.System) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		inmem.New(),
+		logger,
+	)
+
+	specifications := specification.NewManager(specification.NewMemoryBinaryStorage(), specification.NewMemoryBinaryStorage())
+	if err := specifications.Specifications().Add(ctx, specification.Specification{ID: "report"}); err != nil {
+		t.Fatalf("failed to add a specification: %s", err)
+	}
+
+	definitions := NewDefinitions(specification.NewMemoryBinaryStorage())
+	if err := definitions.Add(ctx, Definition{ID: "hourly-report", Specification: "report", Schedule: "@hourly", CatchUp: catchUp}); err != nil {
+		t.Fatalf("failed to add a definition: %s", err)
+	}
+
+	// instances share the storages
+	ticks := NewMemoryTickStorage()
+	services := make([]*Service, 0, amount)
+	for i := 0; i < amount; i++ {
+		services = append(services, &Service{
+			System:         sys,
+			Specifications: specifications,
+			Definitions:    definitions,
+			Ticks:          ticks,
+			Lock:           &MemoryLock{},
+			Interval:       time.Minute,
+			Core:           service.NewCore("cron", fmt.Sprintf("test-%d", i), logger),
+		})
+	}
+
+	return services, sys
+}
+
+func spawned(t *testing.T, sys *core.System) []core.Task {
+	tasks, err := sys.Tasks().GetWithProperties(context.Background(), map[string][]any{
+		"info.cron_id": {"hourly-report"},
+	})
+	if err != nil {
+		t.Fatalf("failed to load tasks: %s", err)
+	}
+
+	return tasks
+}
+
+func TestTickFiresOnce(t *testing.T) {
+	ctx := context.Background()
+	services, sys := newTestServices(t, 2, "")
+
+	start := time.Date(2024, time.January, 31, 10, 59, 0, 0, time.UTC)
+	for _, srvc := range services {
+		if err := srvc.Tick(ctx, start); err != nil {
+			t.Fatalf("failed to tick: %s", err)
+		}
+	}
+
+	// both instances see the same due tick
+	for _, srvc := range services {
+		if err := srvc.Tick(ctx, start.Add(90*time.Second)); err != nil {
+			t.Fatalf("failed to tick: %s", err)
+		}
+	}
+
+	tasks := spawned(t, sys)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+
+	if tasks[0].Info["cron_tick"] != time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected tick: %v", tasks[0].Info["cron_tick"])
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 10, 59, 0, 0, time.UTC)
+	// 3 ticks (11:00, 12:00 and 13:00) are missed
+	end := start.Add(3 * time.Hour)
+
+	cases := map[string]int{
+		CatchUpPolicies.Skip: 0,
+		CatchUpPolicies.Last: 1,
+		CatchUpPolicies.All:  3,
+	}
+
+	for policy, expected := range cases {
+		ctx := context.Background()
+		services, sys := newTestServices(t, 1, policy)
+
+		for _, now := range []time.Time{start, end} {
+			if err := services[0].Tick(ctx, now); err != nil {
+				t.Fatalf("failed to tick: %s", err)
+			}
+		}
+
+		if tasks := spawned(t, sys); len(tasks) != expected {
+			t.Errorf("%s: expected %d tasks, got %d", policy, expected, len(tasks))
+		}
+	}
+}
+
+// failingTickStorage fails to advance ticks while failing is set
+type failingTickStorage struct {
+	TickStorage
+	failing bool
+}
+
+func (storage *failingTickStorage) Advance(ctx context.Context, definitionID string, from, to time.Time) (bool, error) {
+	if storage.failing && !from.IsZero() {
+		return false, errors.New("unavailable")
+	}
+
+	return storage.TickStorage.Advance(ctx, definitionID, from, to)
+}
+
+func TestTickIsRetriedWithoutDuplicates(t *testing.T) {
+	ctx := context.Background()
+	services, sys := newTestServices(t, 1, "")
+
+	ticks := &failingTickStorage{TickStorage: services[0].Ticks}
+	services[0].Ticks = ticks
+
+	start := time.Date(2024, time.January, 31, 10, 59, 0, 0, time.UTC)
+	if err := services[0].Tick(ctx, start); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+
+	// the task is spawned, but the tick is not advanced
+	ticks.failing = true
+	if err := services[0].Tick(ctx, start.Add(90*time.Second)); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+
+	ticks.failing = false
+	if err := services[0].Tick(ctx, start.Add(100*time.Second)); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+
+	if tasks := spawned(t, sys); len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+
+	last, _, err := ticks.LastTick(ctx, "hourly-report")
+	if err != nil || !last.Equal(time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("the tick has not been advanced: %s (%v)", last, err)
+	}
+}
+
+func TestClaimedTickIsNotFired(t *testing.T) {
+	ctx := context.Background()
+	services, sys := newTestServices(t, 2, "")
+
+	start := time.Date(2024, time.January, 31, 10, 59, 0, 0, time.UTC)
+	if err := services[0].Tick(ctx, start); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+
+	// the first instance has claimed the tick and is still firing it
+	tick := time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)
+	claimed, err := services[0].Ticks.Claim(ctx, "hourly-report", tick, services[0].ID(), time.Minute)
+	if err != nil || !claimed {
+		t.Fatalf("failed to claim the tick: %v", err)
+	}
+
+	if err := services[1].Tick(ctx, start.Add(90*time.Second)); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+	if tasks := spawned(t, sys); len(tasks) != 0 {
+		t.Fatalf("a claimed tick has been fired by another instance: %d tasks", len(tasks))
+	}
+
+	// the holder of the claim fires the tick
+	if err := services[0].Tick(ctx, start.Add(90*time.Second)); err != nil {
+		t.Fatalf("failed to tick: %s", err)
+	}
+	if tasks := spawned(t, sys); len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+}
+
+func TestDueTicksAreBounded(t *testing.T) {
+	schedule, err := Parse("* * * * *")
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+
+	last := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.January, 31, 11, 0, 30, 0, time.UTC)
+
+	// missed ticks are collapsed into the latest one (10:59), 11:00 is fresh
+	due := dueTicks(schedule, last, now, 2*time.Minute, CatchUpPolicies.Last)
+	if len(due) != 3 || !due[0].Equal(now.Add(-150*time.Second)) || !due[2].Equal(now.Add(-30*time.Second)) {
+		t.Fatalf("unexpected due ticks: %v", due)
+	}
+
+	due = dueTicks(schedule, last, now, 2*time.Minute, CatchUpPolicies.All)
+	if len(due) != maxCatchUp || !due[0].Equal(last.Add(time.Minute)) {
+		t.Fatalf("expected %d earliest ticks, got %d", maxCatchUp, len(due))
+	}
+}
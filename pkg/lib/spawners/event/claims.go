@@ -0,0 +1,94 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+// Claims makes sure that an event is handled by a single instance at a time
+type Claims interface {
+	// Claim claims the key for the holder until it's released or the ttl passes.
+	// It returns false if the key is claimed by someone else.
+	Claim(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+	// Release releases the key if it's claimed by the holder
+	Release(ctx context.Context, key, holder string) error
+}
+
+type memoryClaim struct {
+	holder    string
+	expiresAt time.Time
+}
+
+type MemoryClaims struct {
+	claims map[string]memoryClaim
+	mu     sync.Mutex
+}
+
+func NewMemoryClaims() *MemoryClaims {
+	return &MemoryClaims{claims: map[string]memoryClaim{}}
+}
+
+func (claims *MemoryClaims) Claim(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	claims.mu.Lock()
+	defer claims.mu.Unlock()
+
+	now := time.Now()
+	if claim, ok := claims.claims[key]; ok && claim.holder != holder && now.Before(claim.expiresAt) {
+		return false, nil
+	}
+
+	claims.claims[key] = memoryClaim{
+		holder:    holder,
+		expiresAt: now.Add(ttl),
+	}
+
+	return true, nil
+}
+
+func (claims *MemoryClaims) Release(ctx context.Context, key, holder string) error {
+	claims.mu.Lock()
+	defer claims.mu.Unlock()
+
+	if claim, ok := claims.claims[key]; ok && claim.holder == holder {
+		delete(claims.claims, key)
+	}
+
+	return nil
+}
+
+// releaseScript deletes the key only if it's held by the holder
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type RedisClaims struct {
+	Client redis.UniversalClient
+	Prefix string
+}
+
+func (claims *RedisClaims) Claim(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	claimed, err := claims.Client.SetNX(ctx, claims.key(key), holder, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim the key: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func (claims *RedisClaims) Release(ctx context.Context, key, holder string) error {
+	if err := releaseScript.Run(ctx, claims.Client, []string{claims.key(key)}, holder).Err(); err != nil {
+		return fmt.Errorf("failed to release the key: %w", err)
+	}
+
+	return nil
+}
+
+func (claims *RedisClaims) key(key string) string {
+	return claims.Prefix + ":" + key
+}
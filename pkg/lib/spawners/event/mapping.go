@@ -0,0 +1,99 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"strconv"
+	"strings"
+)
+
+// Mapping describes tasks spawned from a specification for every event published to the topic
+type Mapping struct {
+	ID            string `json:"id"`
+	Topic         string `json:"topic"`
+	Specification string `json:"specification"`
+	// Inputs are bound to the specification's inputs in order
+	Inputs []Binding      `json:"inputs,omitempty"`
+	Info   map[string]any `json:"info,omitempty"`
+}
+
+// Binding describes the data of an input resource.
+//
+// If Value is set, the constant is used, otherwise the payload is used:
+// the whole one if Path is empty or the JSON encoded field at the dot separated Path (e.g. "user.emails.0").
+type Binding struct {
+	Path  string  `json:"path,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// Parameters binds the payload to the inputs of the mapping
+func (mapping Mapping) Parameters(payload []byte) ([]string, error) {
+	var (
+		decoded    any
+		hasDecoded bool
+	)
+
+	parameters := make([]string, 0, len(mapping.Inputs))
+	for index, binding := range mapping.Inputs {
+		switch {
+		case binding.Value != nil:
+			parameters = append(parameters, *binding.Value)
+		case binding.Path == "":
+			parameters = append(parameters, string(payload))
+		default:
+			if !hasDecoded {
+				if err := json.Unmarshal(payload, &decoded); err != nil {
+					return nil, fmt.Errorf("failed to decode the payload: %w", err)
+				}
+				hasDecoded = true
+			}
+
+			value, err := lookup(decoded, binding.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to bind input %d: %w", index, err)
+			}
+
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode input %d: %w", index, err)
+			}
+
+			parameters = append(parameters, string(data))
+		}
+	}
+
+	return parameters, nil
+}
+
+func lookup(value any, path string) (any, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch typed := value.(type) {
+		case map[string]any:
+			field, ok := typed[key]
+			if !ok {
+				return nil, fmt.Errorf("field not found: %s", path)
+			}
+			value = field
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, fmt.Errorf("invalid index '%s': %s", key, path)
+			}
+			value = typed[index]
+		default:
+			return nil, fmt.Errorf("field not found: %s", path)
+		}
+	}
+
+	return value, nil
+}
+
+func NewMappings(raw specification.BinaryStorage) *specification.JsonStorage[Mapping] {
+	return &specification.JsonStorage[Mapping]{
+		Raw: raw,
+		IdFunc: func(mapping Mapping) string {
+			return mapping.ID
+		},
+	}
+}
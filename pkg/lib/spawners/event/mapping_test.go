@@ -0,0 +1,50 @@
+package event
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParameters(t *testing.T) {
+	constant := "42"
+	mapping := Mapping{
+		Inputs: []Binding{
+			{},
+			{Path: "user.name"},
+			{Path: "user.emails.1"},
+			{Path: "user"},
+			{Value: &constant},
+		},
+	}
+
+	payload := `{"user":{"name":"bob","emails":["a@x.io","b@x.io"]}}`
+
+	parameters, err := mapping.Parameters([]byte(payload))
+	if err != nil {
+		t.Fatalf("failed to bind: %s", err)
+	}
+
+	expected := []string{
+		payload,
+		`"bob"`,
+		`"b@x.io"`,
+		`{"emails":["a@x.io","b@x.io"],"name":"bob"}`,
+		"42",
+	}
+	if !reflect.DeepEqual(parameters, expected) {
+		t.Fatalf("unexpected parameters: %q (expected %q)", parameters, expected)
+	}
+
+	for _, path := range []string{"user.age", "user.emails.2", "user.name.first"} {
+		mapping := Mapping{Inputs: []Binding{{Path: path}}}
+		if _, err := mapping.Parameters([]byte(payload)); err == nil {
+			t.Fatalf("expected an error for '%s'", path)
+		}
+	}
+
+	// the payload is decoded only if a path is used
+	mapping = Mapping{Inputs: []Binding{{}}}
+	if _, err := mapping.Parameters([]byte("not json")); err != nil {
+		t.Fatalf("failed to bind a raw payload: %s", err)
+	}
+}
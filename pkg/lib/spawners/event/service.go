@@ -0,0 +1,239 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/data/uid"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+var QueueName = "event-spawner"
+
+// Service spawns tasks from events according to mappings.
+//
+// Every mapping has its own consumer group (QueueName:mapping id), so instances of the service share the load.
+// Mappings are reloaded every Interval: consumers of changed mappings are restarted, consumers of removed ones are stopped.
+// Spawned tasks are marked with info.event_mapping_id and info.event_id, so redelivered events don't spawn duplicates.
+// An event is claimed before a task is spawned from it, so concurrent deliveries of the event don't spawn duplicates either.
+type Service struct {
+	System         core.AbstractSystem
+	Specifications *specification.Manager
+	Mappings       *specification.JsonStorage[Mapping]
+	// Events is a broker of external events (raw payloads), events of the system are consumed if it's nil
+	Events core.Broker
+	Claims Claims
+	// ClaimTTL limits the time an event is claimed by a handler (30 seconds if not positive)
+	ClaimTTL time.Duration
+	// Interval between reloads of mappings
+	Interval time.Duration
+
+	service.Core
+}
+
+type consumer struct {
+	mapping Mapping
+	cancel  context.CancelFunc
+}
+
+func (srvc *Service) Run(ctx context.Context) error {
+	consumers := map[string]consumer{}
+	defer func() {
+		for _, c := range consumers {
+			c.cancel()
+		}
+	}()
+
+	ticker := time.NewTicker(srvc.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := srvc.sync(ctx, consumers); err != nil {
+			srvc.Logger().Error("failed to sync mappings",
+				slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (srvc *Service) sync(ctx context.Context, consumers map[string]consumer) error {
+	mappings, err := srvc.Mappings.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load mappings: %w", err)
+	}
+
+	actual := make(map[string]struct{}, len(mappings))
+	for _, mapping := range mappings {
+		actual[mapping.ID] = struct{}{}
+
+		if c, ok := consumers[mapping.ID]; ok {
+			if reflect.DeepEqual(c.mapping, mapping) {
+				continue
+			}
+			c.cancel()
+			delete(consumers, mapping.ID)
+		}
+
+		consumerCtx, cancel := context.WithCancel(ctx)
+		if err := srvc.consume(consumerCtx, mapping); err != nil {
+			cancel()
+			srvc.Logger().Error("failed to start a consumer",
+				slog.String("mapping_id", mapping.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		consumers[mapping.ID] = consumer{
+			mapping: mapping,
+			cancel:  cancel,
+		}
+
+		srvc.Logger().Info("started a consumer",
+			slog.String("mapping_id", mapping.ID),
+			slog.String("topic", mapping.Topic))
+	}
+
+	for id, c := range consumers {
+		if _, ok := actual[id]; ok {
+			continue
+		}
+
+		c.cancel()
+		delete(consumers, id)
+
+		srvc.Logger().Info("stopped a consumer",
+			slog.String("mapping_id", id))
+	}
+
+	return nil
+}
+
+func (srvc *Service) consume(ctx context.Context, mapping Mapping) error {
+	events := srvc.Events
+	if events == nil {
+		events = srvc.System.Events()
+	}
+
+	evs, err := events.
+		Consume(ctx,
+			[]string{mapping.Topic},
+			broker.ConsumerSettings{
+				Group:                fmt.Sprintf("%s:%s", QueueName, mapping.ID),
+				InitializationPolicy: broker.NewestOffset,
+			},
+		)
+	if err != nil {
+		return fmt.Errorf("failed to consume events: %w", err)
+	}
+
+	go broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			return srvc.Handle(ctx, mapping, ev)
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			srvc.Logger().Error("processing failed",
+				slog.String("mapping_id", mapping.ID),
+				slog.String("event_id", ev.ID),
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, evs)
+
+	return nil
+}
+
+// Handle spawns a task from the event.
+//
+// Events that can't be bound to the inputs are dropped (an error is returned only if the event must be redelivered).
+func (srvc *Service) Handle(ctx context.Context, mapping Mapping, ev core.Event) error {
+	parameters, err := mapping.Parameters(ev.Data)
+	if err != nil {
+		srvc.Logger().Warn("dropped an event",
+			slog.String("mapping_id", mapping.ID),
+			slog.String("event_id", ev.ID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	// the claim makes the check and the spawn atomic
+	key := fmt.Sprintf("%s:%s", mapping.ID, ev.ID)
+	holder := uid.Generate()
+	claimed, err := srvc.Claims.Claim(ctx, key, holder, srvc.claimTTL())
+	if err != nil {
+		return fmt.Errorf("failed to claim the event: %w", err)
+	}
+	if !claimed {
+		// the event is redelivered later
+		return errors.New("the event is claimed by another handler")
+	}
+	defer func() {
+		if err := srvc.Claims.Release(ctx, key, holder); err != nil {
+			srvc.Logger().Warn("failed to release the event",
+				slog.String("mapping_id", mapping.ID),
+				slog.String("event_id", ev.ID),
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	spawned, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"info.event_mapping_id": {mapping.ID},
+		"info.event_id":         {ev.ID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load spawned tasks: %w", err)
+	}
+	if len(spawned) > 0 {
+		return nil
+	}
+
+	spec, err := srvc.Specifications.Specifications().Get(ctx, mapping.Specification)
+	if err != nil {
+		return fmt.Errorf("failed to load the specification: %w", err)
+	}
+
+	if len(parameters) != len(spec.IO.Inputs.Types) {
+		srvc.Logger().Warn("dropped an event: incorrect amount of inputs",
+			slog.String("mapping_id", mapping.ID),
+			slog.String("event_id", ev.ID),
+			slog.Int("inputs", len(parameters)),
+			slog.Int("expected", len(spec.IO.Inputs.Types)))
+		return nil
+	}
+
+	info := make(map[string]any, len(mapping.Info)+2)
+	for key, value := range mapping.Info {
+		info[key] = value
+	}
+	info["event_mapping_id"] = mapping.ID
+	info["event_id"] = ev.ID
+
+	task, err := specification.Spawn(ctx, srvc.System, spec, parameters, info)
+	if err != nil {
+		return fmt.Errorf("failed to spawn a task: %w", err)
+	}
+
+	srvc.Logger().Info("spawned a task",
+		slog.String("mapping_id", mapping.ID),
+		slog.String("event_id", ev.ID),
+		slog.String("task_id", task.ID))
+
+	return nil
+}
+
+func (srvc *Service) claimTTL() time.Duration {
+	if srvc.ClaimTTL <= 0 {
+		return 30 * time.Second
+	}
+
+	return srvc.ClaimTTL
+}
@@ -0,0 +1,180 @@
+package event
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/specification/typing"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestService(t *testing.T) (*Service, *core.System) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		inmem.New(),
+		logger,
+	)
+
+	specifications := specification.NewManager(specification.NewMemoryBinaryStorage(), specification.NewMemoryBinaryStorage())
+	err := specifications.Specifications().Add(ctx, specification.Specification{
+		ID: "greet",
+		IO: specification.IO{
+			Inputs: specification.ResourceSet{
+				Naming: map[int]string{0: "name"},
+				Types:  map[int]typing.Type{0: {Name: "string"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to add a specification: %s", err)
+	}
+
+	srvc := &Service{
+		System:         sys,
+		Specifications: specifications,
+		Mappings:       NewMappings(specification.NewMemoryBinaryStorage()),
+		Claims:         NewMemoryClaims(),
+		Interval:       20 * time.Millisecond,
+		Core:           service.NewCore("event-spawner", "test", logger),
+	}
+
+	return srvc, sys
+}
+
+func spawned(t *testing.T, sys *core.System, mappingID string) []core.Task {
+	tasks, err := sys.Tasks().GetWithProperties(context.Background(), map[string][]any{
+		"info.event_mapping_id": {mappingID},
+	})
+	if err != nil {
+		t.Fatalf("failed to load tasks: %s", err)
+	}
+
+	return tasks
+}
+
+func TestHandle(t *testing.T) {
+	ctx := context.Background()
+	srvc, sys := newTestService(t)
+
+	mapping := Mapping{
+		ID:            "signups",
+		Topic:         "users.signed_up",
+		Specification: "greet",
+		Inputs:        []Binding{{Path: "name"}},
+		Info:          map[string]any{"source": "test"},
+	}
+
+	ev := core.NewEvent(mapping.Topic, []byte(`{"name":"alice"}`))
+
+	// the second call emulates a redelivery
+	for i := 0; i < 2; i++ {
+		if err := srvc.Handle(ctx, mapping, ev); err != nil {
+			t.Fatalf("failed to handle the event: %s", err)
+		}
+	}
+
+	tasks := spawned(t, sys, mapping.ID)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.Info["event_id"] != ev.ID || task.Info["source"] != "test" || task.Info["type"] != "greet" {
+		t.Fatalf("unexpected task info: %v", task.Info)
+	}
+
+	inputs, err := sys.Resources().Load(ctx, task.Inputs...)
+	if err != nil {
+		t.Fatalf("failed to load inputs: %s", err)
+	}
+	if len(inputs) != 1 || string(inputs[0].Data) != `"alice"` {
+		t.Fatalf("unexpected inputs: %v", inputs)
+	}
+
+	// an event claimed by another handler is redelivered later
+	claimed := core.NewEvent(mapping.Topic, []byte(`{"name":"bob"}`))
+	if _, err := srvc.Claims.Claim(ctx, mapping.ID+":"+claimed.ID, "another", time.Minute); err != nil {
+		t.Fatalf("failed to claim the event: %s", err)
+	}
+	if err := srvc.Handle(ctx, mapping, claimed); err == nil {
+		t.Fatal("expected the claimed event to be rejected")
+	}
+	if err := srvc.Claims.Release(ctx, mapping.ID+":"+claimed.ID, "another"); err != nil {
+		t.Fatalf("failed to release the event: %s", err)
+	}
+	if err := srvc.Handle(ctx, mapping, claimed); err != nil {
+		t.Fatalf("failed to handle the event: %s", err)
+	}
+	if tasks := spawned(t, sys, mapping.ID); len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	// events that can't be bound are dropped
+	if err := srvc.Handle(ctx, mapping, core.NewEvent(mapping.Topic, []byte(`{}`))); err != nil {
+		t.Fatalf("expected the event to be dropped, got: %s", err)
+	}
+	if tasks := spawned(t, sys, mapping.ID); len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+}
+
+func TestRunFollowsMappings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvc, sys := newTestService(t)
+
+	mapping := Mapping{
+		ID:            "signups",
+		Topic:         "users.signed_up",
+		Specification: "greet",
+		Inputs:        []Binding{{}},
+	}
+	if err := srvc.Mappings.Add(ctx, mapping); err != nil {
+		t.Fatalf("failed to add a mapping: %s", err)
+	}
+
+	go srvc.Run(ctx)
+
+	// new consumers start from the newest offset, so the event is published until a task appears
+	deadline := time.Now().Add(5 * time.Second)
+	for len(spawned(t, sys, mapping.ID)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no task has been spawned")
+		}
+
+		if err := sys.Events().Send(ctx, core.NewEvent(mapping.Topic, []byte("bob"))); err != nil {
+			t.Fatalf("failed to send an event: %s", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if err := srvc.Mappings.Remove(ctx, mapping.ID); err != nil {
+		t.Fatalf("failed to remove the mapping: %s", err)
+	}
+
+	// wait for the consumer to stop
+	time.Sleep(10 * srvc.Interval)
+	before := len(spawned(t, sys, mapping.ID))
+
+	if err := sys.Events().Send(ctx, core.NewEvent(mapping.Topic, []byte("bob"))); err != nil {
+		t.Fatalf("failed to send an event: %s", err)
+	}
+	time.Sleep(10 * srvc.Interval)
+
+	if after := len(spawned(t, sys, mapping.ID)); after != before {
+		t.Fatalf("a task has been spawned by a removed mapping (%d -> %d)", before, after)
+	}
+}
@@ -0,0 +1,93 @@
+package canceller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/samber/lo"
+)
+
+// CancelTree cancels the task, every task sharing its execution context and every task
+// descended from them (via "context_parent_id").
+//
+// Tasks that are not active anymore are skipped, the ids of cancelled tasks are returned.
+func CancelTree(ctx context.Context, system core.AbstractSystem, id string, reason string) (cancelled []string, err error) {
+	root, err := system.Task(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	tree, err := collectTree(ctx, system, root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, taskId := range tree {
+		err := system.Cancel(ctx, taskId, reason)
+		if errors.Is(err, core.ErrIllegalTransition) {
+			continue
+		}
+		if err != nil {
+			return cancelled, fmt.Errorf("failed to cancel task '%s': %w", taskId, err)
+		}
+
+		cancelled = append(cancelled, taskId)
+	}
+
+	return cancelled, nil
+}
+
+// collectTree returns the ids of the task tree (the root goes first)
+func collectTree(ctx context.Context, system core.AbstractSystem, root core.Task) ([]string, error) {
+	visited := map[string]struct{}{root.ID: {}}
+	visitedContexts := map[string]struct{}{}
+	result := []string{root.ID}
+
+	frontier := []core.Task{root}
+	for len(frontier) > 0 {
+		var (
+			parentIds  []any
+			contextIds []any
+		)
+		for _, task := range frontier {
+			parentIds = append(parentIds, task.ID)
+
+			contextId := task.ContextID()
+			if _, ok := visitedContexts[contextId]; contextId != "" && !ok {
+				visitedContexts[contextId] = struct{}{}
+				contextIds = append(contextIds, contextId)
+			}
+		}
+
+		children, err := system.Tasks().GetWithProperties(ctx, map[string][]any{
+			"info.context_parent_id": parentIds,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load child tasks: %w", err)
+		}
+
+		var siblings []core.Task
+		if len(contextIds) > 0 {
+			siblings, err = system.Tasks().GetWithProperties(ctx, map[string][]any{
+				"info.context_id": contextIds,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to load tasks from the same context: %w", err)
+			}
+		}
+
+		frontier = nil
+		for _, task := range lo.Flatten([][]core.Task{children, siblings}) {
+			if _, ok := visited[task.ID]; ok {
+				continue
+			}
+			visited[task.ID] = struct{}{}
+
+			result = append(result, task.ID)
+			frontier = append(frontier, task)
+		}
+	}
+
+	return result, nil
+}
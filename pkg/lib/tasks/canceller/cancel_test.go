@@ -0,0 +1,76 @@
+package canceller
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestCancelTree(t *testing.T) {
+	ctx := context.Background()
+
+	tasks := taskdb.New()
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(inmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		tasks,
+		slog.Default(),
+	)
+
+	active := map[string]any{"status": core.TaskStatuses.Received}
+	err := tasks.Insert(ctx, []core.Task{
+		{ID: "root", Info: withInfo(active, "context_id", "a")},
+		{ID: "sibling", Info: withInfo(active, "context_id", "a")},
+		{ID: "child", Info: withInfo(withInfo(active, "context_id", "b"), "context_parent_id", "sibling")},
+		{ID: "finished", Info: map[string]any{"context_id": "a", "status": core.TaskStatuses.Finished}},
+		{ID: "unrelated", Info: withInfo(active, "context_id", "c")},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	cancelled, err := CancelTree(ctx, sys, "root", "test")
+	if err != nil {
+		t.Fatalf("failed to cancel: %s", err)
+	}
+
+	slices.Sort(cancelled)
+	if !slices.Equal(cancelled, []string{"child", "root", "sibling"}) {
+		t.Fatalf("unexpected cancelled tasks: %v", cancelled)
+	}
+
+	loaded, err := tasks.ByIDs(ctx, []string{"root", "finished", "unrelated"})
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+
+	for _, task := range loaded {
+		expected := map[string]string{
+			"root":      core.TaskStatuses.Cancelled,
+			"finished":  core.TaskStatuses.Finished,
+			"unrelated": core.TaskStatuses.Received,
+		}[task.ID]
+
+		if task.Status() != expected {
+			t.Fatalf("task '%s': expected status '%s', got '%s'", task.ID, expected, task.Status())
+		}
+	}
+
+	if loaded[0].Info["cancel_reason"] != "test" {
+		t.Fatalf("unexpected cancel reason: %v", loaded[0].Info["cancel_reason"])
+	}
+}
+
+func withInfo(info map[string]any, key string, value any) map[string]any {
+	result := map[string]any{key: value}
+	for k, v := range info {
+		result[k] = v
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package fn
 import (
 	"context"
 	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/core/taskopts"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/fn/future"
@@ -54,12 +55,16 @@ func (ctx *Context) bindObjectToResources(obj any, linkTo []string) ([]future.Ab
 }
 
 func (ctx *Context) spawn(sys core.AbstractSystem, parentTask core.Task) error {
-	// sort in reverse top-sort order to ensure minimal possible execution while rollback is possible
-	for _, t := range ctx.Scheduled {
-		fut2res := func(fut future.AbstractFuture, _ int) string {
-			return ctx.FutureStorage.GetResource(fut).ID
-		}
+	if len(ctx.Scheduled) == 0 {
+		return nil
+	}
 
+	fut2res := func(fut future.AbstractFuture, _ int) string {
+		return ctx.FutureStorage.GetResource(fut).ID
+	}
+
+	tasks := make([]core.Task, 0, len(ctx.Scheduled))
+	for _, t := range ctx.Scheduled {
 		inputs := lo.Map(t.Inputs, fut2res)
 		outputs := lo.Map(t.Outputs, fut2res)
 		deps := lo.Map(inputs, func(res string, _ int) taskopts.Dependency {
@@ -69,22 +74,26 @@ func (ctx *Context) spawn(sys core.AbstractSystem, parentTask core.Task) error {
 			}
 		})
 
-		spawned, err := sys.Spawn(ctx,
-			core.New(
-				taskopts.WithInputs(inputs...),
-				taskopts.WithOutputs(outputs...),
-				taskopts.WithProperty("context_parent_id", parentTask.ID),
-				taskopts.WithType(t.Type),
-				taskopts.WithDependencies(deps...),
-				taskopts.WithContextID(parentTask.ContextID()),
-			),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to spawn task: %w", err)
-		}
+		tasks = append(tasks, core.New(
+			taskopts.WithInputs(inputs...),
+			taskopts.WithOutputs(outputs...),
+			taskopts.WithProperty("context_parent_id", parentTask.ID),
+			taskopts.WithType(t.Type),
+			taskopts.WithDependencies(dependency.AllOf(), deps...),
+			taskopts.WithContextID(parentTask.ContextID()),
+		))
+	}
+
+	// all scheduled tasks are spawned in one batch (either all of them or none)
+	spawned, err := sys.SpawnBatch(ctx, tasks)
+	if err != nil {
+		return fmt.Errorf("failed to spawn tasks: %w", err)
+	}
 
-		ctx.spawnedTasks = append(ctx.spawnedTasks, spawned.ID)
+	for _, t := range spawned {
+		ctx.spawnedTasks = append(ctx.spawnedTasks, t.ID)
 	}
+
 	return nil
 }
 
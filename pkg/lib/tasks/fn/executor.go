@@ -5,8 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
 	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/lib/resources"
 	"github.com/ischenkx/kantoku/pkg/lib/tasks/fn/future"
+	"github.com/mitchellh/mapstructure"
+	"io"
 	"reflect"
 	"strings"
 )
@@ -49,12 +53,43 @@ func (e Executor[T, I, O]) Type() string {
 func (e Executor[T, I, O]) prepareInput(ctx *Context, sys core.AbstractSystem, task core.Task) (I, error) {
 	var input I
 
-	inputResources, err := sys.Resources().Load(ctx, task.Inputs...)
+	structType := e.task.InputType()
+	if structType.NumField() != len(task.Inputs) {
+		return input, errors.New("input struct doesn't match inputs")
+	}
+
+	// streams are read on demand, so their resources are not loaded
+	var loadedIds []string
+	for i, id := range task.Inputs {
+		if structType.Field(i).Type != streamType {
+			loadedIds = append(loadedIds, id)
+		}
+	}
+
+	loadedResources, err := sys.Resources().Load(ctx, loadedIds...)
 	if err != nil {
 		return input, fmt.Errorf("failed to load resources: %w", err)
 	}
+	if len(loadedResources) != len(loadedIds) {
+		return input, errors.New("unexpected amount of loaded resources")
+	}
+
+	inputResources := make([]core.Resource, len(task.Inputs))
+	for i, id := range task.Inputs {
+		if structType.Field(i).Type == streamType {
+			inputResources[i] = core.Resource{ID: id, Status: core.ResourceStatuses.Ready}
+			continue
+		}
+
+		inputResources[i], loadedResources = loadedResources[0], loadedResources[1:]
+	}
 
-	input, err = e.buildInput(ctx, inputResources)
+	optional, err := optionalInputs(task)
+	if err != nil {
+		return input, fmt.Errorf("failed to decode dependencies: %w", err)
+	}
+
+	input, err = e.buildInput(ctx, sys, inputResources, optional)
 	if err != nil {
 		return input, err
 	}
@@ -94,7 +129,7 @@ func (e Executor[T, I, O]) save(ctx *Context, sys core.AbstractSystem, task core
 }
 
 // can replace any in return value to 'I', but it's hard to return empty value this way
-func (e Executor[T, I, O]) buildInput(ctx *Context, resources []core.Resource) (I, error) {
+func (e Executor[T, I, O]) buildInput(ctx *Context, sys core.AbstractSystem, resources []core.Resource, optional map[string]bool) (I, error) {
 	// TODO: use (var input I; reflect.TypeOf(input)
 	structType := e.task.InputType()
 	structValue := reflect.New(structType).Elem()
@@ -111,15 +146,23 @@ func (e Executor[T, I, O]) buildInput(ctx *Context, resources []core.Resource) (
 
 	// Initialize struct fields from the fields array
 	for i := 0; i < numFields; i++ {
-		if resources[i].Status != core.ResourceStatuses.Ready {
+		if structValue.Field(i).Type() == streamType {
+			structValue.Field(i).Set(reflect.ValueOf(newStream(sys, resources[i].ID)))
+		} else if resources[i].Status == core.ResourceStatuses.Ready {
+			err := parseField(resources[i].Data, structValue.Field(i))
+			if err != nil {
+				return input, err
+			}
+		} else if optional[resources[i].ID] {
+			// the task runs without the input (e.g. it's an optional dependency that has failed),
+			// so the future is left empty (see future.Future.IsFilled)
+			emptyField(structValue.Field(i))
+		} else if resources[i].Status == core.ResourceStatuses.Expired {
+			return input, fmt.Errorf("input '%s' at position %d: %w", resources[i].ID, i, core.ErrResourceExpired)
+		} else {
 			return input, fmt.Errorf("not ready resource_db at position %d", i)
 		}
 
-		err := parseField(resources[i].Data, structValue.Field(i))
-		if err != nil {
-			return input, err
-		}
-
 		// save resource_db to storage so they won't be copied
 		fut, ok := structValue.Field(i).Interface().(future.AbstractFuture)
 		if !ok {
@@ -137,6 +180,57 @@ func (e Executor[T, I, O]) buildInput(ctx *Context, resources []core.Resource) (
 	return input, nil
 }
 
+// optionalInputs returns the inputs the task can run without: optional resource dependencies
+// or all of them if the task doesn't need every dependency to be satisfied (see dependency.KOf)
+func optionalInputs(task core.Task) (map[string]bool, error) {
+	var info struct {
+		Specs []struct {
+			Name     string
+			Data     any
+			Optional bool
+		}
+		Mode dependency.Mode
+	}
+	if err := mapstructure.Decode(task.Info["dependencies"], &info); err != nil {
+		return nil, err
+	}
+
+	optional := map[string]bool{}
+	if info.Mode.Required > 0 && info.Mode.Required < len(info.Specs) {
+		for _, id := range task.Inputs {
+			optional[id] = true
+		}
+		return optional, nil
+	}
+
+	for _, spec := range info.Specs {
+		if id, ok := spec.Data.(string); ok && spec.Name == resourceDependency && spec.Optional {
+			optional[id] = true
+		}
+	}
+	return optional, nil
+}
+
+const resourceDependency = "resource_db"
+
+var streamType = reflect.TypeOf(future.Stream{})
+
+func newStream(sys core.AbstractSystem, id string) future.Stream {
+	streams := resources.Streaming(sys.Resources())
+
+	return future.NewStream(func(ctx context.Context) (io.ReadCloser, error) {
+		return streams.OpenReader(ctx, id)
+	})
+}
+
+func emptyField(field reflect.Value) {
+	fut := reflect.New(field.Type())
+	if initializeable, ok := fut.Interface().(future.InitializeableFuture); ok {
+		initializeable.Initialize()
+	}
+	field.Set(fut.Elem())
+}
+
 func parseField(data []byte, field reflect.Value) error {
 	uninitializedFut := reflect.New(field.Type())
 	futAndErr := uninitializedFut.MethodByName("ParseToNew").Call([]reflect.Value{reflect.ValueOf(data)})
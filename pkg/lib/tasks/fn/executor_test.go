@@ -0,0 +1,207 @@
+package fn
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	"github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/fn/future"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type (
+	countBytesInput struct {
+		Data future.Stream
+	}
+
+	countBytesOutput struct {
+		Size future.Future[int]
+	}
+
+	countBytes struct {
+		Function[countBytes, countBytesInput, countBytesOutput]
+	}
+)
+
+func (f countBytes) Call(ctx *Context, input countBytesInput) (output countBytesOutput, err error) {
+	reader, err := input.Data.Open(ctx)
+	if err != nil {
+		return output, err
+	}
+	defer reader.Close()
+
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return output, err
+	}
+
+	output.Size = future.FromValue(int(n))
+	return output, nil
+}
+
+func TestStreamInput(t *testing.T) {
+	ctx := context.Background()
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		inmem.New(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	ids, err := sys.Resources().Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+	if err := sys.Resources().Init(ctx, []core.Resource{{ID: ids[0], Data: []byte("hello")}}); err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+
+	task, err := sys.Spawn(ctx, core.New(
+		taskopts.WithInputs(ids[0]),
+		taskopts.WithOutputs(ids[1]),
+		taskopts.WithDependencies(dependency.AllOf(), taskopts.Optional(taskopts.ResourceDependency(ids[0]))),
+	))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	if err := NewExecutor[countBytes, countBytesInput, countBytesOutput](countBytes{}).Execute(ctx, sys, task); err != nil {
+		t.Fatalf("failed to execute: %s", err)
+	}
+
+	outputs, err := sys.Resources().Load(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("failed to load the output: %s", err)
+	}
+	if outputs[0].Status != core.ResourceStatuses.Ready || string(outputs[0].Data) != "5" {
+		t.Fatalf("unexpected output: %+v", outputs[0])
+	}
+
+	if spec := ToSpecification(countBytes{}.Function); spec.IO.Inputs.Types[0].Name != "binary" {
+		t.Fatalf("unexpected type of the stream input: %+v", spec.IO.Inputs.Types[0])
+	}
+}
+
+type (
+	orDefaultInput struct {
+		Value future.Future[int]
+	}
+
+	orDefaultOutput struct {
+		Value future.Future[int]
+	}
+
+	orDefault struct {
+		Function[orDefault, orDefaultInput, orDefaultOutput]
+	}
+)
+
+func (f orDefault) Call(ctx *Context, input orDefaultInput) (output orDefaultOutput, err error) {
+	if !input.Value.IsFilled() {
+		output.Value = future.FromValue(-1)
+		return output, nil
+	}
+
+	output.Value = future.FromValue(input.Value.Value())
+	return output, nil
+}
+
+func TestMissingInputIsEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		inmem.New(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	// the input is never initialized (e.g. its producer has failed)
+	ids, err := sys.Resources().Alloc(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	task, err := sys.Spawn(ctx, core.New(
+		taskopts.WithInputs(ids[0]),
+		taskopts.WithOutputs(ids[1]),
+		taskopts.WithDependencies(dependency.AllOf(), taskopts.Optional(taskopts.ResourceDependency(ids[0]))),
+	))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+
+	if err := NewExecutor[orDefault, orDefaultInput, orDefaultOutput](orDefault{}).Execute(ctx, sys, task); err != nil {
+		t.Fatalf("failed to execute: %s", err)
+	}
+
+	resources, err := sys.Resources().Load(ctx, ids...)
+	if err != nil {
+		t.Fatalf("failed to load resources: %s", err)
+	}
+	if resources[0].Status != core.ResourceStatuses.Allocated {
+		t.Fatalf("the missing input has been initialized: %+v", resources[0])
+	}
+	if resources[1].Status != core.ResourceStatuses.Ready || string(resources[1].Data) != "-1" {
+		t.Fatalf("unexpected output: %+v", resources[1])
+	}
+}
+
+func TestRequiredInputMustBeReady(t *testing.T) {
+	ctx := context.Background()
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		inmem.New(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	ids, err := sys.Resources().Alloc(ctx, 3)
+	if err != nil {
+		t.Fatalf("failed to allocate: %s", err)
+	}
+
+	err = sys.Resources().Init(ctx, []core.Resource{{ID: ids[1], Data: []byte("1"), TTL: time.Millisecond}})
+	if err != nil {
+		t.Fatalf("failed to initialize: %s", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	executor := NewExecutor[orDefault, orDefaultInput, orDefaultOutput](orDefault{})
+
+	// the input is never initialized
+	task, err := sys.Spawn(ctx, core.New(taskopts.WithInputs(ids[0]), taskopts.WithOutputs(ids[2]), taskopts.DependOnInputs()))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	if err := executor.Execute(ctx, sys, task); err == nil {
+		t.Fatal("expected the task to fail without its input")
+	}
+
+	// the input has expired
+	task, err = sys.Spawn(ctx, core.New(taskopts.WithInputs(ids[1]), taskopts.WithOutputs(ids[2]), taskopts.DependOnInputs()))
+	if err != nil {
+		t.Fatalf("failed to spawn: %s", err)
+	}
+	if err := executor.Execute(ctx, sys, task); !errors.Is(err, core.ErrResourceExpired) {
+		t.Fatalf("expected %s, got: %v", core.ErrResourceExpired, err)
+	}
+
+	resources, err := sys.Resources().Load(ctx, ids[2])
+	if err != nil {
+		t.Fatalf("failed to load resources: %s", err)
+	}
+	if resources[0].Status != core.ResourceStatuses.Allocated {
+		t.Fatalf("the output has been initialized: %+v", resources[0])
+	}
+}
@@ -44,6 +44,7 @@ func (f Future[T]) Value() T { // or *T?
 	return *f.value
 }
 
+// IsFilled is false for inputs that have not been initialized (e.g. failed optional dependencies)
 func (f Future[T]) IsFilled() bool {
 	return f.filled
 }
@@ -0,0 +1,47 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"io"
+	"sync/atomic"
+)
+
+// Stream is an input that is read directly from the resource database instead of being decoded into memory.
+//
+// The raw data of the resource is streamed (e.g. JSON for resources produced by futures).
+// A stream can be passed to scheduled tasks as an input, but it can't be used as an output.
+type Stream struct {
+	id   fid
+	open func(ctx context.Context) (io.ReadCloser, error)
+}
+
+func NewStream(open func(ctx context.Context) (io.ReadCloser, error)) Stream {
+	return Stream{
+		id:   fid(atomic.AddInt32((*int32)(&idCounter), 1)),
+		open: open,
+	}
+}
+
+// Open returns a reader of the resource data, it must be closed by the caller
+func (s Stream) Open(ctx context.Context) (io.ReadCloser, error) {
+	if s.open == nil {
+		return nil, errors.New("stream is not bound to a resource")
+	}
+
+	return s.open(ctx)
+}
+
+// IsFilled is always false as the data of a stream is never kept in memory
+func (s Stream) IsFilled() bool {
+	return false
+}
+
+func (s Stream) Encode(codec codec.Codec[any, []byte]) ([]byte, error) {
+	return nil, errors.New("can't make resource_db from a stream")
+}
+
+func (s Stream) getId() fid {
+	return s.id
+}
@@ -40,6 +40,11 @@ func structToResourceSet[T any](s T) specification.ResourceSet {
 		resourceSet.Naming[i] = field.Name
 
 		fieldType := field.Type
+		if fieldType == streamType {
+			resourceSet.Types[i] = typing.Binary()
+			continue
+		}
+
 		if fieldType.Kind() == reflect.Struct &&
 			strings.HasPrefix(fieldType.Name(), "Future[") &&
 			fieldType.PkgPath() == "github.com/ischenkx/kantoku/pkg/lib/tasks/fn/future" {
@@ -0,0 +1,482 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/retrier"
+	"github.com/samber/lo"
+	"log/slog"
+	"time"
+)
+
+// Policy sets the retention of tasks of a type
+type Policy struct {
+	Type      string
+	Retention time.Duration
+}
+
+// Report lists everything deleted (or that would be deleted in the dry-run mode) by a collection
+type Report struct {
+	Trees     int
+	Tasks     []string
+	Resources []string
+	Groups    []string
+	// UninitializedGroups are dependency groups left uninitialized by interrupted registrations
+	UninitializedGroups []string
+}
+
+// Service deletes finished task trees.
+//
+// A tree is a set of tasks connected by execution contexts (info.context_id) and parent-child relations
+// (info.context_parent_id). It's collected when all of its tasks are finished and the retention of each task
+// has passed since its last status transition. The retention of a task is info.retention (see taskopts.WithRetention),
+// the policy of its type or the default one. Trees with tasks without any retention are kept forever.
+//
+// Resources of a tree are deallocated unless they are referenced by unfinished tasks outside the tree,
+// dependency groups, their bindings in resolvers and task-group bindings are deleted as well.
+// Tasks are deleted last, so a failed collection is retried on the next run.
+//
+// Each run looks at a page of finished tasks ordered by info.finished_at (if the task db is a core.PagedTaskDB),
+// the next run continues from where the previous one has stopped and the last page wraps around to the first one.
+//
+// Dependency groups that are not initialized within UninitializedGroupAge and are not referenced by any task
+// (a registration interrupted before saving its group) are deleted too, if the manager is a dependency.UninitializedGroupsFinder.
+type Service struct {
+	System       core.AbstractSystem
+	Dependencies dependency.Manager
+	TaskToGroup  manager.TaskToGroup
+	// Resolvers whose bindings are deleted with the groups (those that are manager.CollectableResolver)
+	Resolvers map[string]manager.Resolver
+	// Retention of tasks that don't match any policy (such tasks are kept forever if not set)
+	Retention time.Duration
+	Policies  []Policy
+	// Interval between collections
+	Interval time.Duration
+	// DryRun makes the service only report what would be deleted
+	DryRun bool
+	// PageSize is the maximal amount of finished tasks looked at by a run (DefaultPageSize if not positive)
+	PageSize int
+	// UninitializedGroupAge is the age of uninitialized groups that are collected (DefaultUninitializedGroupAge if not positive)
+	UninitializedGroupAge time.Duration
+
+	cursor core.PageCursor
+
+	service.Core
+}
+
+const (
+	DefaultPageSize              = 1000
+	DefaultUninitializedGroupAge = time.Hour
+)
+
+func (srvc *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(srvc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			report, err := srvc.Collect(ctx, time.Now())
+			if err != nil {
+				srvc.Logger().Error("failed to collect garbage",
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			if report.Trees == 0 && len(report.UninitializedGroups) == 0 {
+				continue
+			}
+
+			message := "collected garbage"
+			if srvc.DryRun {
+				message = "found garbage (dry run)"
+			}
+
+			srvc.Logger().Info(message,
+				slog.Int("trees", report.Trees),
+				slog.Any("tasks", report.Tasks),
+				slog.Any("resources", report.Resources),
+				slog.Any("groups", report.Groups),
+				slog.Any("uninitialized_groups", report.UninitializedGroups))
+		}
+	}
+}
+
+// Collect deletes task trees of the next page of finished tasks that are expired at the moment
+func (srvc *Service) Collect(ctx context.Context, now time.Time) (Report, error) {
+	candidates, err := srvc.nextPage(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load finished tasks: %w", err)
+	}
+
+	var report Report
+	visited := map[string]struct{}{}
+	for _, candidate := range candidates {
+		if _, ok := visited[candidate.ID]; ok {
+			continue
+		}
+
+		tree, err := collectTree(ctx, srvc.System, candidate)
+		if err != nil {
+			return report, fmt.Errorf("failed to collect the tree of '%s': %w", candidate.ID, err)
+		}
+
+		for _, task := range tree {
+			visited[task.ID] = struct{}{}
+		}
+
+		if !srvc.expired(tree, now) {
+			continue
+		}
+
+		if err := srvc.sweep(ctx, tree, &report); err != nil {
+			return report, fmt.Errorf("failed to delete the tree of '%s': %w", candidate.ID, err)
+		}
+	}
+
+	if err := srvc.collectUninitializedGroups(ctx, &report); err != nil {
+		return report, fmt.Errorf("failed to collect uninitialized groups: %w", err)
+	}
+
+	return report, nil
+}
+
+// nextPage returns finished tasks after the cursor and moves it (task dbs that can't page are scanned entirely)
+func (srvc *Service) nextPage(ctx context.Context) ([]core.Task, error) {
+	filter := map[string][]any{
+		"info.status": {core.TaskStatuses.Finished, core.TaskStatuses.Cancelled},
+	}
+
+	pager, ok := srvc.System.Tasks().(core.PagedTaskDB)
+	if !ok {
+		return srvc.System.Tasks().GetWithProperties(ctx, filter)
+	}
+
+	pageSize := srvc.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	page, err := pager.GetPage(ctx, filter, "info.finished_at", srvc.cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page) < pageSize {
+		srvc.cursor = core.PageCursor{}
+	} else {
+		last := page[len(page)-1]
+		finishedAt, _ := last.FinishedAt()
+		srvc.cursor = core.PageCursor{Value: finishedAt.Unix(), ID: last.ID}
+	}
+
+	return page, nil
+}
+
+// collectUninitializedGroups deletes old groups that have never been initialized and are not referenced by tasks
+func (srvc *Service) collectUninitializedGroups(ctx context.Context, report *Report) error {
+	finder, ok := srvc.Dependencies.(dependency.UninitializedGroupsFinder)
+	if !ok {
+		return nil
+	}
+
+	age := srvc.UninitializedGroupAge
+	if age <= 0 {
+		age = DefaultUninitializedGroupAge
+	}
+
+	pageSize := srvc.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	groups, err := finder.UninitializedGroups(ctx, age, pageSize)
+	if err != nil {
+		return fmt.Errorf("failed to find uninitialized groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	// a saved group is initialized when the registration of its task is resumed
+	tasks, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"info.dependencies.group_id": lo.ToAnySlice(groups),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load tasks referencing groups: %w", err)
+	}
+
+	referenced := map[string]struct{}{}
+	for _, task := range tasks {
+		dependencies, _ := task.Info["dependencies"].(map[string]any)
+		if groupId, ok := dependencies["group_id"].(string); ok {
+			referenced[groupId] = struct{}{}
+		}
+	}
+
+	abandoned := lo.Filter(groups, func(groupId string, _ int) bool {
+		_, ok := referenced[groupId]
+		return !ok
+	})
+	report.UninitializedGroups = append(report.UninitializedGroups, abandoned...)
+
+	if srvc.DryRun || len(abandoned) == 0 {
+		return nil
+	}
+
+	if err := srvc.Dependencies.DeleteGroups(ctx, abandoned...); err != nil {
+		return fmt.Errorf("failed to delete dependency groups: %w", err)
+	}
+
+	return nil
+}
+
+func (srvc *Service) expired(tree []core.Task, now time.Time) bool {
+	for _, task := range tree {
+		if !finished(task) {
+			return false
+		}
+
+		retention, ok := srvc.retention(task)
+		if !ok {
+			return false
+		}
+
+		updatedAt, ok := task.UpdatedAt()
+		if !ok || now.Sub(updatedAt) < retention {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (srvc *Service) retention(task core.Task) (time.Duration, bool) {
+	retention, ok, err := task.Retention()
+	if err != nil {
+		srvc.Logger().Warn("failed to parse the retention",
+			slog.String("task_id", task.ID),
+			slog.String("error", err.Error()))
+		return 0, false
+	}
+	if ok {
+		return retention, true
+	}
+
+	taskType, _ := task.Info["type"].(string)
+	for _, policy := range srvc.Policies {
+		if policy.Type == taskType {
+			return policy.Retention, true
+		}
+	}
+
+	return srvc.Retention, srvc.Retention > 0
+}
+
+func (srvc *Service) sweep(ctx context.Context, tree []core.Task, report *Report) error {
+	taskIds := lo.Map(tree, func(task core.Task, _ int) string { return task.ID })
+
+	resources, err := srvc.unreferencedResources(ctx, tree)
+	if err != nil {
+		return err
+	}
+
+	groups := lo.FilterMap(tree, func(task core.Task, _ int) (string, bool) {
+		dependencies, _ := task.Info["dependencies"].(map[string]any)
+		groupId, ok := dependencies["group_id"].(string)
+		return groupId, ok && groupId != ""
+	})
+
+	report.Trees++
+	report.Tasks = append(report.Tasks, taskIds...)
+	report.Resources = append(report.Resources, resources...)
+	report.Groups = append(report.Groups, groups...)
+
+	if srvc.DryRun {
+		return nil
+	}
+
+	if err := srvc.deleteBindings(ctx, groups); err != nil {
+		return err
+	}
+
+	if err := srvc.Dependencies.DeleteGroups(ctx, groups...); err != nil {
+		return fmt.Errorf("failed to delete dependency groups: %w", err)
+	}
+
+	if err := srvc.TaskToGroup.Delete(ctx, taskIds...); err != nil {
+		return fmt.Errorf("failed to delete task-group bindings: %w", err)
+	}
+
+	if err := srvc.System.Resources().Dealloc(ctx, resources); err != nil {
+		return fmt.Errorf("failed to deallocate resources: %w", err)
+	}
+
+	if err := srvc.System.Tasks().Delete(ctx, taskIds); err != nil {
+		return fmt.Errorf("failed to delete tasks: %w", err)
+	}
+
+	return nil
+}
+
+// deleteBindings deletes bindings of the groups' dependencies from the resolvers
+func (srvc *Service) deleteBindings(ctx context.Context, groupIds []string) error {
+	if len(groupIds) == 0 || len(srvc.Resolvers) == 0 {
+		return nil
+	}
+
+	loaded, err := srvc.Dependencies.LoadGroups(ctx, groupIds...)
+	if err != nil {
+		return fmt.Errorf("failed to load dependency groups: %w", err)
+	}
+
+	var dependencyIds []string
+	for _, group := range loaded {
+		for _, dep := range group.Dependencies {
+			dependencyIds = append(dependencyIds, dep.ID)
+		}
+	}
+
+	if len(dependencyIds) == 0 {
+		return nil
+	}
+
+	for name, resolver := range srvc.Resolvers {
+		collectable, ok := resolver.(manager.CollectableResolver)
+		if !ok {
+			continue
+		}
+
+		if err := collectable.DeleteBindings(ctx, dependencyIds...); err != nil {
+			return fmt.Errorf("failed to delete bindings of the '%s' resolver: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// unreferencedResources returns resources of the tree that are not used by unfinished tasks outside of it
+func (srvc *Service) unreferencedResources(ctx context.Context, tree []core.Task) ([]string, error) {
+	inTree := map[string]struct{}{}
+	var resources []any
+	for _, task := range tree {
+		inTree[task.ID] = struct{}{}
+		for _, id := range append(task.Inputs, task.Outputs...) {
+			resources = append(resources, id)
+		}
+	}
+	resources = lo.Uniq(resources)
+
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	referenced := map[string]struct{}{}
+	for _, field := range []string{"inputs", "outputs"} {
+		tasks, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+			field: resources,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tasks referencing resources: %w", err)
+		}
+
+		for _, task := range tasks {
+			if _, ok := inTree[task.ID]; ok || finished(task) {
+				continue
+			}
+
+			for _, id := range append(task.Inputs, task.Outputs...) {
+				referenced[id] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		id := resource.(string)
+		if _, ok := referenced[id]; !ok {
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
+// finished reports whether the task will never change
+func finished(task core.Task) bool {
+	switch task.Status() {
+	case core.TaskStatuses.Finished:
+		// a retry of the task is about to be spawned
+		restartedBy, _ := task.Info["restarted_by"].(string)
+		return task.Info["retry_status"] != retrier.RetryStatuses.Scheduled || restartedBy != ""
+	case core.TaskStatuses.Cancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// collectTree returns every task connected to the root by execution contexts or parent-child relations
+func collectTree(ctx context.Context, system core.AbstractSystem, root core.Task) ([]core.Task, error) {
+	visited := map[string]struct{}{root.ID: {}}
+	visitedContexts := map[string]struct{}{}
+	result := []core.Task{root}
+
+	frontier := []core.Task{root}
+	for len(frontier) > 0 {
+		var (
+			ids        []any
+			contextIds []any
+			parentIds  []any
+		)
+		for _, task := range frontier {
+			ids = append(ids, task.ID)
+
+			contextId := task.ContextID()
+			if _, ok := visitedContexts[contextId]; contextId != "" && !ok {
+				visitedContexts[contextId] = struct{}{}
+				contextIds = append(contextIds, contextId)
+			}
+
+			if parentId, ok := task.Info["context_parent_id"].(string); ok && parentId != "" {
+				parentIds = append(parentIds, parentId)
+			}
+		}
+
+		var related []core.Task
+		for _, filter := range []map[string][]any{
+			{"info.context_parent_id": ids},
+			{"info.context_id": contextIds},
+			{"id": parentIds},
+		} {
+			if len(lo.Flatten(lo.Values(filter))) == 0 {
+				continue
+			}
+
+			tasks, err := system.Tasks().GetWithProperties(ctx, filter)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load related tasks: %w", err)
+			}
+			related = append(related, tasks...)
+		}
+
+		frontier = nil
+		for _, task := range related {
+			if _, ok := visited[task.ID]; ok {
+				continue
+			}
+			visited[task.ID] = struct{}{}
+
+			result = append(result, task)
+			frontier = append(frontier, task)
+		}
+	}
+
+	return result, nil
+}
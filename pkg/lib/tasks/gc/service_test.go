@@ -0,0 +1,279 @@
+package gc
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/common/dependency"
+	"github.com/ischenkx/kantoku/pkg/common/dependency/inmem"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	brokerinmem "github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/resolvers/timer_resolver"
+	"github.com/ischenkx/kantoku/pkg/core/services/scheduler/dependencies/manager/task2group"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fixture struct {
+	t   *testing.T
+	ctx context.Context
+	sys *core.System
+	gc  *Service
+	now time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(brokerinmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		taskdb.New(),
+		logger,
+	)
+
+	return &fixture{
+		t:   t,
+		ctx: context.Background(),
+		sys: sys,
+		gc: &Service{
+			System:       sys,
+			Dependencies: inmem.New(),
+			TaskToGroup:  task2group.NewMemoryStorage(),
+			Retention:    24 * time.Hour,
+			Policies:     []Policy{{Type: "report", Retention: 7 * 24 * time.Hour}},
+			Interval:     time.Hour,
+			Core:         service.NewCore("gc", "test", logger),
+		},
+		now: time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// spawn creates a task with an initialized output in the status that was set "age" ago
+func (f *fixture) spawn(status string, age time.Duration, options ...core.Option) core.Task {
+	outputs, err := f.sys.Resources().Alloc(f.ctx, 1)
+	if err != nil {
+		f.t.Fatalf("failed to allocate a resource: %s", err)
+	}
+	if err := f.sys.Resources().Init(f.ctx, []core.Resource{{ID: outputs[0], Data: []byte("42")}}); err != nil {
+		f.t.Fatalf("failed to initialize a resource: %s", err)
+	}
+
+	task, err := f.sys.Spawn(f.ctx, core.New(append(options, taskopts.WithOutputs(outputs...))...))
+	if err != nil {
+		f.t.Fatalf("failed to spawn a task: %s", err)
+	}
+
+	properties := map[string]any{
+		"info.status":     status,
+		"info.updated_at": f.now.Add(-age).Unix(),
+	}
+	if status == core.TaskStatuses.Finished || status == core.TaskStatuses.Cancelled {
+		properties["info.finished_at"] = f.now.Add(-age).Unix()
+	}
+
+	err = f.sys.Tasks().UpdateByIDs(f.ctx, []string{task.ID}, properties)
+	if err != nil {
+		f.t.Fatalf("failed to update a task: %s", err)
+	}
+
+	return task
+}
+
+func (f *fixture) exists(id string) bool {
+	tasks, err := f.sys.Tasks().ByIDs(f.ctx, []string{id})
+	if err != nil {
+		f.t.Fatalf("failed to load a task: %s", err)
+	}
+
+	return len(tasks) > 0
+}
+
+func TestCollect(t *testing.T) {
+	f := newFixture(t)
+	finished := core.TaskStatuses.Finished
+
+	// an old tree: a task with a child task in another context
+	parent := f.spawn(finished, 48*time.Hour)
+	child := f.spawn(finished, 48*time.Hour, taskopts.WithProperty("context_parent_id", parent.ID))
+
+	// a tree with a running task
+	running := f.spawn(core.TaskStatuses.Received, time.Minute)
+	runningSibling := f.spawn(finished, 48*time.Hour, taskopts.WithContextID(running.ContextID()))
+
+	// retention of the type and of the task itself
+	report := f.spawn(finished, 48*time.Hour, taskopts.WithType("report"))
+	kept := f.spawn(finished, 48*time.Hour, taskopts.WithRetention(72*time.Hour))
+
+	// an old task whose output is read by a running task
+	shared := f.spawn(finished, 48*time.Hour)
+	reader := f.spawn(core.TaskStatuses.Ready, time.Minute, taskopts.WithInputs(shared.Outputs...))
+
+	f.gc.DryRun = true
+	dryReport, err := f.gc.Collect(f.ctx, f.now)
+	if err != nil {
+		t.Fatalf("failed to collect: %s", err)
+	}
+	if !f.exists(parent.ID) {
+		t.Fatal("a task has been deleted in the dry-run mode")
+	}
+
+	f.gc.DryRun = false
+	result, err := f.gc.Collect(f.ctx, f.now)
+	if err != nil {
+		t.Fatalf("failed to collect: %s", err)
+	}
+
+	if result.Trees != 2 || dryReport.Trees != result.Trees {
+		t.Fatalf("unexpected amount of collected trees: %d (dry run: %d)", result.Trees, dryReport.Trees)
+	}
+
+	for _, task := range []core.Task{parent, child, shared} {
+		if f.exists(task.ID) {
+			t.Fatalf("task '%s' has not been deleted", task.ID)
+		}
+	}
+
+	for _, task := range []core.Task{running, runningSibling, report, kept, reader} {
+		if !f.exists(task.ID) {
+			t.Fatalf("task '%s' has been deleted", task.ID)
+		}
+	}
+
+	if !slices.Contains(result.Resources, parent.Outputs[0]) || slices.Contains(result.Resources, shared.Outputs[0]) {
+		t.Fatalf("unexpected deallocated resources: %v", result.Resources)
+	}
+
+	resources, err := f.sys.Resources().Load(f.ctx, shared.Outputs[0])
+	if err != nil {
+		t.Fatalf("failed to load a resource: %s", err)
+	}
+	if resources[0].Status == core.ResourceStatuses.DoesNotExist {
+		t.Fatal("a resource referenced by a running task has been deallocated")
+	}
+}
+
+func TestCollectByPages(t *testing.T) {
+	f := newFixture(t)
+	f.gc.PageSize = 2
+
+	var tasks []core.Task
+	for i := 0; i < 5; i++ {
+		tasks = append(tasks, f.spawn(core.TaskStatuses.Finished, time.Duration(48+i)*time.Hour))
+	}
+
+	// every run looks at a page, the last page is shorter, so the cursor wraps around
+	for run, expected := range []int{2, 2, 1, 0} {
+		report, err := f.gc.Collect(f.ctx, f.now)
+		if err != nil {
+			t.Fatalf("run %d: failed to collect: %s", run, err)
+		}
+
+		if report.Trees != expected {
+			t.Fatalf("run %d: expected %d collected trees, got %d", run, expected, report.Trees)
+		}
+	}
+
+	for _, task := range tasks {
+		if f.exists(task.ID) {
+			t.Fatalf("task '%s' has not been deleted", task.ID)
+		}
+	}
+}
+
+func TestCollectDeletesBindings(t *testing.T) {
+	f := newFixture(t)
+
+	timers := timerResolver.NewMemoryStorage()
+	f.gc.Resolvers = map[string]manager.Resolver{
+		"timer": &timerResolver.Resolver{Storage: timers},
+	}
+
+	deps, err := f.gc.Dependencies.NewDependencies(f.ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to create dependencies: %s", err)
+	}
+
+	groupId, err := f.gc.Dependencies.NewGroup(f.ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+
+	if err := f.gc.Dependencies.InitializeGroup(f.ctx, groupId, dependency.AllOf(), deps[0].ID); err != nil {
+		t.Fatalf("failed to initialize a group: %s", err)
+	}
+
+	if err := f.gc.Resolvers["timer"].Bind(f.ctx, deps[0].ID, f.now.Add(time.Hour).UnixMilli()); err != nil {
+		t.Fatalf("failed to bind: %s", err)
+	}
+
+	f.spawn(core.TaskStatuses.Finished, 48*time.Hour,
+		taskopts.WithProperty("dependencies", map[string]any{"group_id": groupId}))
+
+	if _, err := f.gc.Collect(f.ctx, f.now); err != nil {
+		t.Fatalf("failed to collect: %s", err)
+	}
+
+	bindings, err := timers.Poll(f.ctx, f.now.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("failed to poll: %s", err)
+	}
+
+	if len(bindings) != 0 {
+		t.Fatalf("bindings have not been deleted: %v", bindings)
+	}
+}
+
+func TestCollectUninitializedGroups(t *testing.T) {
+	f := newFixture(t)
+	f.gc.UninitializedGroupAge = time.Millisecond
+
+	abandoned, err := f.gc.Dependencies.NewGroup(f.ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+
+	// the registration of the task is interrupted after saving its group
+	saved, err := f.gc.Dependencies.NewGroup(f.ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+	f.spawn(core.TaskStatuses.Ready, 0,
+		taskopts.WithProperty("dependencies", map[string]any{"group_id": saved}))
+
+	time.Sleep(10 * time.Millisecond)
+
+	fresh, err := f.gc.Dependencies.NewGroup(f.ctx)
+	if err != nil {
+		t.Fatalf("failed to create a group: %s", err)
+	}
+
+	report, err := f.gc.Collect(f.ctx, f.now)
+	if err != nil {
+		t.Fatalf("failed to collect: %s", err)
+	}
+
+	if !slices.Equal(report.UninitializedGroups, []string{abandoned}) {
+		t.Fatalf("unexpected uninitialized groups: %v", report.UninitializedGroups)
+	}
+
+	finder := f.gc.Dependencies.(dependency.UninitializedGroupsFinder)
+	left, err := finder.UninitializedGroups(f.ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to find uninitialized groups: %s", err)
+	}
+
+	slices.Sort(left)
+	expected := []string{saved, fresh}
+	slices.Sort(expected)
+	if !slices.Equal(left, expected) {
+		t.Fatalf("unexpected groups left: %v (expected %v)", left, expected)
+	}
+}
@@ -5,80 +5,63 @@ import (
 	"fmt"
 	"github.com/ischenkx/kantoku/pkg/core"
 	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/samber/lo"
 )
 
+// Restart spawns a copy of a failed task (with the options of the task, see copyInfo).
+//
+// The original task keeps its finished status and is linked to the copy by info.restarted_by,
+// the copy is linked back by info.restart_parent (and info.restart_root for chains of restarts).
 func Restart(ctx context.Context, system core.AbstractSystem, id string, infoCopiers ...InfoCopier) (newTaskID string, err error) {
 	t, err := system.Task(ctx, id)
 	if err != nil {
 		return "", fmt.Errorf("failed to get task: %w", err)
 	}
 
-	rawStatus, ok := t.Info["status"]
-	if !ok {
-		return "", fmt.Errorf("no task status")
-	}
-
-	status, ok := rawStatus.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid task status type (not string)")
-	}
-
-	if status != core.TaskStatuses.Finished {
-		return "", fmt.Errorf("task is not finished")
-	}
-
-	rawSubStatus, ok := t.Info["sub_status"]
-	if !ok {
-		return "", fmt.Errorf("no task sub_status")
-	}
-
-	subStatus, ok := rawSubStatus.(string)
-	if !ok {
-		return "", fmt.Errorf("invalid task sub_status type (not string)")
+	var restartRoot any = t.ID
+	if parentRestartRoot, ok := t.Info["restart_root"]; ok {
+		restartRoot = parentRestartRoot
 	}
 
-	if subStatus != core.TaskSubStatuses.Failed {
-		return "", fmt.Errorf("task is not failed")
+	// only failed tasks can be restarted and the claim is atomic, so a task is restarted at most once
+	err = core.TaskStates.Transition(ctx, system.Tasks(), id, core.TaskStatuses.Finished, t.SubStatus(), map[string]any{
+		core.RestartedFlag:  true,
+		"info.restart_root": restartRoot,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to claim the task: %w", err)
 	}
 
-	if rawRestarted, ok := t.Info["restarted"]; ok {
-		if restarted, asserted := rawRestarted.(bool); restarted && asserted {
-			return "", fmt.Errorf("already restarted")
+	newTask, err := spawn(ctx, system, t, restartRoot, infoCopiers)
+	if err != nil {
+		// the claim is released, so the restart can be repeated
+		if rollbackErr := rollback(ctx, system, t); rollbackErr != nil {
+			return "", fmt.Errorf("%w (failed to roll back the restart: %s)", err, rollbackErr)
 		}
+		return "", err
 	}
 
-	var restartRoot any = t.ID
-	if parentRestartRoot, ok := t.Info["restart_root"]; ok {
-		restartRoot = parentRestartRoot
-	}
-
-	modified, err := system.Tasks().UpdateWithProperties(ctx,
-		map[string][]any{
-			"info.restarted": {nil},
-			"id":             {id},
-		},
-		map[string]any{
-			"info.restarted":    true,
-			"info.restart_root": restartRoot,
-		},
+	_, err = system.Tasks().UpdateWithProperties(ctx,
+		map[string][]any{"id": {id}},
+		map[string]any{"info.restarted_by": newTask.ID},
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to update: %w", err)
+		return "", fmt.Errorf("failed to link the new task (new_task_id='%s'): %w", newTask.ID, err)
 	}
 
-	if modified != 1 {
-		return "", fmt.Errorf("no task updated")
-	}
+	return newTask.ID, nil
+}
 
+func spawn(ctx context.Context, system core.AbstractSystem, t core.Task, restartRoot any, infoCopiers []InfoCopier) (core.Task, error) {
 	newInfo := make(map[string]any)
-	copyEssentialInfo(t.Info, newInfo)
+	copyInfo(t.Info, newInfo)
 
 	for _, copier := range infoCopiers {
 		if copier == nil {
 			continue
 		}
 		if err := copier(ctx, system, t, newInfo); err != nil {
-			return "", fmt.Errorf("failed to copy info: %w", err)
+			return core.Task{}, fmt.Errorf("failed to copy info: %w", err)
 		}
 	}
 
@@ -89,23 +72,57 @@ func Restart(ctx context.Context, system core.AbstractSystem, id string, infoCop
 		taskopts.WithProperty("restart_parent", t.ID),
 		taskopts.WithProperty("restart_root", restartRoot),
 	))
-	// TODO: rollback a restart (we might need another service that would persistently try to restart tasks)
 	if err != nil {
-		return "", fmt.Errorf("failed to spawn a new task: %w", err)
+		return core.Task{}, fmt.Errorf("failed to spawn a new task: %w", err)
 	}
 
-	return newTask.ID, nil
+	return newTask, nil
+}
+
+func rollback(ctx context.Context, system core.AbstractSystem, t core.Task) error {
+	_, err := system.Tasks().UpdateWithProperties(ctx,
+		map[string][]any{
+			"id":                {t.ID},
+			core.RestartedFlag:  {true},
+			"info.restarted_by": {nil},
+		},
+		map[string]any{
+			core.RestartedFlag: false,
+		},
+	)
+
+	return err
 }
 
 type InfoCopier func(ctx context.Context, system core.AbstractSystem, oldTask core.Task, newTaskInfo map[string]any) error
 
-func copyEssentialInfo(oldInfo, newInfo map[string]any) {
-	typ, ok := oldInfo["type"]
-	if ok {
-		newInfo["type"] = typ
-	}
-	contextId, ok := oldInfo["context_id"]
-	if ok {
-		newInfo["context_id"] = contextId
+// runtimeInfo is the info set while a task is processed, the rest of the info is set by users (and spawners)
+var runtimeInfo = []string{
+	"status",
+	"sub_status",
+	"updated_at",
+	"finished_at",
+	"cancel_reason",
+	"failure_reason",
+	"error_code",
+	"result",
+	core.OutboxInfoKey,
+	// the dependencies of the original task have been satisfied
+	"dependencies",
+	"restarted",
+	"restarted_by",
+	"restart_parent",
+	"restart_root",
+	"retry_at",
+	"retry_status",
+	"retry_attempt",
+}
+
+// copyInfo copies every option of the old task (e.g. type, context_id, timeout, retry, deadline, retention)
+func copyInfo(oldInfo, newInfo map[string]any) {
+	for key, value := range oldInfo {
+		if !lo.Contains(runtimeInfo, key) {
+			newInfo[key] = value
+		}
 	}
 }
@@ -0,0 +1,91 @@
+package restarter
+
+import (
+	"context"
+	"errors"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker/inmem"
+	"github.com/ischenkx/kantoku/pkg/core"
+	eventbroker "github.com/ischenkx/kantoku/pkg/core/database/event_broker"
+	resourcedb "github.com/ischenkx/kantoku/pkg/core/database/resource_db"
+	taskdb "github.com/ischenkx/kantoku/pkg/core/database/task_db/inmem"
+	"log/slog"
+	"testing"
+)
+
+func TestRestart(t *testing.T) {
+	ctx := context.Background()
+
+	tasks := taskdb.New()
+	sys := core.NewSystem(
+		eventbroker.WrapCommonBroker(inmem.New[core.Event]()),
+		resourcedb.NewMockDB(),
+		tasks,
+		slog.Default(),
+	)
+
+	err := tasks.Insert(ctx, []core.Task{
+		{ID: "ok", Info: map[string]any{"status": core.TaskStatuses.Finished, "sub_status": core.TaskSubStatuses.OK}},
+		{ID: "failed", Info: map[string]any{
+			"status":              core.TaskStatuses.Finished,
+			"sub_status":          core.TaskSubStatuses.Failed,
+			"failure_reason":      core.TaskFailureReasons.UpstreamFailed,
+			"type":                "report",
+			"timeout":             "1m",
+			"deadline":            int64(1700000000),
+			"retention":           "24h",
+			"on_upstream_failure": core.UpstreamFailurePolicies.Run,
+			"context_parent_id":   "parent",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to insert: %s", err)
+	}
+
+	if _, err := Restart(ctx, sys, "ok"); !errors.Is(err, core.ErrIllegalTransition) {
+		t.Fatalf("a successful task must not be restarted, got %v", err)
+	}
+
+	newTaskID, err := Restart(ctx, sys, "failed")
+	if err != nil {
+		t.Fatalf("failed to restart: %s", err)
+	}
+
+	// only the first restart is legal
+	if _, err := Restart(ctx, sys, "failed"); !errors.Is(err, core.ErrIllegalTransition) {
+		t.Fatalf("a task must be restarted at most once, got %v", err)
+	}
+
+	original, err := sys.Task(ctx, "failed")
+	if err != nil {
+		t.Fatalf("failed to load the task: %s", err)
+	}
+
+	if original.Status() != core.TaskStatuses.Finished || original.SubStatus() != core.TaskSubStatuses.Failed {
+		t.Fatalf("the restarted task must keep its status, got %s/%s", original.Status(), original.SubStatus())
+	}
+
+	if original.Info["restarted_by"] != newTaskID {
+		t.Fatalf("expected the task to be restarted by '%s', got %v", newTaskID, original.Info["restarted_by"])
+	}
+
+	retry, err := sys.Task(ctx, newTaskID)
+	if err != nil {
+		t.Fatalf("failed to load the retry: %s", err)
+	}
+
+	if retry.Info["restart_parent"] != "failed" || retry.Info["restart_root"] != "failed" {
+		t.Fatalf("unexpected info of the retry: %v", retry.Info)
+	}
+
+	// options are copied, the state of the original task is not
+	for _, key := range []string{"type", "timeout", "deadline", "retention", "on_upstream_failure", "context_parent_id"} {
+		if retry.Info[key] != original.Info[key] {
+			t.Errorf("the option '%s' has not been copied: %v", key, retry.Info[key])
+		}
+	}
+	for _, key := range []string{"sub_status", "failure_reason", "restarted"} {
+		if _, ok := retry.Info[key]; ok {
+			t.Errorf("the state '%s' has been copied: %v", key, retry.Info[key])
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package retrier
+
+import (
+	"context"
+	"fmt"
+	codec "github.com/ischenkx/kantoku/pkg/common/data/codec"
+	"github.com/ischenkx/kantoku/pkg/common/service"
+	"github.com/ischenkx/kantoku/pkg/common/transport/broker"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/services/executor"
+	"github.com/ischenkx/kantoku/pkg/lib/tasks/restarter"
+	"log/slog"
+	"time"
+)
+
+var QueueName = "retrier"
+
+var RetryStatuses = struct {
+	Scheduled string
+}{
+	Scheduled: "scheduled",
+}
+
+// Service restarts failed tasks according to their retry policies (see core.RetryPolicy).
+//
+// A retry is scheduled when a "finished" event arrives (info.retry_status and info.retry_at in unix milliseconds)
+// and performed by a poller, so scheduled retries survive restarts of the service.
+// Retries are spawned by restarter.Restart, so they can be found by info.restarted_by / info.restart_root / info.restart_parent.
+type Service struct {
+	System      core.AbstractSystem
+	ResultCodec codec.Codec[executor.Result, []byte]
+	// Interval between scans of scheduled retries
+	Interval time.Duration
+
+	service.Core
+}
+
+func (srvc *Service) Run(ctx context.Context) error {
+	evs, err := srvc.System.
+		Events().
+		Consume(ctx,
+			[]string{core.OnTask.Finished},
+			broker.ConsumerSettings{
+				Group:                QueueName,
+				InitializationPolicy: broker.OldestOffset,
+			},
+		)
+	if err != nil {
+		return fmt.Errorf("failed to consume events: %w", err)
+	}
+
+	go broker.Processor[core.Event]{
+		Handler: func(ctx context.Context, ev core.Event) error {
+			result, err := srvc.ResultCodec.Decode(ev.Data)
+			if err != nil {
+				return fmt.Errorf("failed to decode the result: %w", err)
+			}
+
+			if err := srvc.Schedule(ctx, result.TaskID, string(result.Status), result.Code); err != nil {
+				return fmt.Errorf("failed to schedule a retry (task_id='%s'): %w", result.TaskID, err)
+			}
+
+			return nil
+		},
+		ErrorHandler: func(ctx context.Context, ev core.Event, err error) {
+			srvc.Logger().Error("processing failed",
+				slog.String("error", err.Error()))
+		},
+	}.Process(ctx, evs)
+
+	ticker := time.NewTicker(srvc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := srvc.Drain(ctx); err != nil {
+				srvc.Logger().Error("failed to retry tasks",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// Schedule schedules a retry of the task if its policy allows retrying the sub status and the error code
+func (srvc *Service) Schedule(ctx context.Context, id, subStatus, errorCode string) error {
+	if subStatus == core.TaskSubStatuses.OK {
+		return nil
+	}
+
+	task, err := srvc.System.Task(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load the task: %w", err)
+	}
+
+	policy, ok, err := task.RetryPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to parse the retry policy: %w", err)
+	}
+
+	attempt := task.RetryAttempt()
+	if !ok || !task.WillRetry(subStatus, errorCode) {
+		return nil
+	}
+
+	retryAt := time.Now().Add(policy.Backoff(attempt))
+
+	// the condition protects from rescheduling on redelivered events
+	_, err = srvc.System.Tasks().UpdateWithProperties(ctx,
+		map[string][]any{
+			"id":                {id},
+			"info.retry_status": {nil},
+		},
+		map[string]any{
+			"info.retry_status": RetryStatuses.Scheduled,
+			"info.retry_at":     retryAt.UnixMilli(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update the task: %w", err)
+	}
+
+	srvc.Logger().Debug("scheduled a retry",
+		slog.String("task_id", id),
+		slog.Int("attempt", attempt),
+		slog.Time("retry_at", retryAt))
+
+	return nil
+}
+
+// Drain restarts finished tasks whose retries are due
+func (srvc *Service) Drain(ctx context.Context) error {
+	tasks, err := srvc.System.Tasks().GetWithProperties(ctx, map[string][]any{
+		"info.status":       {core.TaskStatuses.Finished},
+		"info.retry_status": {RetryStatuses.Scheduled},
+		core.RestartedFlag:  {nil, false},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load scheduled tasks: %w", err)
+	}
+
+	now := time.Now()
+
+	for _, task := range tasks {
+		if retryAt, ok := task.RetryAt(); ok && retryAt.After(now) {
+			continue
+		}
+
+		newTaskID, err := restarter.Restart(ctx, srvc.System, task.ID, copyRetryInfo)
+		if err != nil {
+			srvc.Logger().Error("failed to retry the task",
+				slog.String("task_id", task.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		srvc.Logger().Info("retried the task",
+			slog.String("task_id", task.ID),
+			slog.String("new_task_id", newTaskID))
+	}
+
+	return nil
+}
+
+func copyRetryInfo(_ context.Context, _ core.AbstractSystem, oldTask core.Task, newTaskInfo map[string]any) error {
+	// the options of the task (including the retry policy) are copied by the restarter
+	newTaskInfo["retry_attempt"] = oldTask.RetryAttempt() + 1
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package specification
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type MemoryBinaryStorage struct {
+	data map[string][]byte
+	mu   sync.RWMutex
+}
+
+func NewMemoryBinaryStorage() *MemoryBinaryStorage {
+	return &MemoryBinaryStorage{
+		data: map[string][]byte{},
+	}
+}
+
+func (s *MemoryBinaryStorage) Get(ctx context.Context, id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// a missing item is not an error (same as in PostgresBinaryStorage)
+	return s.data[id], nil
+}
+
+func (s *MemoryBinaryStorage) GetAll(ctx context.Context) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.data))
+	for id := range s.data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, s.data[id])
+	}
+
+	return result, nil
+}
+
+func (s *MemoryBinaryStorage) Add(ctx context.Context, id string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[id] = append([]byte(nil), data...)
+
+	return nil
+}
+
+func (s *MemoryBinaryStorage) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, id)
+
+	return nil
+}
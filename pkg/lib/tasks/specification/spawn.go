@@ -0,0 +1,178 @@
+package specification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"github.com/samber/lo"
+	"log/slog"
+)
+
+// Spawn allocates resources for the specification's inputs and outputs,
+// initializes the inputs with the parameters and spawns a task depending on them.
+func Spawn(ctx context.Context, system core.AbstractSystem, spec Specification, parameters []string, info map[string]any) (core.Task, error) {
+	type txParams struct {
+		Inputs  []string
+		Outputs []string
+		Task    core.Task
+	}
+
+	if len(parameters) != len(spec.IO.Inputs.Types) {
+		return core.Task{}, fmt.Errorf("incorrect amount of input parameters: %d (expected %d)", len(parameters), len(spec.IO.Inputs.Types))
+	}
+
+	if timeout, ok := spec.Meta["timeout"]; ok {
+		if _, err := core.ParseTimeout(timeout); err != nil {
+			return core.Task{}, fmt.Errorf("%w: invalid specification timeout: %s", core.ErrInvalidTimeout, err)
+		}
+	}
+
+	if policy, ok := spec.Meta["retry"]; ok {
+		if _, err := core.ParseRetryPolicy(policy); err != nil {
+			return core.Task{}, fmt.Errorf("invalid specification retry policy: %w", err)
+		}
+	}
+
+	tx := lo.NewTransaction[txParams]().
+		Then(
+			func(params txParams) (txParams, error) {
+				// resource_db allocation
+				inputAmounts := len(spec.IO.Inputs.Types)
+				outputAmounts := len(spec.IO.Outputs.Types)
+				totalResources := inputAmounts + outputAmounts
+				resources, err := system.Resources().Alloc(ctx, totalResources)
+				if err != nil {
+					return params, fmt.Errorf("failed to allocate resources: %s", err)
+				}
+				params.Inputs = resources[:inputAmounts]
+				params.Outputs = resources[inputAmounts:]
+
+				return params, nil
+			},
+			func(params txParams) txParams {
+				err := system.Resources().Dealloc(ctx, append(params.Inputs, params.Outputs...))
+				if err != nil {
+					slog.Error("failed to dealloc resources", slog.String("error", err.Error()))
+				}
+				return params
+			},
+		).
+		Then(
+			func(params txParams) (txParams, error) {
+				initializedResources := make([]core.Resource, 0, len(params.Inputs))
+				for index := 0; index < len(params.Inputs); index++ {
+					initializedResources = append(initializedResources, core.Resource{
+						Data: []byte(parameters[index]),
+						ID:   params.Inputs[index],
+					})
+				}
+
+				err := system.Resources().Init(ctx, initializedResources)
+				if err != nil {
+					return params, fmt.Errorf("failed to initialize resources: %s", err)
+				}
+
+				return params, nil
+			},
+			func(params txParams) txParams {
+				return params
+			},
+		).
+		Then(
+			func(params txParams) (txParams, error) {
+				t, err := system.Spawn(ctx, core.New(
+					taskopts.WithInputs(params.Inputs...),
+					taskopts.WithOutputs(params.Outputs...),
+					taskopts.WithInfo(info),
+					taskopts.WithProperty("type", spec.ID),
+					taskopts.DependOnInputs(),
+					withDefaults(spec),
+				))
+				if err != nil {
+					return params, fmt.Errorf("failed to spawn a new task: %w", err)
+				}
+
+				params.Task = t
+
+				return params, nil
+			},
+			func(params txParams) txParams {
+				return params
+			},
+		)
+
+	result, err := tx.Process(txParams{})
+	if err != nil {
+		return core.Task{}, err
+	}
+
+	return result.Task, nil
+}
+
+// withDefaults sets task properties that are not set explicitly from the specification's meta
+func withDefaults(spec Specification) core.Option {
+	return func(t *core.Task) {
+		for _, key := range []string{"timeout", "retry"} {
+			value, ok := spec.Meta[key]
+			if !ok {
+				continue
+			}
+
+			if _, ok := t.Info[key]; !ok {
+				t.Info[key] = value
+			}
+		}
+	}
+}
+
+// Defaults sets properties of tasks from the specifications of their types (info.type), see core.System.SetDefaults
+type Defaults struct {
+	Specifications *Manager
+}
+
+// Apply loads the specification of every distinct type of the tasks once
+func (defaults Defaults) Apply(ctx context.Context, tasks []core.Task) error {
+	specs := map[string]*Specification{}
+
+	for i := range tasks {
+		typ, _ := tasks[i].Info["type"].(string)
+		if typ == "" {
+			continue
+		}
+
+		spec, ok := specs[typ]
+		if !ok {
+			var err error
+			if spec, err = defaults.load(ctx, typ); err != nil {
+				return err
+			}
+			specs[typ] = spec
+		}
+
+		// tasks of types without specifications have no defaults
+		if spec != nil {
+			withDefaults(*spec)(&tasks[i])
+		}
+	}
+
+	return nil
+}
+
+func (defaults Defaults) load(ctx context.Context, typ string) (*Specification, error) {
+	raw, err := defaults.Specifications.Specifications().Raw.Get(ctx, typ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the specification: %w", err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var spec Specification
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the specification: %w", err)
+	}
+
+	return &spec, nil
+}
@@ -0,0 +1,51 @@
+package specification
+
+import (
+	"context"
+	"github.com/ischenkx/kantoku/pkg/core"
+	"github.com/ischenkx/kantoku/pkg/core/taskopts"
+	"testing"
+)
+
+type countingStorage struct {
+	*MemoryBinaryStorage
+	gets int
+}
+
+func (s *countingStorage) Get(ctx context.Context, id string) ([]byte, error) {
+	s.gets++
+	return s.MemoryBinaryStorage.Get(ctx, id)
+}
+
+func TestDefaultsLoadEveryTypeOnce(t *testing.T) {
+	ctx := context.Background()
+
+	storage := &countingStorage{MemoryBinaryStorage: NewMemoryBinaryStorage()}
+	manager := NewManager(storage, NewMemoryBinaryStorage())
+
+	err := manager.Specifications().Add(ctx, Specification{ID: "slow", Meta: map[string]any{"timeout": "1m"}})
+	if err != nil {
+		t.Fatalf("failed to add a specification: %s", err)
+	}
+
+	tasks := []core.Task{
+		core.New(taskopts.WithType("slow")),
+		core.New(taskopts.WithType("slow"), taskopts.WithProperty("timeout", "5s")),
+		core.New(taskopts.WithType("unknown")),
+		core.New(taskopts.WithType("slow")),
+	}
+
+	if err := (Defaults{Specifications: manager}).Apply(ctx, tasks); err != nil {
+		t.Fatalf("failed to apply defaults: %s", err)
+	}
+
+	if storage.gets != 2 {
+		t.Fatalf("expected every type to be loaded once, got %d loads", storage.gets)
+	}
+
+	for i, expected := range []any{"1m", "5s", nil, "1m"} {
+		if tasks[i].Info["timeout"] != expected {
+			t.Errorf("task %d: expected the timeout %v, got %v", i, expected, tasks[i].Info["timeout"])
+		}
+	}
+}